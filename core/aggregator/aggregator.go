@@ -0,0 +1,325 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package aggregator
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/cache"
+	"github.com/sysflow-telemetry/sf-processor/core/flattener"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
+)
+
+const (
+	pluginName      string = "aggregator"
+	flatChannelName string = "flattenerchan"
+	evtChannelName  string = "eventchan"
+)
+
+// EnrichFlowRecords is the enrichment key of the number of flows summarized by a record.
+const EnrichFlowRecords = "flow.records"
+
+// FlowRecordsInt is the SysFlow attribute of flattened summaries holding the number of flows they
+// summarize. It follows the attributes defined by sf-apis, and is absent from other flat records.
+const FlowRecordsInt = sfgo.INT_ARRAY_SIZE
+
+// flowAttrs lists the flat record attributes of a flow type.
+type flowAttrs struct {
+	ts       sfgo.Attribute
+	endTs    sfgo.Attribute
+	opFlags  sfgo.Attribute
+	counters []sfgo.Attribute
+}
+
+// flowTypes maps the aggregated record types to their attributes.
+var flowTypes = map[int64]flowAttrs{
+	sfgo.FILE_FLOW: {ts: sfgo.FL_FILE_TS_INT, endTs: sfgo.FL_FILE_ENDTS_INT, opFlags: sfgo.FL_FILE_OPFLAGS_INT,
+		counters: []sfgo.Attribute{sfgo.FL_FILE_NUMRRECVOPS_INT, sfgo.FL_FILE_NUMWSENDOPS_INT, sfgo.FL_FILE_NUMRRECVBYTES_INT, sfgo.FL_FILE_NUMWSENDBYTES_INT}},
+	sfgo.NET_FLOW: {ts: sfgo.FL_NETW_TS_INT, endTs: sfgo.FL_NETW_ENDTS_INT, opFlags: sfgo.FL_NETW_OPFLAGS_INT,
+		counters: []sfgo.Attribute{sfgo.FL_NETW_NUMRRECVOPS_INT, sfgo.FL_NETW_NUMWSENDOPS_INT, sfgo.FL_NETW_NUMRRECVBYTES_INT, sfgo.FL_NETW_NUMWSENDBYTES_INT}},
+}
+
+// group summarizes the flows of a key in a window.
+type group struct {
+	rec   *engine.Record
+	count int
+}
+
+// Aggregator defines a plugin that summarizes the file and network flows sharing a key over time
+// windows into a single record, and optionally samples flows by key. Flows that matched rules are
+// never sampled out. Other records are forwarded as they are. The aggregator reads and writes either
+// flattener channels (before the policy engine) or event channels (after the policy engine).
+type Aggregator struct {
+	config   Config
+	mappers  []engine.StrFieldMap
+	out      func(r *engine.Record)
+	closeOut func()
+	groups   map[string]*group
+	keys     []string
+	window   int64
+	idle     bool
+	pending  int64
+	flows    int
+	emitted  int
+	sampled  int
+}
+
+// NewAggregator creates a new plugin instance.
+func NewAggregator() plugins.SFProcessor {
+	return &Aggregator{groups: make(map[string]*group)}
+}
+
+// GetName returns the plugin name.
+func (s *Aggregator) GetName() string {
+	return pluginName
+}
+
+// Register registers plugin to plugin cache.
+func (s *Aggregator) Register(pc plugins.SFPluginCache) {
+	pc.AddProcessor(pluginName, NewAggregator)
+}
+
+// ConfigSchema returns the plugin config schema.
+func (s *Aggregator) ConfigSchema() schema.Schema {
+	return schema.Schema{Attrs: ConfigAttrs, In: []string{flatChannelName, evtChannelName}, Out: schema.SameAsIn}
+}
+
+// Init initializes the plugin with a configuration map.
+func (s *Aggregator) Init(conf map[string]string) error {
+	config, err := CreateConfig(conf)
+	if err != nil {
+		return err
+	}
+	s.config = config
+	for _, a := range s.config.Key {
+		s.mappers = append(s.mappers, engine.Mapper.MapStr(a))
+	}
+	return nil
+}
+
+// Process implements the main loop of the plugin. Summaries are emitted when a flow of a later
+// window is received, or when no records are received for a window.
+func (s *Aggregator) Process(ch interface{}, wg *sync.WaitGroup) {
+	defer wg.Done()
+	logger.Trace.Printf("Starting aggregator with key %s and window %v", strings.Join(s.config.Key, ","), s.config.Window)
+	ticker := time.NewTicker(s.config.Window)
+	defer ticker.Stop()
+	switch c := ch.(type) {
+	case *flattener.FlatChannel:
+		for open := true; open; {
+			select {
+			case fr, ok := <-c.In:
+				if open = ok; ok {
+					s.add(RestoreFlowCount(engine.NewRecord(*fr, cache.GetRecordInstance(fr))))
+				}
+			case <-ticker.C:
+				s.tick()
+			}
+		}
+	case *engine.RecordChannel:
+		for open := true; open; {
+			select {
+			case r, ok := <-c.In:
+				if open = ok; ok {
+					s.add(r)
+				}
+			case <-ticker.C:
+				s.tick()
+			}
+		}
+	}
+	s.flush()
+	logger.Info.Printf("Aggregated %d flows into %d records, sampling out %d flows", s.flows, s.emitted, s.sampled)
+	logger.Trace.Println("Input channel closed. Shutting down.")
+}
+
+// add aggregates a flow record into the group of its key, and forwards other records.
+func (s *Aggregator) add(r *engine.Record) {
+	s.idle = false
+	fa, ok := flowTypes[r.GetInt(sfgo.SF_REC_TYPE, sfgo.SYSFLOW_SRC)]
+	if !ok {
+		s.out(r)
+		return
+	}
+	s.flows++
+	w := s.config.Window.Nanoseconds()
+	ts := r.GetInt(fa.ts, sfgo.SYSFLOW_SRC)
+	if start := ts - ts%w; start > s.window {
+		s.flush()
+		s.window = start
+	}
+	key := s.key(r)
+	if s.config.Sample > 1 && len(r.Ctx.GetRules()) == 0 && hash(key)%s.config.Sample != 0 {
+		s.sampled++
+		return
+	}
+	atomic.AddInt64(&s.pending, 1)
+	g, ok := s.groups[key]
+	if !ok {
+		s.groups[key] = &group{rec: clone(r), count: flowCount(r)}
+		s.keys = append(s.keys, key)
+		return
+	}
+	g.count += flowCount(r)
+	ints, fints := g.rec.Fr.Ints[sfgo.SYSFLOW_IDX], r.Fr.Ints[sfgo.SYSFLOW_IDX]
+	for _, a := range fa.counters {
+		ints[a] += fints[a]
+	}
+	if fints[fa.ts] < ints[fa.ts] {
+		ints[fa.ts] = fints[fa.ts]
+	}
+	if fints[fa.endTs] > ints[fa.endTs] {
+		ints[fa.endTs] = fints[fa.endTs]
+	}
+	ints[fa.opFlags] |= fints[fa.opFlags]
+}
+
+// flowCount returns the number of flows summarized by a record, which is 1 for flows that have
+// not been aggregated.
+func flowCount(r *engine.Record) int {
+	if n, ok := r.Ctx.GetEnrichment()[EnrichFlowRecords].(int); ok && n > 0 {
+		return n
+	}
+	return 1
+}
+
+// key returns the aggregation key of a flow record, which includes its type and matching rules.
+func (s *Aggregator) key(r *engine.Record) string {
+	var b strings.Builder
+	b.WriteString(strconv.FormatInt(r.GetInt(sfgo.SF_REC_TYPE, sfgo.SYSFLOW_SRC), 10))
+	for _, m := range s.mappers {
+		b.WriteByte(0)
+		b.WriteString(m(r))
+	}
+	for _, rule := range r.Ctx.GetRules() {
+		b.WriteByte(0)
+		b.WriteString(rule.Name)
+	}
+	return b.String()
+}
+
+// hash returns a deterministic hash of an aggregation key.
+func hash(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return h.Sum64()
+}
+
+// clone copies a record so that the summary of its group can be updated without modifying
+// records shared with other stages.
+func clone(r *engine.Record) *engine.Record {
+	fr := r.Fr
+	fr.Ints = append([][]int64(nil), r.Fr.Ints...)
+	fr.Ints[sfgo.SYSFLOW_IDX] = append([]int64(nil), r.Fr.Ints[sfgo.SYSFLOW_IDX]...)
+	c := engine.NewRecord(fr, r.Cr)
+	for _, rule := range r.Ctx.GetRules() {
+		c.Ctx.AddRule(rule)
+	}
+	c.Ctx.SetTags(r.Ctx.GetTags())
+	if h := r.Ctx.GetHashes(); h != (engine.HashSet{}) {
+		c.Ctx.SetHashes(h)
+	}
+	for k, v := range r.Ctx.GetEnrichment() {
+		c.Ctx.AddEnrichment(k, v)
+	}
+	return c
+}
+
+// tick flushes the summaries if no records were received since the last tick.
+func (s *Aggregator) tick() {
+	if s.idle {
+		s.flush()
+	}
+	s.idle = true
+}
+
+// flush emits the summaries of the current window in the order in which their keys were seen.
+func (s *Aggregator) flush() {
+	for _, k := range s.keys {
+		g := s.groups[k]
+		g.rec.Ctx.AddEnrichment(EnrichFlowRecords, g.count)
+		s.out(g.rec)
+		s.emitted++
+	}
+	s.groups = make(map[string]*group)
+	s.keys = nil
+	atomic.StoreInt64(&s.pending, 0)
+}
+
+// InFlight returns the number of flows held in summaries.
+func (s *Aggregator) InFlight() int {
+	return int(atomic.LoadInt64(&s.pending))
+}
+
+// SetOutChan sets the output channel of the plugin.
+func (s *Aggregator) SetOutChan(ch interface{}) {
+	switch c := ch.(type) {
+	case *flattener.FlatChannel:
+		s.out = func(r *engine.Record) { c.In <- flatten(r) }
+		s.closeOut = func() { close(c.In) }
+	case *engine.RecordChannel:
+		s.out = func(r *engine.Record) { c.In <- r }
+		s.closeOut = func() { close(c.In) }
+	}
+}
+
+// flatten returns the flat record of a record, with the number of flows summarized by a summary
+// in the FlowRecordsInt attribute.
+func flatten(r *engine.Record) *sfgo.FlatRecord {
+	n, ok := r.Ctx.GetEnrichment()[EnrichFlowRecords].(int)
+	if !ok {
+		return &r.Fr
+	}
+	fr := r.Fr
+	fr.Ints = append([][]int64(nil), fr.Ints...)
+	ints := make([]int64, FlowRecordsInt+1)
+	copy(ints, fr.Ints[sfgo.SYSFLOW_IDX])
+	ints[FlowRecordsInt] = int64(n)
+	fr.Ints[sfgo.SYSFLOW_IDX] = ints
+	return &fr
+}
+
+// RestoreFlowCount sets the EnrichFlowRecords enrichment of a record created from a flattened
+// summary, and returns the record.
+func RestoreFlowCount(r *engine.Record) *engine.Record {
+	if len(r.Fr.Ints) > sfgo.SYSFLOW_IDX && len(r.Fr.Ints[sfgo.SYSFLOW_IDX]) > int(FlowRecordsInt) {
+		if n := r.Fr.Ints[sfgo.SYSFLOW_IDX][FlowRecordsInt]; n > 0 {
+			r.Ctx.AddEnrichment(EnrichFlowRecords, int(n))
+		}
+	}
+	return r
+}
+
+// Cleanup tears down plugin resources.
+func (s *Aggregator) Cleanup() {
+	logger.Trace.Println("Exiting ", pluginName)
+	if s.closeOut != nil {
+		s.closeOut()
+	}
+}
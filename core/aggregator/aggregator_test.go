@@ -0,0 +1,264 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package aggregator_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/aggregator"
+	"github.com/sysflow-telemetry/sf-processor/core/flattener"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+const (
+	base = int64(1600000000000000000)
+	ms   = int64(time.Millisecond)
+)
+
+// newFlow creates a flattened file flow of exe, with one read op of n bytes.
+func newFlow(exe string, ts int64, end int64, opFlags int64, n int64) *sfgo.FlatRecord {
+	fr := &sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}
+	ints := fr.Ints[sfgo.SYSFLOW_IDX]
+	ints[sfgo.SF_REC_TYPE] = sfgo.FILE_FLOW
+	ints[sfgo.FL_FILE_TS_INT] = ts
+	ints[sfgo.FL_FILE_ENDTS_INT] = end
+	ints[sfgo.FL_FILE_OPFLAGS_INT] = opFlags
+	ints[sfgo.FL_FILE_NUMRRECVOPS_INT] = 1
+	ints[sfgo.FL_FILE_NUMRRECVBYTES_INT] = n
+	fr.Strs[sfgo.SYSFLOW_IDX][sfgo.PROC_EXE_STR] = exe
+	fr.Strs[sfgo.SYSFLOW_IDX][sfgo.FILE_PATH_STR] = "/etc/hosts"
+	return fr
+}
+
+// newEvent creates a flattened process event of exe.
+func newEvent(exe string, ts int64) *sfgo.FlatRecord {
+	fr := newFlow(exe, ts, ts, 0, 0)
+	fr.Ints[sfgo.SYSFLOW_IDX][sfgo.SF_REC_TYPE] = sfgo.PROC_EVT
+	return fr
+}
+
+func newRecord(fr *sfgo.FlatRecord) *engine.Record {
+	return engine.NewRecord(*fr, nil)
+}
+
+// startAggregator runs an aggregator configured with conf, reading and writing channels of the
+// type of in.
+func startAggregator(t *testing.T, conf map[string]string, in interface{}, out interface{}) *sync.WaitGroup {
+	a := aggregator.NewAggregator()
+	assert.NoError(t, a.Init(conf))
+	a.SetOutChan(out)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		a.Process(in, &wg)
+		a.Cleanup()
+	}()
+	return &wg
+}
+
+// aggregate runs records through an aggregator on event channels, returning its output.
+func aggregate(t *testing.T, conf map[string]string, recs ...*engine.Record) []*engine.Record {
+	in := &engine.RecordChannel{In: make(chan *engine.Record, len(recs))}
+	out := &engine.RecordChannel{In: make(chan *engine.Record, len(recs)+1)}
+	for _, r := range recs {
+		in.In <- r
+	}
+	close(in.In)
+	wg := startAggregator(t, conf, in, out)
+	wg.Wait()
+	var res []*engine.Record
+	for r := range out.In {
+		res = append(res, r)
+	}
+	return res
+}
+
+func ints(r *engine.Record) []int64 {
+	return r.Fr.Ints[sfgo.SYSFLOW_IDX]
+}
+
+func TestWindowRollover(t *testing.T) {
+	evt := newRecord(newEvent("/bin/sh", base+2*ms))
+	res := aggregate(t, map[string]string{aggregator.WindowConfigKey: "1s"},
+		newRecord(newFlow("/bin/cat", base+10*ms, base+20*ms, sfgo.OP_READ_RECV, 100)),
+		evt,
+		newRecord(newFlow("/bin/cat", base+5*ms, base+30*ms, sfgo.OP_CLOSE, 50)),
+		newRecord(newFlow("/bin/ls", base+40*ms, base+50*ms, sfgo.OP_READ_RECV, 10)),
+		newRecord(newFlow("/bin/cat", base+1500*ms, base+1600*ms, sfgo.OP_READ_RECV, 7)))
+	assert.Len(t, res, 4)
+
+	// other records are forwarded as they arrive
+	assert.Same(t, evt, res[0])
+
+	// flows of the first window are summarized when a flow of the next window arrives
+	cat := ints(res[1])
+	assert.Equal(t, "/bin/cat", res[1].GetStr(sfgo.PROC_EXE_STR, sfgo.SYSFLOW_SRC))
+	assert.Equal(t, base+5*ms, cat[sfgo.FL_FILE_TS_INT])
+	assert.Equal(t, base+30*ms, cat[sfgo.FL_FILE_ENDTS_INT])
+	assert.Equal(t, int64(sfgo.OP_READ_RECV|sfgo.OP_CLOSE), cat[sfgo.FL_FILE_OPFLAGS_INT])
+	assert.Equal(t, int64(2), cat[sfgo.FL_FILE_NUMRRECVOPS_INT])
+	assert.Equal(t, int64(150), cat[sfgo.FL_FILE_NUMRRECVBYTES_INT])
+	assert.Equal(t, 2, res[1].Ctx.GetEnrichment()[aggregator.EnrichFlowRecords])
+	assert.Equal(t, "/bin/ls", res[2].GetStr(sfgo.PROC_EXE_STR, sfgo.SYSFLOW_SRC))
+	assert.Equal(t, 1, res[2].Ctx.GetEnrichment()[aggregator.EnrichFlowRecords])
+
+	// the last window is flushed when the input is closed
+	assert.Equal(t, base+1500*ms, ints(res[3])[sfgo.FL_FILE_TS_INT])
+	assert.Equal(t, int64(7), ints(res[3])[sfgo.FL_FILE_NUMRRECVBYTES_INT])
+}
+
+func TestSummaryCounts(t *testing.T) {
+	// summaries of summaries count the flows summarized by their records
+	s := newRecord(newFlow("/bin/cat", base, base, 0, 1))
+	s.Ctx.AddEnrichment(aggregator.EnrichFlowRecords, 3)
+	res := aggregate(t, map[string]string{}, s, newRecord(newFlow("/bin/cat", base, base, 0, 1)))
+	assert.Len(t, res, 1)
+	assert.Equal(t, 4, res[0].Ctx.GetEnrichment()[aggregator.EnrichFlowRecords])
+
+	// records that are not summaries have no count
+	assert.Nil(t, newRecord(newEvent("/bin/sh", base)).Ctx.GetEnrichment())
+
+	// flattened summaries carry their count to the records created from them
+	in := flattener.NewFlattenerChan(4).(*flattener.FlatChannel)
+	out := flattener.NewFlattenerChan(4).(*flattener.FlatChannel)
+	evt := newEvent("/bin/sh", base)
+	in.In <- newFlow("/bin/cat", base, base, 0, 1)
+	in.In <- evt
+	in.In <- newFlow("/bin/cat", base, base, 0, 1)
+	close(in.In)
+	startAggregator(t, map[string]string{}, in, out).Wait()
+	assert.Equal(t, evt, <-out.In)
+	fr := <-out.In
+	assert.Equal(t, int64(2), fr.Ints[sfgo.SYSFLOW_IDX][sfgo.FL_FILE_NUMRRECVOPS_INT])
+	assert.Equal(t, int64(2), fr.Ints[sfgo.SYSFLOW_IDX][aggregator.FlowRecordsInt])
+	r := aggregator.RestoreFlowCount(newRecord(fr))
+	assert.Equal(t, 2, r.Ctx.GetEnrichment()[aggregator.EnrichFlowRecords])
+	assert.Equal(t, "/bin/cat", r.GetStr(sfgo.PROC_EXE_STR, sfgo.SYSFLOW_SRC))
+	_, open := <-out.In
+	assert.False(t, open)
+	assert.Nil(t, aggregator.RestoreFlowCount(newRecord(evt)).Ctx.GetEnrichment())
+}
+
+const testPolicy = `
+- rule: Cat flow
+  desc: Unit test aggregated flows
+  condition: sf.type = FF and sf.proc.exe = /bin/cat
+  action: [alert]
+  priority: low
+  tags: [test]
+`
+
+func TestUpstreamOfPolicyEngine(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aggregator")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "policy.yaml"), []byte(testPolicy), 0644))
+
+	// the aggregator reads and writes flattener channels, feeding the policy engine
+	in := flattener.NewFlattenerChan(4).(*flattener.FlatChannel)
+	flat := flattener.NewFlattenerChan(4).(*flattener.FlatChannel)
+	out := &engine.RecordChannel{In: make(chan *engine.Record, 4)}
+	for _, fr := range []*sfgo.FlatRecord{
+		newFlow("/bin/cat", base, base, 0, 10),
+		newFlow("/bin/ls", base, base, 0, 1),
+		newFlow("/bin/cat", base+ms, base+ms, 0, 20),
+		newFlow("/bin/cat", base+2*ms, base+2*ms, 0, 30),
+	} {
+		in.In <- fr
+	}
+	close(in.In)
+	agg := startAggregator(t, map[string]string{}, in, flat)
+	pe := policyengine.NewPolicyEngine()
+	assert.NoError(t, pe.Init(map[string]string{engine.PoliciesConfigKey: dir}))
+	pe.SetOutChan(out)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		pe.Process(flat, &wg)
+		pe.Cleanup()
+	}()
+	agg.Wait()
+	wg.Wait()
+	var res []*engine.Record
+	for r := range out.In {
+		res = append(res, r)
+	}
+	assert.Len(t, res, 1)
+	assert.Equal(t, "Cat flow", res[0].Ctx.GetRules()[0].Name)
+	assert.Equal(t, int64(60), ints(res[0])[sfgo.FL_FILE_NUMRRECVBYTES_INT])
+	assert.Equal(t, 3, res[0].Ctx.GetEnrichment()[aggregator.EnrichFlowRecords])
+}
+
+func TestIdleFlush(t *testing.T) {
+	in := &engine.RecordChannel{In: make(chan *engine.Record)}
+	out := &engine.RecordChannel{In: make(chan *engine.Record, 2)}
+	wg := startAggregator(t, map[string]string{aggregator.WindowConfigKey: "20ms"}, in, out)
+	in.In <- newRecord(newFlow("/bin/cat", base, base, 0, 1))
+	in.In <- newRecord(newFlow("/bin/cat", base, base, 0, 1))
+	// summaries are emitted when no records arrive for a window, before the input is closed
+	select {
+	case r := <-out.In:
+		assert.Equal(t, 2, r.Ctx.GetEnrichment()[aggregator.EnrichFlowRecords])
+	case <-time.After(5 * time.Second):
+		t.Fatal("summary not flushed when idle")
+	}
+	close(in.In)
+	wg.Wait()
+	_, open := <-out.In
+	assert.False(t, open)
+}
+
+func TestSampling(t *testing.T) {
+	var recs []*engine.Record
+	for i := 0; i < 200; i++ {
+		recs = append(recs, newRecord(newFlow(fmt.Sprintf("/bin/p%d", i), base, base, 0, 1)))
+	}
+	conf := map[string]string{aggregator.SampleConfigKey: "4"}
+	kept := func(res []*engine.Record) []string {
+		var exes []string
+		for _, r := range res {
+			exes = append(exes, r.GetStr(sfgo.PROC_EXE_STR, sfgo.SYSFLOW_SRC))
+		}
+		return exes
+	}
+	first := kept(aggregate(t, conf, recs...))
+	assert.True(t, len(first) > 20 && len(first) < 80, len(first))
+	// keys are sampled deterministically
+	assert.Equal(t, first, kept(aggregate(t, conf, recs...)))
+
+	// flows that matched rules are not sampled out
+	for _, r := range recs {
+		r.Ctx.AddRule(engine.Rule{Name: "flows"})
+	}
+	assert.Len(t, aggregate(t, conf, recs...), len(recs))
+}
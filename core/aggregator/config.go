@@ -0,0 +1,113 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package aggregator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
+)
+
+// Configuration keys.
+const (
+	KeyConfigKey    string = "key"
+	WindowConfigKey string = "window"
+	SampleConfigKey string = "sample"
+)
+
+// Configuration defaults.
+const (
+	DefaultKey    string        = "sf.proc.exe,sf.file.path,sf.net.dip,sf.net.dport"
+	DefaultWindow time.Duration = 10 * time.Second
+)
+
+// Config defines a configuration object for the aggregator.
+type Config struct {
+	Key    []string
+	Window time.Duration
+	Sample uint64
+}
+
+// ConfigAttrs declares the aggregator configuration attributes.
+var ConfigAttrs = []schema.Attr{
+	{Key: KeyConfigKey, Type: schema.String, Default: DefaultKey, Check: func(v string) error {
+		_, err := parseKey(v)
+		return err
+	}},
+	{Key: WindowConfigKey, Type: schema.Duration, Default: DefaultWindow.String(), Check: func(v string) error {
+		if d, _ := time.ParseDuration(v); d <= 0 {
+			return errors.New("must be positive")
+		}
+		return nil
+	}},
+	{Key: SampleConfigKey, Type: schema.Int, Default: "1", Check: func(v string) error {
+		if n, _ := strconv.Atoi(v); n <= 0 {
+			return errors.New("must be positive")
+		}
+		return nil
+	}},
+}
+
+// CreateConfig creates a new config object from config dictionary.
+func CreateConfig(conf map[string]string) (Config, error) {
+	var c Config = Config{Window: DefaultWindow, Sample: 1}
+	var err error
+	v, ok := conf[KeyConfigKey]
+	if !ok {
+		v = DefaultKey
+	}
+	if c.Key, err = parseKey(v); err != nil {
+		return c, err
+	}
+	if v, ok := conf[WindowConfigKey]; ok {
+		if c.Window, err = time.ParseDuration(v); err != nil || c.Window <= 0 {
+			return c, fmt.Errorf("Configuration tag '%s' must be a positive duration: %s", WindowConfigKey, v)
+		}
+	}
+	if v, ok := conf[SampleConfigKey]; ok {
+		if c.Sample, err = strconv.ParseUint(v, 10, 64); err != nil || c.Sample == 0 {
+			return c, fmt.Errorf("Configuration tag '%s' must be a positive integer: %s", SampleConfigKey, v)
+		}
+	}
+	return c, nil
+}
+
+// parseKey parses a comma-separated list of record attributes.
+func parseKey(v string) ([]string, error) {
+	var key []string
+	for _, a := range strings.Split(v, ",") {
+		if a = strings.TrimSpace(a); a == "" {
+			continue
+		}
+		if !engine.Mapper.IsField(a) {
+			return nil, fmt.Errorf("unknown attribute '%s'", a)
+		}
+		key = append(key, a)
+	}
+	if len(key) == 0 {
+		return nil, errors.New("must list at least one attribute")
+	}
+	return key, nil
+}
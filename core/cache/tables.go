@@ -37,6 +37,22 @@ const (
 var instance *SFTables
 var once sync.Once
 
+// streamKey identifies the entity tables of a SysFlow stream. Entity OIDs are unique per
+// monitored host, so streams exported from the same host share their tables.
+type streamKey struct {
+	exporter string
+	ip       string
+}
+
+var streams = make(map[streamKey]*SFTables)
+var streamsMutex sync.RWMutex
+
+// released stands in for the tables of released streams, whose records may still be in flight.
+var released = newSFTables(cacheSize)
+
+// warned holds the released streams whose records were resolved to the released tables.
+var warned sync.Map
+
 // SFTables defines thread-safe shared cache for plugins for storing SysFlow entities.
 type SFTables struct {
 	contTable *cqueue.FIFO
@@ -54,6 +70,60 @@ func GetInstance() *SFTables {
 	return instance
 }
 
+// GetStreamInstance returns the SFTables instance of the stream exported by exporter from ip,
+// creating it on first use. Streams without exporter and ip share the singleton instance.
+func GetStreamInstance(exporter string, ip string) *SFTables {
+	if exporter == "" && ip == "" {
+		return GetInstance()
+	}
+	key := streamKey{exporter, ip}
+	streamsMutex.RLock()
+	t, ok := streams[key]
+	streamsMutex.RUnlock()
+	if ok {
+		return t
+	}
+	streamsMutex.Lock()
+	defer streamsMutex.Unlock()
+	if t, ok = streams[key]; !ok {
+		t = newSFTables(cacheSize)
+		streams[key] = t
+		warned.Delete(key)
+	}
+	return t
+}
+
+// ReleaseStreamInstance releases the SFTables instance of the stream exported by exporter from ip.
+// A later stream from the same exporter and ip starts with empty tables.
+func ReleaseStreamInstance(exporter string, ip string) {
+	streamsMutex.Lock()
+	defer streamsMutex.Unlock()
+	delete(streams, streamKey{exporter, ip})
+}
+
+// GetRecordInstance returns the SFTables instance of the stream a flattened record belongs to.
+// Records of released streams resolve to empty tables, which is logged once per stream.
+func GetRecordInstance(fr *sfgo.FlatRecord) *SFTables {
+	if len(fr.Strs) <= sfgo.SYSFLOW_IDX || len(fr.Strs[sfgo.SYSFLOW_IDX]) <= int(sfgo.SFHE_IP_STR) {
+		return GetInstance()
+	}
+	strs := fr.Strs[sfgo.SYSFLOW_IDX]
+	if strs[sfgo.SFHE_EXPORTER_STR] == "" && strs[sfgo.SFHE_IP_STR] == "" {
+		return GetInstance()
+	}
+	key := streamKey{strs[sfgo.SFHE_EXPORTER_STR], strs[sfgo.SFHE_IP_STR]}
+	streamsMutex.RLock()
+	t, ok := streams[key]
+	streamsMutex.RUnlock()
+	if ok {
+		return t
+	}
+	if _, ok := warned.LoadOrStore(key, true); !ok {
+		logger.Warn.Printf("Entity tables of stream from exporter %s (%s) were released, resolving its records without entities\n", key.exporter, key.ip)
+	}
+	return released
+}
+
 // newSFTables creates a new SFTables instance.
 func newSFTables(capacity int) *SFTables {
 	t := new(SFTables)
@@ -87,11 +157,11 @@ func (t *SFTables) reset(queue *cqueue.FIFO) {
 	}
 }
 
-// GetCont retrieves a cached container object by ID.
+// GetCont retrieves a cached container object by ID, looking up the most recent generation first.
 func (t *SFTables) GetCont(ID string) *sfgo.Container {
 	t.rwmutex.RLock()
 	defer t.rwmutex.RUnlock()
-	for i := 0; i < t.contTable.GetLen(); i++ {
+	for i := t.contTable.GetLen() - 1; i >= 0; i-- {
 		m, _ := t.contTable.Get(i)
 		table := m.(cmap.ConcurrentMap)
 		if v, ok := table.Get(ID); ok {
@@ -114,7 +184,7 @@ func (t *SFTables) SetCont(ID string, o *sfgo.Container) {
 func (t *SFTables) GetProc(ID sfgo.OID) *sfgo.Process {
 	t.rwmutex.RLock()
 	defer t.rwmutex.RUnlock()
-	for i := 0; i < t.procTable.GetLen(); i++ {
+	for i := t.procTable.GetLen() - 1; i >= 0; i-- {
 		m, _ := t.procTable.Get(i)
 		table := m.(cmap.ConcurrentMap)
 		if v, ok := table.Get(t.getHash(ID)); ok {
@@ -137,7 +207,7 @@ func (t *SFTables) SetProc(ID sfgo.OID, o *sfgo.Process) {
 func (t *SFTables) GetFile(ID sfgo.FOID) *sfgo.File {
 	t.rwmutex.RLock()
 	defer t.rwmutex.RUnlock()
-	for i := 0; i < t.fileTable.GetLen(); i++ {
+	for i := t.fileTable.GetLen() - 1; i >= 0; i-- {
 		m, _ := t.fileTable.Get(i)
 		table := m.(cmap.ConcurrentMap)
 		if v, ok := table.Get(t.getHash(ID)); ok {
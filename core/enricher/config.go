@@ -0,0 +1,70 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package enricher
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
+)
+
+// Configuration keys.
+const (
+	HandlersConfigKey string = "handlers"
+)
+
+// Config defines a configuration object for the enricher.
+type Config struct {
+	Handlers     []string
+	HandlerConfs map[string]string
+}
+
+// ConfigAttrs declares the enricher configuration attributes. Handler names are also config keys.
+var ConfigAttrs = []schema.Attr{
+	{Key: HandlersConfigKey, Type: schema.String, Required: true, Check: func(v string) error {
+		for _, h := range strings.Split(v, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				if _, err := engine.NewHandler(h); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}},
+}
+
+// CreateConfig creates a new config object from config dictionary. Handlers are given as a
+// comma-separated list, and each handler is configured by the value of its name key.
+func CreateConfig(conf map[string]string) (Config, error) {
+	var c Config = Config{HandlerConfs: make(map[string]string)}
+	v, ok := conf[HandlersConfigKey]
+	if !ok {
+		return c, errors.New("Configuration tag 'handlers' missing from enricher plugin settings")
+	}
+	for _, h := range strings.Split(v, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			c.Handlers = append(c.Handlers, h)
+			c.HandlerConfs[h] = conf[h]
+		}
+	}
+	return c, nil
+}
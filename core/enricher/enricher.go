@@ -0,0 +1,116 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package enricher
+
+import (
+	"sync"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
+)
+
+const (
+	pluginName  string = "enricher"
+	channelName string = "eventchan"
+)
+
+// Enricher defines a plugin that chains enrichment handlers between the policy engine and the exporter.
+type Enricher struct {
+	handlers []engine.Handler
+	outCh    chan *engine.Record
+	config   Config
+}
+
+// NewEnricher creates a new plugin instance.
+func NewEnricher() plugins.SFProcessor {
+	return new(Enricher)
+}
+
+// GetName returns the plugin name.
+func (s *Enricher) GetName() string {
+	return pluginName
+}
+
+// Register registers plugin to plugin cache.
+func (s *Enricher) Register(pc plugins.SFPluginCache) {
+	pc.AddProcessor(pluginName, NewEnricher)
+}
+
+// ConfigSchema returns the plugin config schema.
+func (s *Enricher) ConfigSchema() schema.Schema {
+	return schema.Schema{Attrs: ConfigAttrs, In: []string{channelName}, Out: channelName, Names: HandlersConfigKey}
+}
+
+// Init initializes the plugin with a configuration map.
+func (s *Enricher) Init(conf map[string]string) error {
+	config, err := CreateConfig(conf)
+	if err != nil {
+		return err
+	}
+	s.config = config
+	for _, name := range s.config.Handlers {
+		h, err := engine.NewHandler(name)
+		if err != nil {
+			return err
+		}
+		logger.Trace.Println("Initializing enrichment handler ", name)
+		if err := h.Init(s.config.HandlerConfs[name]); err != nil {
+			return err
+		}
+		s.handlers = append(s.handlers, h)
+	}
+	return nil
+}
+
+// Process implements the main loop of the plugin.
+func (s *Enricher) Process(ch interface{}, wg *sync.WaitGroup) {
+	in := ch.(*engine.RecordChannel).In
+	defer wg.Done()
+	logger.Trace.Printf("Starting enricher with %d handlers and channel capacity %d", len(s.handlers), cap(in))
+	for r := range in {
+		for _, h := range s.handlers {
+			if _, err := h.ProcessSync(r); err != nil {
+				logger.Warn.Println("Error enriching record: ", err)
+			}
+		}
+		s.outCh <- r
+	}
+	logger.Trace.Println("Input channel closed. Shutting down.")
+}
+
+// SetOutChan sets the output channel of the plugin.
+func (s *Enricher) SetOutChan(ch interface{}) {
+	s.outCh = (ch.(*engine.RecordChannel)).In
+}
+
+// Cleanup tears down plugin resources.
+func (s *Enricher) Cleanup() {
+	logger.Trace.Println("Exiting ", pluginName)
+	for _, h := range s.handlers {
+		if err := h.Cleanup(); err != nil {
+			logger.Warn.Println("Error cleaning up enrichment handler: ", err)
+		}
+	}
+	if s.outCh != nil {
+		close(s.outCh)
+	}
+}
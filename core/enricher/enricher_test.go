@@ -0,0 +1,109 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package enricher_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/enricher"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+// newRecord creates a process event record of a process with user ID uid.
+func newRecord(uid int64) *engine.Record {
+	fr := sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}
+	fr.Ints[sfgo.SYSFLOW_IDX][sfgo.SF_REC_TYPE] = sfgo.PROC_EVT
+	fr.Ints[sfgo.SYSFLOW_IDX][sfgo.PROC_UID_INT] = uid
+	return engine.NewRecord(fr, nil)
+}
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestInit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "enricher")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	passwd := writeFile(t, dir, "passwd", "alice:x:1000:1000::/home/alice:/bin/sh\n")
+	hosts := writeFile(t, dir, "hosts", "10.1.2.3 db.local\n")
+
+	// handlers are applied in the order in which they are listed
+	e := enricher.NewEnricher().(*enricher.Enricher)
+	assert.NoError(t, e.Init(map[string]string{enricher.HandlersConfigKey: "passwd, hostmeta,hosts", "passwd": passwd, "hosts": hosts, "hostmeta": "cluster=prod"}))
+	hs := e.Handlers()
+	assert.Len(t, hs, 3)
+	assert.IsType(t, &engine.PasswdHandler{}, hs[0])
+	assert.IsType(t, &engine.HostMetaHandler{}, hs[1])
+	assert.IsType(t, &engine.HostsHandler{}, hs[2])
+
+	for name, conf := range map[string]map[string]string{
+		"missing handlers": {},
+		"unknown handler":  {enricher.HandlersConfigKey: "hosts,dns", "hosts": hosts},
+		"handler config":   {enricher.HandlersConfigKey: "hostmeta", "hostmeta": "cluster"},
+		"handler file":     {enricher.HandlersConfigKey: "passwd", "passwd": filepath.Join(dir, "missing")},
+	} {
+		assert.Error(t, enricher.NewEnricher().Init(conf), name)
+	}
+	// unknown handlers are also reported by config validation
+	err = e.ConfigSchema().Validate(map[string]string{enricher.HandlersConfigKey: "hosts,dns"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Unrecognized enrichment handler: dns")
+}
+
+func TestProcess(t *testing.T) {
+	dir, err := ioutil.TempDir("", "enricher")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	e := enricher.NewEnricher()
+	// each handler is configured by the attribute named after it
+	assert.NoError(t, e.Init(map[string]string{
+		enricher.HandlersConfigKey: "hostmeta,passwd",
+		"hostmeta":                 "cluster=prod",
+		"passwd":                   writeFile(t, dir, "passwd", "alice:x:1000:1000::/home/alice:/bin/sh\n"),
+	}))
+	in := &engine.RecordChannel{In: make(chan *engine.Record, 2)}
+	out := &engine.RecordChannel{In: make(chan *engine.Record, 2)}
+	e.SetOutChan(out)
+	in.In <- newRecord(1000)
+	in.In <- newRecord(0)
+	close(in.In)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	e.Process(in, &wg)
+	e.Cleanup()
+	wg.Wait()
+	assert.Equal(t, map[string]interface{}{"host.cluster": "prod", engine.EnrichProcUser: "alice"}, (<-out.In).Ctx.GetEnrichment())
+	assert.Equal(t, map[string]interface{}{"host.cluster": "prod"}, (<-out.In).Ctx.GetEnrichment())
+	_, open := <-out.In
+	assert.False(t, open)
+}
@@ -20,7 +20,12 @@
 package exporter
 
 import (
+	"errors"
 	"strconv"
+	"strings"
+
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
 )
 
 // Configuration keys.
@@ -36,6 +41,11 @@ const (
 	PortConfigKey        string = "port"
 	PathConfigKey        string = "path"
 	EventBufferConfigKey string = "buffer"
+	SeverityConfigKey    string = "severity"
+	SinksConfigKey       string = "sinks"
+	MatchConfigKey       string = "match"
+	URLConfigKey         string = "url"
+	QueueConfigKey       string = "queue"
 	VersionKey           string = "version"
 	JSONSchemaVersionKey string = "jsonschemaversion"
 	BuildNumberKey       string = "buildnumber"
@@ -54,14 +64,39 @@ type Config struct {
 	Port              int
 	Path              string
 	EventBuffer       int
+	MinSeverity       engine.Severity
+	SeverityFilter    bool
+	Match             string
+	URL               string
+	QueueSize         int
 	Version           string
 	JSONSchemaVersion string
 	BuildNumber       string
 }
 
+// ConfigAttrs declares the exporter configuration attributes.
+var ConfigAttrs = []schema.Attr{
+	{Key: ExportConfigKey, Type: schema.Enum, Default: StdOutExport.String(), Values: []string{StdOutExport.String(), FileExport.String(), SyslogExport.String(), WebhookExport.String()}},
+	{Key: ExpTypeConfigKey, Type: schema.Enum, Default: TelemetryType.String(), Values: []string{TelemetryType.String(), BatchType.String()}},
+	{Key: FormatConfigKey, Type: schema.Enum, Default: JSONFormat.String(), Values: []string{JSONFormat.String()}},
+	{Key: FlatConfigKey, Type: schema.Bool, Default: "false"},
+	{Key: ProtoConfigKey, Type: schema.Enum, Default: TCPProto.String(), Values: []string{TCPProto.String(), TCPTLSProto.String(), UDPProto.String()}},
+	{Key: TagConfigKey, Type: schema.String, Default: "sysflow"},
+	{Key: LogSourceConfigKey, Type: schema.String},
+	{Key: HostConfigKey, Type: schema.String, Default: "localhost"},
+	{Key: PortConfigKey, Type: schema.Int, Default: "514"},
+	{Key: PathConfigKey, Type: schema.String, Default: "./export.out"},
+	{Key: EventBufferConfigKey, Type: schema.Int, Default: "0"},
+	{Key: SeverityConfigKey, Type: schema.String, Check: func(v string) error { _, err := engine.ParseSeverity(v); return err }},
+	{Key: SinksConfigKey, Type: schema.String},
+	{Key: MatchConfigKey, Type: schema.String, Check: func(v string) error { _, err := engine.CompileExpression(v); return err }},
+	{Key: URLConfigKey, Type: schema.String},
+	{Key: QueueConfigKey, Type: schema.Int, Default: strconv.Itoa(DefaultQueueSize)},
+}
+
 // CreateConfig creates a new config object from config dictionary.
-func CreateConfig(conf map[string]string) Config {
-	var c Config = Config{Host: "localhost", Port: 514, Path: "./export.out", Tag: "sysflow"} // default values
+func CreateConfig(conf map[string]string) (Config, error) {
+	var c Config = Config{Host: "localhost", Port: 514, Path: "./export.out", Tag: "sysflow", QueueSize: DefaultQueueSize} // default values
 	if v, ok := conf[ExportConfigKey]; ok {
 		c.Export = parseExportConfig(v)
 	}
@@ -87,13 +122,41 @@ func CreateConfig(conf map[string]string) Config {
 		c.Host = v
 	}
 	if v, ok := conf[PortConfigKey]; ok {
-		c.Port, _ = strconv.Atoi(v)
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return c, errors.New("Configuration tag 'port' must be a port number: " + v)
+		}
+		c.Port = n
 	}
 	if v, ok := conf[PathConfigKey]; ok {
 		c.Path = v
 	}
 	if v, ok := conf[EventBufferConfigKey]; ok {
-		c.EventBuffer, _ = strconv.Atoi(v)
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return c, errors.New("Configuration tag 'buffer' must be a non-negative integer: " + v)
+		}
+		c.EventBuffer = n
+	}
+	if v, ok := conf[SeverityConfigKey]; ok {
+		sev, err := engine.ParseSeverity(v)
+		if err != nil {
+			return c, err
+		}
+		c.MinSeverity, c.SeverityFilter = sev, true
+	}
+	if v, ok := conf[MatchConfigKey]; ok {
+		c.Match = v
+	}
+	if v, ok := conf[URLConfigKey]; ok {
+		c.URL = v
+	}
+	if v, ok := conf[QueueConfigKey]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return c, errors.New("Configuration tag 'queue' must be a positive integer: " + v)
+		}
+		c.QueueSize = n
 	}
 	if v, ok := conf[VersionKey]; ok {
 		c.Version = v
@@ -104,7 +167,65 @@ func CreateConfig(conf map[string]string) Config {
 	if v, ok := conf[BuildNumberKey]; ok {
 		c.BuildNumber = v
 	}
-	return c
+	return c, nil
+}
+
+// DefaultQueueSize is the default capacity of sink queues.
+const DefaultQueueSize int = 1024
+
+// CreateSinkConfigs creates a config object for each sink listed in the sinks key of config dictionary.
+// Sink attributes are given by keys prefixed with the sink name and a dot or underscore (e.g., alerts.export,
+// or alerts_export in environment variables), and default to the exporter attributes. Without sinks, a
+// single sink is configured from the exporter attributes.
+func CreateSinkConfigs(conf map[string]string) ([]string, []Config, error) {
+	v, ok := conf[SinksConfigKey]
+	if !ok {
+		c, err := CreateConfig(conf)
+		return []string{pluginName}, []Config{c}, err
+	}
+	var names []string
+	var configs []Config
+	for _, name := range strings.Split(v, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		sc := make(map[string]string)
+		for k, v := range conf {
+			if isSinkConfigKey(k) {
+				sc[k] = v
+			}
+		}
+		for _, sep := range []string{"_", "."} {
+			for k, v := range conf {
+				if strings.HasPrefix(k, name+sep) && isSinkConfigKey(strings.TrimPrefix(k, name+sep)) {
+					sc[strings.TrimPrefix(k, name+sep)] = v
+				}
+			}
+		}
+		c, err := CreateConfig(sc)
+		if err != nil {
+			return nil, nil, errors.New("Sink " + name + ": " + err.Error())
+		}
+		names = append(names, name)
+		configs = append(configs, c)
+	}
+	return names, configs, nil
+}
+
+// isSinkConfigKey checks whether key is an exporter attribute that applies to sinks.
+func isSinkConfigKey(key string) bool {
+	switch key {
+	case SinksConfigKey:
+		return false
+	case VersionKey, JSONSchemaVersionKey, BuildNumberKey:
+		return true
+	}
+	for _, a := range ConfigAttrs {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
 }
 
 // Export type.
@@ -115,10 +236,11 @@ const (
 	StdOutExport Export = iota
 	FileExport
 	SyslogExport
+	WebhookExport
 )
 
 func (s Export) String() string {
-	return [...]string{"terminal", "file", "syslog"}[s]
+	return [...]string{"terminal", "file", "syslog", "webhook"}[s]
 }
 
 func parseExportConfig(s string) Export {
@@ -128,6 +250,9 @@ func parseExportConfig(s string) Export {
 	if SyslogExport.String() == s {
 		return SyslogExport
 	}
+	if WebhookExport.String() == s {
+		return WebhookExport
+	}
 	return StdOutExport
 }
 
@@ -0,0 +1,65 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	. "github.com/sysflow-telemetry/sf-processor/core/exporter"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+func TestCreateSinkConfigs(t *testing.T) {
+	names, configs, err := CreateSinkConfigs(map[string]string{PathConfigKey: "all.out", SeverityConfigKey: "medium"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"exporter"}, names)
+	assert.Equal(t, "all.out", configs[0].Path)
+	assert.Equal(t, engine.SevWarning, configs[0].MinSeverity)
+	conf := map[string]string{
+		SinksConfigKey:    "alerts, shells",
+		ExportConfigKey:   "file",
+		PathConfigKey:     "all.out",
+		VersionKey:        "1.0",
+		"alerts_severity": "high",
+		"alerts.path":     "alerts.out",
+		"alerts_queue":    "8",
+		"shells.match":    "sf.proc.exe = /bin/sh",
+	}
+	names, configs, err = CreateSinkConfigs(conf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alerts", "shells"}, names)
+	assert.Equal(t, FileExport, configs[0].Export)
+	assert.Equal(t, "alerts.out", configs[0].Path)
+	assert.True(t, configs[0].SeverityFilter)
+	assert.Equal(t, engine.SevError, configs[0].MinSeverity)
+	assert.Equal(t, 8, configs[0].QueueSize)
+	assert.Empty(t, configs[0].Match)
+	assert.Equal(t, FileExport, configs[1].Export)
+	assert.Equal(t, "all.out", configs[1].Path)
+	assert.False(t, configs[1].SeverityFilter)
+	assert.Equal(t, DefaultQueueSize, configs[1].QueueSize)
+	assert.Equal(t, "sf.proc.exe = /bin/sh", configs[1].Match)
+	assert.Equal(t, "1.0", configs[0].Version)
+	assert.Equal(t, "1.0", configs[1].Version)
+	conf["shells_queue"] = "0"
+	_, _, err = CreateSinkConfigs(conf)
+	assert.EqualError(t, err, "Sink shells: Configuration tag 'queue' must be a positive integer: 0")
+}
@@ -20,34 +20,29 @@
 package exporter
 
 import (
-	"crypto/tls"
-	"fmt"
-	"os"
+	"context"
 	"sync"
-	"time"
 
-	syslog "github.com/RackSec/srslog"
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
 	"github.com/sysflow-telemetry/sf-apis/go/plugins"
-	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
 	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
 )
 
 const (
-	pluginName string = "exporter"
+	pluginName  string = "exporter"
+	channelName string = "eventchan"
 )
 
-// Exporter defines a syslogger plugin.
+// Exporter defines an exporter plugin, which routes records to one or more sinks.
 type Exporter struct {
-	recs    []*engine.Record
-	counter int
-	sysl    *syslog.Writer
-	config  Config
+	sinks []*sink
+	ctx   context.Context
 }
 
 // NewExporter creates a new plugin instance.
 func NewExporter() plugins.SFProcessor {
-	return new(Exporter)
+	return &Exporter{ctx: context.Background()}
 }
 
 // GetName returns the plugin name.
@@ -62,27 +57,22 @@ func (s *Exporter) Register(pc plugins.SFPluginCache) {
 
 // Init initializes the plugin with a configuration map and cache.
 func (s *Exporter) Init(conf map[string]string) error {
-	var err error
-	s.config = CreateConfig(conf)
-	if s.config.Export == FileExport {
-		os.Remove(s.config.Path)
-	} else if s.config.Export == SyslogExport {
-		raddr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
-		if s.config.Proto == TCPTLSProto {
-			// TODO: verify connection with given trust certifications
-			nopTLSConfig := &tls.Config{InsecureSkipVerify: true}
-			s.sysl, err = syslog.DialWithTLSConfig("tcp+tls", raddr, syslog.LOG_ALERT|syslog.LOG_DAEMON, s.config.Tag, nopTLSConfig)
-		} else {
-			s.sysl, err = syslog.Dial(s.config.Proto.String(), raddr, syslog.LOG_ALERT|syslog.LOG_DAEMON, s.config.Tag)
-		}
-		if err == nil {
-			s.sysl.SetFormatter(syslog.RFC5424Formatter)
-			if s.config.LogSource != sfgo.Zeros.String {
-				s.sysl.SetHostname(s.config.LogSource)
-			}
+	names, configs, err := CreateSinkConfigs(conf)
+	if err != nil {
+		return err
+	}
+	for i, name := range names {
+		sk, err := newSink(name, configs[i])
+		if err != nil {
+			return err
 		}
+		s.sinks = append(s.sinks, sk)
 	}
-	return err
+	// a single sink applies backpressure rather than dropping records
+	if len(s.sinks) == 1 {
+		s.sinks[0].block = true
+	}
+	return nil
 }
 
 // Process implements the main interface of the plugin.
@@ -91,91 +81,43 @@ func (s *Exporter) Process(ch interface{}, wg *sync.WaitGroup) {
 	record := cha.In
 	defer wg.Done()
 
-	maxIdle := 1 * time.Second
-	ticker := time.NewTicker(maxIdle)
-	defer ticker.Stop()
-	lastFlush := time.Now()
-
-	logger.Trace.Printf("Starting Exporter in mode %s with channel capacity %d", s.config.Export.String(), cap(record))
-RecLoop:
-	for {
-		select {
-		case fc, ok := <-record:
-			if ok {
-				s.counter++
-				s.recs = append(s.recs, fc)
-				if s.counter > s.config.EventBuffer {
-					s.process()
-					s.recs = s.recs[:0]
-					s.counter = 0
-					lastFlush = time.Now()
-				}
-			} else {
-				s.process()
-				logger.Trace.Println("Channel closed. Shutting down.")
-				break RecLoop
-			}
-		case <-ticker.C:
-			// force flush records after 1sec idle
-			if time.Now().Sub(lastFlush) > maxIdle && s.counter > 0 {
-				s.process()
-				s.recs = s.recs[:0]
-				s.counter = 0
-				lastFlush = time.Now()
-			}
+	logger.Trace.Printf("Starting Exporter with %d sinks and channel capacity %d", len(s.sinks), cap(record))
+	for _, sk := range s.sinks {
+		sk.start(s.ctx)
+	}
+	for fc := range record {
+		for _, sk := range s.sinks {
+			sk.submit(fc)
 		}
 	}
+	logger.Trace.Println("Channel closed. Shutting down.")
+	for _, sk := range s.sinks {
+		sk.close()
+	}
 }
 
-func (s *Exporter) process() {
-	s.export(s.createEvents())
-}
+// SetOutChan sets the output channel of the plugin.
+func (s *Exporter) SetOutChan(ch interface{}) {}
 
-func (s *Exporter) createEvents() []Event {
-	if s.config.ExpType == BatchType {
-		return CreateOffenses(s.recs, s.config)
-	}
-	return CreateTelemetryRecords(s.recs, s.config)
+// ConfigSchema returns the plugin config schema.
+func (s *Exporter) ConfigSchema() schema.Schema {
+	return schema.Schema{Attrs: ConfigAttrs, In: []string{channelName}, Prefixes: SinksConfigKey}
 }
 
-func (s *Exporter) export(events []Event) {
-	if s.config.Format == JSONFormat {
-		s.exportAsJSON(events)
+// InFlight returns the number of records buffered in the exporter sinks.
+func (s *Exporter) InFlight() int {
+	n := 0
+	for _, sk := range s.sinks {
+		n += sk.inFlight()
 	}
+	return n
 }
 
-func (s *Exporter) exportAsJSON(events []Event) {
-	switch s.config.Export {
-	case StdOutExport:
-		for _, evt := range events {
-			fmt.Println(evt.ToJSONStr())
-		}
-	case SyslogExport:
-		for _, evt := range events {
-			if err := s.sysl.Alert(evt.ToJSONStr()); err != nil {
-				logger.Error.Println("Can't export to syslog:\n", err)
-				break
-			}
-		}
-	case FileExport:
-		f, err := os.OpenFile(s.config.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-		if err != nil {
-			logger.Error.Println("Can't open trace file:", err)
-			break
-		}
-		defer f.Close()
-		for _, evt := range events {
-			if _, err := fmt.Fprintln(f, evt.ToJSONStr()); nil != err {
-				logger.Error.Println("Can't write to trace file:\n", err)
-				break
-			}
-		}
-	}
+// SetContext sets the context used to abort sinks that cannot drain on shutdown.
+func (s *Exporter) SetContext(ctx context.Context) {
+	s.ctx = ctx
 }
 
-// SetOutChan sets the output channel of the plugin.
-func (s *Exporter) SetOutChan(ch interface{}) {}
-
 // Cleanup tears down plugin resources.
 func (s *Exporter) Cleanup() {
 	logger.Trace.Println("Exiting ", pluginName)
@@ -0,0 +1,131 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package exporter_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/cache"
+	. "github.com/sysflow-telemetry/sf-processor/core/exporter"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+func newTestRecord(exe string, sevs ...engine.Severity) *engine.Record {
+	ints := make([]int64, sfgo.INT_ARRAY_SIZE)
+	strs := make([]string, sfgo.STR_ARRAY_SIZE)
+	ints[sfgo.SF_REC_TYPE] = sfgo.PROC_EVT
+	strs[sfgo.PROC_EXE_STR] = exe
+	fr := sfgo.FlatRecord{Sources: []sfgo.Source{sfgo.SYSFLOW_SRC}, Ints: [][]int64{ints}, Strs: [][]string{strs}}
+	r := engine.NewRecord(fr, cache.GetInstance())
+	for _, sev := range sevs {
+		r.Ctx.AddRule(engine.Rule{Name: sev.String(), Severity: sev, Priority: sev.Priority()})
+	}
+	return r
+}
+
+// export runs the exporter configured by conf over recs.
+func export(t *testing.T, conf map[string]string, recs ...*engine.Record) {
+	exp := NewExporter()
+	assert.NoError(t, exp.Init(conf))
+	ch := &engine.RecordChannel{In: make(chan *engine.Record, len(recs))}
+	for _, r := range recs {
+		ch.In <- r
+	}
+	close(ch.In)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	exp.Process(ch, &wg)
+	wg.Wait()
+}
+
+// exported returns the exported lines of a file sink.
+func exported(t *testing.T, path string) []string {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	assert.NoError(t, err)
+	if len(b) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSpace(string(b)), "\n")
+}
+
+func TestSinkRouting(t *testing.T) {
+	dir, err := ioutil.TempDir("", "exporter")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	alerts, shells, all := filepath.Join(dir, "alerts.out"), filepath.Join(dir, "shells.out"), filepath.Join(dir, "all.out")
+	export(t, map[string]string{
+		SinksConfigKey:    "alerts,shells,all",
+		ExportConfigKey:   "file",
+		"alerts.path":     alerts,
+		"alerts.severity": "error",
+		"shells.path":     shells,
+		"shells.match":    "sf.proc.exe = /bin/sh",
+		"all.path":        all,
+	},
+		newTestRecord("/bin/sh", engine.SevNotice),
+		newTestRecord("/usr/bin/python", engine.SevCritical),
+		newTestRecord("/bin/sh", engine.SevWarning, engine.SevError),
+		newTestRecord("/usr/bin/curl"),
+	)
+	assert.Len(t, exported(t, alerts), 2)
+	assert.Len(t, exported(t, shells), 2)
+	assert.Len(t, exported(t, all), 4)
+	for _, l := range exported(t, shells) {
+		assert.Contains(t, l, `"/bin/sh"`)
+	}
+	for _, l := range exported(t, alerts) {
+		assert.NotContains(t, l, `"/usr/bin/curl"`)
+	}
+}
+
+func TestSeverityThreshold(t *testing.T) {
+	dir, err := ioutil.TempDir("", "exporter")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for _, c := range []struct {
+		severity string
+		expected int
+	}{
+		{"debug", 3},
+		{"low", 2},
+		{"warning", 2},
+		{"high", 1},
+		{"emergency", 0},
+	} {
+		path := filepath.Join(dir, c.severity+".out")
+		export(t, map[string]string{ExportConfigKey: "file", PathConfigKey: path, SeverityConfigKey: c.severity},
+			newTestRecord("/bin/sh", engine.SevInformational),
+			newTestRecord("/bin/sh", engine.SevDebug, engine.SevWarning),
+			newTestRecord("/bin/sh", engine.SevCritical),
+			newTestRecord("/bin/sh"),
+		)
+		assert.Len(t, exported(t, path), c.expected, c.severity)
+	}
+}
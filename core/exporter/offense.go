@@ -38,6 +38,9 @@ type Policy struct {
 	ID       string   `json:"id"`
 	Desc     string   `json:"desc"`
 	Priority int      `json:"priority"`
+	Severity string   `json:"severity"`
+	Score    int      `json:"score,omitempty"`
+	Output   string   `json:"output,omitempty"`
 	Tags     []string `json:"tags"`
 }
 
@@ -0,0 +1,258 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	syslog "github.com/RackSec/srslog"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+// sink exports records matching its configuration to a single destination. Each sink has its own
+// queue and goroutine, so that slow or failing sinks do not block other sinks.
+type sink struct {
+	name    string
+	config  Config
+	match   *engine.Criterion
+	in      chan *engine.Record
+	recs    []*engine.Record
+	sysl    *syslog.Writer
+	client  *http.Client
+	dropped int
+	block   bool
+	batched int32
+	ctx     context.Context
+	wg      sync.WaitGroup
+}
+
+// newSink creates a sink and connects it to its destination.
+func newSink(name string, config Config) (*sink, error) {
+	s := &sink{name: name, config: config, in: make(chan *engine.Record, config.QueueSize)}
+	if config.Match != "" {
+		c, err := engine.CompileExpression(config.Match)
+		if err != nil {
+			return nil, err
+		}
+		s.match = &c
+	}
+	var err error
+	switch config.Export {
+	case FileExport:
+		os.Remove(config.Path)
+	case SyslogExport:
+		raddr := fmt.Sprintf("%s:%d", config.Host, config.Port)
+		if config.Proto == TCPTLSProto {
+			// TODO: verify connection with given trust certifications
+			nopTLSConfig := &tls.Config{InsecureSkipVerify: true}
+			s.sysl, err = syslog.DialWithTLSConfig("tcp+tls", raddr, syslog.LOG_ALERT|syslog.LOG_DAEMON, config.Tag, nopTLSConfig)
+		} else {
+			s.sysl, err = syslog.Dial(config.Proto.String(), raddr, syslog.LOG_ALERT|syslog.LOG_DAEMON, config.Tag)
+		}
+		if err == nil {
+			s.sysl.SetFormatter(syslog.RFC5424Formatter)
+			if config.LogSource != sfgo.Zeros.String {
+				s.sysl.SetHostname(config.LogSource)
+			}
+		}
+	case WebhookExport:
+		if config.URL == "" {
+			return nil, fmt.Errorf("Configuration tag 'url' missing from webhook sink %s", name)
+		}
+		s.client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return s, err
+}
+
+// accept checks whether a record is routed to the sink. When a severity threshold is set, records must
+// match at least one rule with severity equal or higher than the threshold.
+func (s *sink) accept(r *engine.Record) bool {
+	if s.config.SeverityFilter {
+		found := false
+		for _, rule := range r.Ctx.GetRules() {
+			if rule.Severity >= s.config.MinSeverity {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return s.match == nil || s.match.Eval(r)
+}
+
+// submit queues a record for export if it is routed to the sink. Unless the sink blocks, records
+// are dropped when the queue is full.
+func (s *sink) submit(r *engine.Record) {
+	if !s.accept(r) {
+		return
+	}
+	if s.block {
+		select {
+		case s.in <- r:
+		case <-s.ctx.Done():
+		}
+		return
+	}
+	select {
+	case s.in <- r:
+	default:
+		if s.dropped++; s.dropped == 1 || s.dropped%DefaultQueueSize == 0 {
+			logger.Warn.Printf("Sink %s queue full; dropped %d records so far\n", s.name, s.dropped)
+		}
+	}
+}
+
+// start starts exporting queued records until the sink is closed or ctx is canceled.
+func (s *sink) start(ctx context.Context) {
+	s.ctx = ctx
+	s.wg.Add(1)
+	go s.run()
+}
+
+// inFlight returns the number of queued and batched records.
+func (s *sink) inFlight() int {
+	return len(s.in) + int(atomic.LoadInt32(&s.batched))
+}
+
+// close flushes queued records and waits for the sink to finish.
+func (s *sink) close() {
+	close(s.in)
+	s.wg.Wait()
+}
+
+func (s *sink) run() {
+	defer s.wg.Done()
+	maxIdle := 1 * time.Second
+	ticker := time.NewTicker(maxIdle)
+	defer ticker.Stop()
+	lastFlush := time.Now()
+
+	logger.Trace.Printf("Starting sink %s in mode %s with queue capacity %d", s.name, s.config.Export.String(), cap(s.in))
+	for {
+		select {
+		case fc, ok := <-s.in:
+			if !ok {
+				s.process()
+				logger.Trace.Printf("Sink %s closed. Shutting down.", s.name)
+				return
+			}
+			s.recs = append(s.recs, fc)
+			atomic.StoreInt32(&s.batched, int32(len(s.recs)))
+			if len(s.recs) > s.config.EventBuffer {
+				s.process()
+				lastFlush = time.Now()
+			}
+		case <-s.ctx.Done():
+			logger.Trace.Printf("Sink %s aborted; dropped %d buffered records\n", s.name, len(s.recs)+len(s.in))
+			return
+		case <-ticker.C:
+			// force flush records after 1sec idle
+			if time.Now().Sub(lastFlush) > maxIdle && len(s.recs) > 0 {
+				s.process()
+				lastFlush = time.Now()
+			}
+		}
+	}
+}
+
+func (s *sink) process() {
+	s.export(s.createEvents())
+	s.recs = s.recs[:0]
+	atomic.StoreInt32(&s.batched, 0)
+}
+
+func (s *sink) createEvents() []Event {
+	if s.config.ExpType == BatchType {
+		return CreateOffenses(s.recs, s.config)
+	}
+	return CreateTelemetryRecords(s.recs, s.config)
+}
+
+func (s *sink) export(events []Event) {
+	if s.config.Format == JSONFormat {
+		s.exportAsJSON(events)
+	}
+}
+
+func (s *sink) exportAsJSON(events []Event) {
+	switch s.config.Export {
+	case StdOutExport:
+		for _, evt := range events {
+			fmt.Println(evt.ToJSONStr())
+		}
+	case SyslogExport:
+		for _, evt := range events {
+			if err := s.sysl.Alert(evt.ToJSONStr()); err != nil {
+				logger.Error.Println("Can't export to syslog:\n", err)
+				break
+			}
+		}
+	case FileExport:
+		f, err := os.OpenFile(s.config.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			logger.Error.Println("Can't open trace file:", err)
+			break
+		}
+		defer f.Close()
+		for _, evt := range events {
+			if _, err := fmt.Fprintln(f, evt.ToJSONStr()); nil != err {
+				logger.Error.Println("Can't write to trace file:\n", err)
+				break
+			}
+		}
+	case WebhookExport:
+		for _, evt := range events {
+			if err := s.post(evt.ToJSON()); err != nil {
+				logger.Error.Println("Can't export to webhook:\n", err)
+				break
+			}
+		}
+	}
+}
+
+// post sends a JSON event to the sink's webhook.
+func (s *sink) post(body []byte) error {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Unexpected webhook response status: %s", resp.Status)
+	}
+	return nil
+}
@@ -36,6 +36,7 @@ const (
 	file      = "file"
 	flow      = "flow"
 	container = "container"
+	pod       = "pod"
 	node      = "node"
 )
 
@@ -44,8 +45,9 @@ type TelemetryRecord struct {
 	Version     string `json:"version,omitempty"`
 	*FlatRecord `json:",omitempty"`
 	*DataRecord `json:",omitempty"`
-	Hashes      *engine.HashSet `json:"hashes,omitempty"`
-	Policies    []Policy        `json:"policies,omitempty"`
+	Hashes      *engine.HashSet        `json:"hashes,omitempty"`
+	Enrichment  map[string]interface{} `json:"enrichment,omitempty"`
+	Policies    []Policy               `json:"policies,omitempty"`
 }
 
 // FlatRecord type
@@ -67,7 +69,9 @@ type DataRecord struct {
 	*FileData  `json:",omitempty"`
 	*FlowData  `json:",omitempty"`
 	*ContData  `json:",omitempty"`
+	*PodData   `json:",omitempty"`
 	*NodeData  `json:",omitempty"`
+	*ExtData   `json:",omitempty"`
 }
 
 // ProcData type
@@ -100,11 +104,21 @@ type ContData struct {
 	Container map[string]interface{} `json:"container"`
 }
 
+// PodData type
+type PodData struct {
+	Pod map[string]interface{} `json:"pod"`
+}
+
 // NodeData type
 type NodeData struct {
 	Node map[string]interface{} `json:"node"`
 }
 
+// ExtData type
+type ExtData struct {
+	Ext map[string]map[string]interface{} `json:"ext"`
+}
+
 // CreateTelemetryRecords creates offense instances based on a list of records
 func CreateTelemetryRecords(occs []*engine.Record, config Config) []Event {
 	var recs = make([]Event, 0)
@@ -134,12 +148,18 @@ func extractTelemetryRecord(rec *engine.Record, config Config) TelemetryRecord {
 		for _, k := range engine.Fields {
 			r.Data[k] = engine.Mapper.Mappers[k](rec)
 		}
+		for _, k := range engine.ExtFields {
+			if engine.Mapper.MapPresent(k)(rec) {
+				r.Data[k] = engine.Mapper.Mappers[k](rec)
+			}
+		}
 	} else {
 		r.DataRecord = new(DataRecord)
 		pprocID := engine.Mapper.MapInt(engine.SF_PPROC_PID)(rec)
 		pprocExists := !reflect.ValueOf(pprocID).IsZero()
 		ct := engine.Mapper.MapStr(engine.SF_CONTAINER_ID)(rec)
 		ctExists := !reflect.ValueOf(ct).IsZero()
+		podExists := ctExists && engine.Mapper.MapPresent(engine.SF_POD_NAME)(rec)
 		for _, k := range engine.Fields {
 			kc := strings.Split(k, ".")
 			value := extractValue(k, engine.Mapper.Mappers[k](rec))
@@ -200,6 +220,14 @@ func extractTelemetryRecord(rec *engine.Record, config Config) TelemetryRecord {
 						}
 						r.Container[kc[2]] = value
 					}
+				case pod:
+					if podExists {
+						if r.PodData == nil {
+							r.PodData = new(PodData)
+							r.PodData.Pod = make(map[string]interface{})
+						}
+						r.Pod[kc[2]] = value
+					}
 				case node:
 					if r.NodeData == nil {
 						r.NodeData = new(NodeData)
@@ -209,22 +237,45 @@ func extractTelemetryRecord(rec *engine.Record, config Config) TelemetryRecord {
 				}
 			}
 		}
+		extractExtData(rec, r.DataRecord)
 	}
 	hashset := rec.Ctx.GetHashes()
 	if !reflect.ValueOf(hashset.MD5).IsZero() {
 		r.Hashes = &hashset
 	}
-	r.Policies = extractPolicySet(rec.Ctx.GetRules())
+	r.Enrichment = rec.Ctx.GetEnrichment()
+	r.Policies = extractPolicySet(rec)
 	return r
 }
 
-func extractPolicySet(rules []engine.Rule) []Policy {
+// extractExtData adds a section for each extended attribute component (e.g., ext.proc) defined by the record's sources.
+func extractExtData(rec *engine.Record, r *DataRecord) {
+	for _, k := range engine.ExtFields {
+		if !engine.Mapper.MapPresent(k)(rec) {
+			continue
+		}
+		kc := strings.SplitN(k, ".", 3)
+		if r.ExtData == nil {
+			r.ExtData = new(ExtData)
+			r.ExtData.Ext = make(map[string]map[string]interface{})
+		}
+		if r.Ext[kc[1]] == nil {
+			r.Ext[kc[1]] = make(map[string]interface{})
+		}
+		r.Ext[kc[1]][kc[2]] = engine.Mapper.Mappers[k](rec)
+	}
+}
+
+func extractPolicySet(rec *engine.Record) []Policy {
 	var pols = make([]Policy, 0)
-	for _, r := range rules {
+	for _, r := range rec.Ctx.GetRules() {
 		p := Policy{
 			ID:       r.Name,
 			Desc:     r.Desc,
 			Priority: int(r.Priority),
+			Severity: r.Severity.String(),
+			Score:    r.Score,
+			Output:   r.FormatOutput(rec),
 			Tags:     extracTags(r.Tags),
 		}
 		pols = append(pols, p)
@@ -262,5 +313,5 @@ func extractValue(k string, v interface{}) interface{} {
 func array(k string) bool {
 	return k == engine.SF_OPFLAGS || k == engine.SF_PROC_APID || k == engine.SF_PROC_ANAME ||
 		k == engine.SF_PROC_AEXE || k == engine.SF_PROC_ACMDLINE || k == engine.SF_FILE_OPENFLAGS ||
-		k == engine.SF_NET_IP || k == engine.SF_NET_PORT
+		k == engine.SF_NET_IP || k == engine.SF_NET_PORT || k == engine.SF_POD_LABELS
 }
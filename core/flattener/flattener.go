@@ -25,6 +25,7 @@ import (
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
 	"github.com/sysflow-telemetry/sf-apis/go/plugins"
 	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
 )
 
 const (
@@ -58,6 +59,11 @@ func (s *Flattener) Register(pc plugins.SFPluginCache) {
 	pc.AddChannel(channelName, NewFlattenerChan)
 }
 
+// ConfigSchema returns the handler config schema.
+func (s *Flattener) ConfigSchema() schema.Schema {
+	return schema.Schema{Out: channelName}
+}
+
 // Init initializes the handler with a configuration map.
 func (s *Flattener) Init(conf map[string]string) error {
 	return nil
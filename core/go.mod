@@ -26,6 +26,7 @@ require (
 	github.com/antlr/antlr4 v0.0.0-20200417160354-8c50731894e0
 	github.com/cespare/xxhash v1.1.0
 	github.com/enriquebris/goconcurrentqueue v0.6.0
+	github.com/linkedin/goavro v2.1.0+incompatible
 	github.com/orcaman/concurrent-map v0.0.0-20190826125027-8c72a8bb44f6
 	github.com/stretchr/testify v1.6.1
 	github.com/sysflow-telemetry/sf-apis/go v0.0.0-20201207153955-828257760aa4
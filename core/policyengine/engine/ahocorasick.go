@@ -0,0 +1,95 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+// acMatcher implements Aho-Corasick multi-pattern substring matching.
+type acMatcher struct {
+	next  []map[byte]int
+	fail  []int
+	match []bool
+}
+
+// newACMatcher builds a matcher for a set of patterns.
+func newACMatcher(patterns []string) *acMatcher {
+	m := &acMatcher{next: []map[byte]int{{}}, fail: []int{0}, match: []bool{false}}
+	for _, p := range patterns {
+		s := 0
+		for i := 0; i < len(p); i++ {
+			t, ok := m.next[s][p[i]]
+			if !ok {
+				t = len(m.next)
+				m.next = append(m.next, map[byte]int{})
+				m.fail = append(m.fail, 0)
+				m.match = append(m.match, false)
+				m.next[s][p[i]] = t
+			}
+			s = t
+		}
+		m.match[s] = true
+	}
+	queue := make([]int, 0, len(m.next))
+	for _, t := range m.next[0] {
+		queue = append(queue, t)
+	}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		for c, t := range m.next[s] {
+			f := m.fail[s]
+			for {
+				if u, ok := m.next[f][c]; ok && u != t {
+					m.fail[t] = u
+					break
+				}
+				if f == 0 {
+					break
+				}
+				f = m.fail[f]
+			}
+			m.match[t] = m.match[t] || m.match[m.fail[t]]
+			queue = append(queue, t)
+		}
+	}
+	return m
+}
+
+// matchAny returns true if s contains any of the matcher's patterns.
+func (m *acMatcher) matchAny(s string) bool {
+	if m.match[0] {
+		return true
+	}
+	st := 0
+	for i := 0; i < len(s); i++ {
+		for {
+			if t, ok := m.next[st][s[i]]; ok {
+				st = t
+				break
+			}
+			if st == 0 {
+				break
+			}
+			st = m.fail[st]
+		}
+		if m.match[st] {
+			return true
+		}
+	}
+	return false
+}
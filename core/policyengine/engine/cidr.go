@@ -0,0 +1,138 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"math/bits"
+	"net"
+	"strings"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+)
+
+// Well-known IPv4 networks.
+var (
+	privateNets  = newCIDRTrie([]string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"})
+	loopbackNets = newCIDRTrie([]string{"127.0.0.0/8"})
+)
+
+// cidrTrie is a binary prefix trie of IPv4 networks.
+type cidrTrie struct {
+	root *cidrNode
+}
+
+type cidrNode struct {
+	child [2]*cidrNode
+	leaf  bool
+}
+
+// newCIDRTrie creates a prefix trie from a list of networks in CIDR notation. Addresses
+// without a prefix length denote single hosts, and invalid or non-IPv4 networks are skipped.
+func newCIDRTrie(cidrs []string) *cidrTrie {
+	t := &cidrTrie{root: new(cidrNode)}
+	for _, c := range cidrs {
+		c = strings.TrimSpace(unquote(c))
+		if !strings.Contains(c, "/") {
+			c += "/32"
+		}
+		_, n, err := net.ParseCIDR(c)
+		if err != nil || n.IP.To4() == nil {
+			logger.Warn.Println("Invalid IPv4 network ", c)
+			continue
+		}
+		ones, _ := n.Mask.Size()
+		t.insert(ipToUint32(n.IP.To4()), ones)
+	}
+	return t
+}
+
+// insert adds the network with the first plen bits of ip to the trie.
+func (t *cidrTrie) insert(ip uint32, plen int) {
+	n := t.root
+	for i := 0; i < plen && !n.leaf; i++ {
+		b := (ip >> (31 - i)) & 1
+		if n.child[b] == nil {
+			n.child[b] = new(cidrNode)
+		}
+		n = n.child[b]
+	}
+	n.leaf = true
+	n.child = [2]*cidrNode{}
+}
+
+// contains returns true if ip belongs to some network in the trie.
+func (t *cidrTrie) contains(ip uint32) bool {
+	n := t.root
+	for i := 0; n != nil; i++ {
+		if n.leaf {
+			return true
+		}
+		if i == 32 {
+			break
+		}
+		n = n.child[(ip>>(31-i))&1]
+	}
+	return false
+}
+
+// ipToUint32 converts an IPv4 address into its numerical value.
+func ipToUint32(ip net.IP) uint32 {
+	return uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])
+}
+
+// flatIPToUint32 converts an IP address stored in a flat record into its numerical value.
+func flatIPToUint32(ip int64) uint32 {
+	return bits.ReverseBytes32(uint32(ip))
+}
+
+// ipAttributes maps IP attributes to the flat record attributes that store their integer values.
+var ipAttributes = map[string][]sfgo.Attribute{
+	SF_NET_SIP:   {sfgo.FL_NETW_SIP_INT},
+	SF_NET_DIP:   {sfgo.FL_NETW_DIP_INT},
+	SF_NET_IP:    {sfgo.FL_NETW_SIP_INT, sfgo.FL_NETW_DIP_INT},
+	FALCO_FD_SIP: {sfgo.FL_NETW_SIP_INT},
+	FALCO_FD_DIP: {sfgo.FL_NETW_DIP_INT},
+	FALCO_FD_IP:  {sfgo.FL_NETW_SIP_INT, sfgo.FL_NETW_DIP_INT},
+}
+
+// compileIPOperand resolves attr into an accessor of the IPv4 addresses it denotes. IP attributes
+// are read as integers; other attributes and constants are parsed from their string values.
+func compileIPOperand(attr string) func(r *Record) []uint32 {
+	if attrs, ok := ipAttributes[attr]; ok {
+		return func(r *Record) []uint32 {
+			ips := make([]uint32, len(attrs))
+			for i, a := range attrs {
+				ips[i] = flatIPToUint32(r.GetInt(a, sfgo.SYSFLOW_SRC))
+			}
+			return ips
+		}
+	}
+	m := Mapper.MapStr(attr)
+	return func(r *Record) []uint32 {
+		var ips []uint32
+		for _, s := range constItems(m(r)) {
+			if ip := net.ParseIP(strings.TrimSpace(s)).To4(); ip != nil {
+				ips = append(ips, ipToUint32(ip))
+			}
+		}
+		return ips
+	}
+}
@@ -19,7 +19,14 @@
 //
 package engine
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
+)
 
 // Configuration keys.
 const (
@@ -28,8 +35,28 @@ const (
 	VersionKey           string = "version"
 	JSONSchemaVersionKey string = "jsonschemaversion"
 	BuildNumberKey       string = "buildnumber"
+	ConcurrencyKey       string = "concurrency"
+	OrderingKey          string = "ordering"
+	ReorderBufferKey     string = "reorderbuffer"
+	NullSemanticsKey     string = "nullsemantics"
+	ListRefreshKey       string = "listrefresh"
+	PodSourceKey         string = "podsource"
+	PodRefreshKey        string = "podrefresh"
+	PodCAKey             string = "podca"
+	PodInsecureKey       string = "podinsecure"
+	SeverityOverridesKey string = "severityoverrides"
+	ScoreOverridesKey    string = "scoreoverrides"
+)
+
+// Default values for parallel policy evaluation.
+const (
+	DefaultConcurrency   int = 1
+	DefaultReorderBuffer int = 1024
 )
 
+// DefaultListRefresh is the default interval for checking external list files for changes.
+const DefaultListRefresh = 60 * time.Second
+
 // Config defines a configuration object for the engine.
 type Config struct {
 	PoliciesPath      string
@@ -37,11 +64,43 @@ type Config struct {
 	Version           string
 	JSONSchemaVersion string
 	BuildNumber       string
+	Concurrency       int
+	Ordering          Ordering
+	ReorderBuffer     int
+	NullSemantics     NullSemantics
+	ListRefresh       time.Duration
+	PodSource         string
+	PodRefresh        time.Duration
+	PodCA             string
+	PodInsecure       bool
+	SeverityOverrides map[string]Severity
+	ScoreOverrides    map[string]int
+}
+
+// ConfigAttrs declares the policy engine configuration attributes.
+var ConfigAttrs = []schema.Attr{
+	{Key: PoliciesConfigKey, Type: schema.String, Required: true},
+	{Key: ModeConfigKey, Type: schema.Enum, Default: AlertMode.String(), Values: []string{AlertMode.String(), FilterMode.String(), BypassMode.String()}},
+	{Key: ConcurrencyKey, Type: schema.Int, Default: strconv.Itoa(DefaultConcurrency)},
+	{Key: OrderingKey, Type: schema.Enum, Default: GlobalOrdering.String(), Values: []string{GlobalOrdering.String(), ProcessOrdering.String(), ContainerOrdering.String(), NoOrdering.String()}},
+	{Key: ReorderBufferKey, Type: schema.Int, Default: strconv.Itoa(DefaultReorderBuffer)},
+	{Key: NullSemanticsKey, Type: schema.Enum, Default: LegacyNulls.String(), Values: []string{LegacyNulls.String(), StrictNulls.String()}},
+	{Key: ListRefreshKey, Type: schema.Duration, Default: DefaultListRefresh.String()},
+	{Key: PodSourceKey, Type: schema.String},
+	{Key: PodRefreshKey, Type: schema.Duration, Default: DefaultPodRefresh.String()},
+	{Key: PodCAKey, Type: schema.String},
+	{Key: PodInsecureKey, Type: schema.Bool, Default: "false"},
+	{Key: SeverityOverridesKey, Type: schema.String, Check: func(v string) error {
+		return parseTagOverrides(SeverityOverridesKey, v, func(tag string, value string) (err error) { _, err = ParseSeverity(value); return })
+	}},
+	{Key: ScoreOverridesKey, Type: schema.String, Check: func(v string) error {
+		return parseTagOverrides(ScoreOverridesKey, v, func(tag string, value string) (err error) { _, err = strconv.Atoi(value); return })
+	}},
 }
 
 // CreateConfig creates a new config object from config dictionary.
 func CreateConfig(conf map[string]string) (Config, error) {
-	var c Config = Config{Mode: AlertMode} // default values
+	var c Config = Config{Mode: AlertMode, Concurrency: DefaultConcurrency, Ordering: GlobalOrdering, ReorderBuffer: DefaultReorderBuffer, ListRefresh: DefaultListRefresh, PodRefresh: DefaultPodRefresh} // default values
 	if v, ok := conf[PoliciesConfigKey]; ok {
 		c.PoliciesPath = v
 	} else {
@@ -59,9 +118,94 @@ func CreateConfig(conf map[string]string) (Config, error) {
 	if v, ok := conf[BuildNumberKey]; ok {
 		c.BuildNumber = v
 	}
+	if v, ok := conf[ConcurrencyKey]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return c, errors.New("Configuration tag 'concurrency' must be a positive integer: " + v)
+		}
+		c.Concurrency = n
+	}
+	if v, ok := conf[OrderingKey]; ok {
+		o, err := parseOrderingConfig(v)
+		if err != nil {
+			return c, err
+		}
+		c.Ordering = o
+	}
+	if v, ok := conf[ReorderBufferKey]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return c, errors.New("Configuration tag 'reorderbuffer' must be a positive integer: " + v)
+		}
+		c.ReorderBuffer = n
+	}
+	if v, ok := conf[NullSemanticsKey]; ok {
+		n, err := parseNullSemanticsConfig(v)
+		if err != nil {
+			return c, err
+		}
+		c.NullSemantics = n
+	}
+	if v, ok := conf[ListRefreshKey]; ok {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return c, errors.New("Configuration tag 'listrefresh' must be a non-negative duration: " + v)
+		}
+		c.ListRefresh = d
+	}
+	if v, ok := conf[PodSourceKey]; ok {
+		c.PodSource = v
+	}
+	if v, ok := conf[PodRefreshKey]; ok {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return c, errors.New("Configuration tag 'podrefresh' must be a non-negative duration: " + v)
+		}
+		c.PodRefresh = d
+	}
+	if v, ok := conf[PodCAKey]; ok {
+		c.PodCA = v
+	}
+	if v, ok := conf[PodInsecureKey]; ok && v == "true" {
+		c.PodInsecure = true
+	}
+	if v, ok := conf[SeverityOverridesKey]; ok {
+		c.SeverityOverrides = make(map[string]Severity)
+		err := parseTagOverrides(SeverityOverridesKey, v, func(tag string, value string) (err error) {
+			c.SeverityOverrides[tag], err = ParseSeverity(value)
+			return
+		})
+		if err != nil {
+			return c, err
+		}
+	}
+	if v, ok := conf[ScoreOverridesKey]; ok {
+		c.ScoreOverrides = make(map[string]int)
+		err := parseTagOverrides(ScoreOverridesKey, v, func(tag string, value string) (err error) {
+			c.ScoreOverrides[tag], err = strconv.Atoi(value)
+			return
+		})
+		if err != nil {
+			return c, err
+		}
+	}
 	return c, nil
 }
 
+// parseTagOverrides parses comma-separated tag=value pairs, calling set for each pair.
+func parseTagOverrides(key string, s string, set func(tag string, value string) error) error {
+	for _, kv := range strings.Split(s, ",") {
+		if kv = strings.TrimSpace(kv); kv == "" {
+			continue
+		}
+		p := strings.SplitN(kv, "=", 2)
+		if len(p) != 2 || set(strings.TrimSpace(p[0]), strings.TrimSpace(p[1])) != nil {
+			return errors.New("Configuration tag '" + key + "' must be a list of tag=value pairs: " + s)
+		}
+	}
+	return nil
+}
+
 // Mode type.
 type Mode int
 
@@ -88,3 +232,53 @@ func parseModeConfig(s string) Mode {
 	}
 	return AlertMode
 }
+
+// Ordering type.
+type Ordering int
+
+// Ordering config options.
+const (
+	GlobalOrdering Ordering = iota
+	ProcessOrdering
+	ContainerOrdering
+	NoOrdering
+)
+
+func (s Ordering) String() string {
+	return [...]string{"global", "process", "container", "none"}[s]
+}
+
+func parseOrderingConfig(s string) (Ordering, error) {
+	for _, o := range []Ordering{GlobalOrdering, ProcessOrdering, ContainerOrdering, NoOrdering} {
+		if o.String() == s {
+			return o, nil
+		}
+	}
+	return GlobalOrdering, errors.New("Unrecognized ordering option: " + s)
+}
+
+// NullSemantics type.
+type NullSemantics int
+
+// NullSemantics config options.
+//
+// Legacy semantics, the default, compare absent attributes as zero values.
+// With strict semantics, comparisons involving an attribute that is absent from a record
+// evaluate to false. In both, 'exists' holds only for attributes present in the record.
+const (
+	LegacyNulls NullSemantics = iota
+	StrictNulls
+)
+
+func (s NullSemantics) String() string {
+	return [...]string{"legacy", "strict"}[s]
+}
+
+func parseNullSemanticsConfig(s string) (NullSemantics, error) {
+	for _, n := range []NullSemantics{LegacyNulls, StrictNulls} {
+		if n.String() == s {
+			return n, nil
+		}
+	}
+	return LegacyNulls, errors.New("Unrecognized null semantics option: " + s)
+}
@@ -0,0 +1,61 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	. "github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
+)
+
+func TestConfigSchema(t *testing.T) {
+	s := schema.Schema{Attrs: ConfigAttrs}
+	assert.NoError(t, s.Validate(map[string]string{PoliciesConfigKey: ".", ModeConfigKey: "filter", ConcurrencyKey: "4",
+		ListRefreshKey: "30s", SeverityOverridesKey: "pci=critical", ScoreOverridesKey: "pci=80"}))
+	assert.NoError(t, s.Validate(map[string]string{PoliciesConfigKey: ".", "in": "flat flattenerchan"}, "in"))
+	assert.Error(t, s.Validate(map[string]string{}))
+	assert.Error(t, s.Validate(map[string]string{PoliciesConfigKey: ".", "concurrncy": "4"}))
+	assert.Error(t, s.Validate(map[string]string{PoliciesConfigKey: ".", ConcurrencyKey: "four"}))
+	assert.Error(t, s.Validate(map[string]string{PoliciesConfigKey: ".", ModeConfigKey: "audit"}))
+	assert.Error(t, s.Validate(map[string]string{PoliciesConfigKey: ".", PodRefreshKey: "30"}))
+	assert.Error(t, s.Validate(map[string]string{PoliciesConfigKey: ".", SeverityOverridesKey: "pci=severe"}))
+
+	rc := s.Resolve(map[string]string{PoliciesConfigKey: ".", ModeConfigKey: "filter"})
+	assert.Equal(t, "filter", rc[ModeConfigKey])
+	assert.Equal(t, "global", rc[OrderingKey])
+	c, err := CreateConfig(rc)
+	assert.NoError(t, err)
+	d, err := CreateConfig(map[string]string{PoliciesConfigKey: ".", ModeConfigKey: "filter"})
+	assert.NoError(t, err)
+	assert.Equal(t, d, c)
+}
+
+func TestConfigSchemaPrefixes(t *testing.T) {
+	s := schema.Schema{Attrs: []schema.Attr{{Key: "sinks"}, {Key: "port", Type: schema.Int}}, Prefixes: "sinks"}
+	assert.NoError(t, s.Validate(map[string]string{"sinks": "a,b", "a.port": "1", "b_port": "2"}))
+	assert.Error(t, s.Validate(map[string]string{"sinks": "a", "a.port": "x"}))
+	assert.Error(t, s.Validate(map[string]string{"sinks": "a", "c.port": "1"}))
+	assert.Error(t, s.Validate(map[string]string{"sinks": "a", "a.sinks": "b"}))
+	n := schema.Schema{Attrs: []schema.Attr{{Key: "handlers"}}, Names: "handlers"}
+	assert.NoError(t, n.Validate(map[string]string{"handlers": "hosts", "hosts": "/etc/hosts"}))
+	assert.Error(t, n.Validate(map[string]string{"handlers": "hosts", "passwd": "/etc/passwd"}))
+}
@@ -78,6 +78,7 @@ const (
 	SF_PROC_AEXE            string = "sf.proc.aexe"
 	SF_PROC_ACMDLINE        string = "sf.proc.acmdline"
 	SF_PROC_APID            string = "sf.proc.apid"
+	SF_PROC_ADEPTH          string = "sf.proc.adepth"
 	SF_PPROC_OID            string = "sf.pproc.oid"
 	SF_PPROC_PID            string = "sf.pproc.pid"
 	SF_PPROC_NAME           string = "sf.pproc.name"
@@ -113,6 +114,12 @@ const (
 	SF_NET_SIP              string = "sf.net.sip"
 	SF_NET_DIP              string = "sf.net.dip"
 	SF_NET_IP               string = "sf.net.ip"
+	SF_NET_SIP_PRIVATE      string = "sf.net.sip.private"
+	SF_NET_DIP_PRIVATE      string = "sf.net.dip.private"
+	SF_NET_IP_PRIVATE       string = "sf.net.ip.private"
+	SF_NET_SIP_LOOPBACK     string = "sf.net.sip.loopback"
+	SF_NET_DIP_LOOPBACK     string = "sf.net.dip.loopback"
+	SF_NET_IP_LOOPBACK      string = "sf.net.ip.loopback"
 	SF_FLOW_RBYTES          string = "sf.flow.rbytes"
 	SF_FLOW_ROPS            string = "sf.flow.rops"
 	SF_FLOW_WBYTES          string = "sf.flow.wbytes"
@@ -123,6 +130,15 @@ const (
 	SF_CONTAINER_IMAGE      string = "sf.container.image"
 	SF_CONTAINER_TYPE       string = "sf.container.type"
 	SF_CONTAINER_PRIVILEGED string = "sf.container.privileged"
+	SF_POD_NAME             string = "sf.pod.name"
+	SF_POD_NAMESPACE        string = "sf.pod.namespace"
+	SF_POD_LABELS           string = "sf.pod.labels"
+	SF_POD_SERVICEACCOUNT   string = "sf.pod.serviceaccount"
+	SF_RULE_NAME            string = "sf.rule.name"
+	SF_RULE_TAGS            string = "sf.rule.tags"
+	SF_RULE_SEVERITY        string = "sf.rule.severity"
+	SF_RULE_PRIORITY        string = "sf.rule.priority"
+	SF_RULE_SCORE           string = "sf.rule.score"
 	SF_NODE_ID              string = "sf.node.id"
 	SF_NODE_IP              string = "sf.node.ip"
 	SF_SCHEMA_VERSION       string = "sf.schema"
@@ -271,6 +287,9 @@ const (
 	FALCO_CONT_NAME             = "container.name"
 	FALCO_CONT_TYPE             = "container.type"
 	FALCO_CONT_PRIVILEGED       = "container.privileged"
+	FALCO_K8S_POD_NAME          = "k8s.pod.name"
+	FALCO_K8S_NS_NAME           = "k8s.ns.name"
+	FALCO_K8S_POD_LABELS        = "k8s.pod.labels"
 )
 
 // Falco constants
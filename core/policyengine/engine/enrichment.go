@@ -19,6 +19,14 @@
 //
 package engine
 
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
 // Handler defines an interface for SysFlow enrichment routines.
 type Handler interface {
 	Init(confPath string) error
@@ -26,3 +34,207 @@ type Handler interface {
 	ProcessAsync(r *Record, callback func(o interface{})) error
 	Cleanup() error
 }
+
+// Built-in enrichment handler names.
+const (
+	HostsHandlerName    string = "hosts"
+	PasswdHandlerName   string = "passwd"
+	HostMetaHandlerName string = "hostmeta"
+)
+
+// Enrichment keys added to record contexts by built-in handlers.
+const (
+	EnrichNetSHost string = "net.shost"
+	EnrichNetDHost string = "net.dhost"
+	EnrichProcUser string = "proc.user"
+	EnrichHostMeta string = "host."
+)
+
+// Default files for built-in handlers.
+const (
+	DefaultHostsDB  string = "/etc/hosts"
+	DefaultPasswdDB string = "/etc/passwd"
+)
+
+// handlerFactories maps built-in handler names to their constructors.
+var handlerFactories = map[string]func() Handler{
+	HostsHandlerName:    NewHostsHandler,
+	PasswdHandlerName:   NewPasswdHandler,
+	HostMetaHandlerName: NewHostMetaHandler,
+}
+
+// NewHandler creates a built-in enrichment handler by name.
+func NewHandler(name string) (Handler, error) {
+	if f, ok := handlerFactories[name]; ok {
+		return f(), nil
+	}
+	return nil, errors.New("Unrecognized enrichment handler: " + name)
+}
+
+// readLines calls f with the fields of each non-empty, non-comment line of file path, split by sep (or whitespace if empty).
+func readLines(path string, sep string, f func(fields []string)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	s := bufio.NewScanner(file)
+	for s.Scan() {
+		line := s.Text()
+		if i := strings.Index(line, "#"); i >= 0 && sep == "" {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if sep == "" {
+			f(strings.Fields(line))
+		} else {
+			f(strings.Split(line, sep))
+		}
+	}
+	return s.Err()
+}
+
+// processAsync implements ProcessAsync for synchronous handlers.
+func processAsync(h Handler, r *Record, callback func(o interface{})) error {
+	o, err := h.ProcessSync(r)
+	if err == nil {
+		callback(o)
+	}
+	return err
+}
+
+// HostsHandler resolves network flow endpoints to host names from a hosts-style file.
+type HostsHandler struct {
+	names map[string]string
+}
+
+// NewHostsHandler creates a new reverse-DNS handler.
+func NewHostsHandler() Handler {
+	return &HostsHandler{names: make(map[string]string)}
+}
+
+// Init loads the hosts file at confPath (default: /etc/hosts). The first name of an address is its canonical name.
+func (h *HostsHandler) Init(confPath string) error {
+	if confPath == "" {
+		confPath = DefaultHostsDB
+	}
+	return readLines(confPath, "", func(fields []string) {
+		if _, ok := h.names[fields[0]]; !ok && len(fields) > 1 {
+			h.names[fields[0]] = fields[1]
+		}
+	})
+}
+
+// ProcessSync adds the host names of the source and destination addresses of network flows.
+func (h *HostsHandler) ProcessSync(r *Record) (interface{}, error) {
+	if Mapper.MapStr(SF_TYPE)(r) != TyNF {
+		return r, nil
+	}
+	if n, ok := h.names[Mapper.MapStr(SF_NET_SIP)(r)]; ok {
+		r.Ctx.AddEnrichment(EnrichNetSHost, n)
+	}
+	if n, ok := h.names[Mapper.MapStr(SF_NET_DIP)(r)]; ok {
+		r.Ctx.AddEnrichment(EnrichNetDHost, n)
+	}
+	return r, nil
+}
+
+// ProcessAsync enriches r and calls callback with the enriched record.
+func (h *HostsHandler) ProcessAsync(r *Record, callback func(o interface{})) error {
+	return processAsync(h, r, callback)
+}
+
+// Cleanup releases the handler resources.
+func (h *HostsHandler) Cleanup() error {
+	return nil
+}
+
+// PasswdHandler maps process user IDs to user names from a passwd-style file.
+type PasswdHandler struct {
+	users map[int64]string
+}
+
+// NewPasswdHandler creates a new user name handler.
+func NewPasswdHandler() Handler {
+	return &PasswdHandler{users: make(map[int64]string)}
+}
+
+// Init loads the passwd file at confPath (default: /etc/passwd).
+func (h *PasswdHandler) Init(confPath string) error {
+	if confPath == "" {
+		confPath = DefaultPasswdDB
+	}
+	return readLines(confPath, ":", func(fields []string) {
+		if len(fields) < 3 {
+			return
+		}
+		if uid, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			if _, ok := h.users[uid]; !ok {
+				h.users[uid] = fields[0]
+			}
+		}
+	})
+}
+
+// ProcessSync adds the user name of the process user ID.
+func (h *PasswdHandler) ProcessSync(r *Record) (interface{}, error) {
+	if n, ok := h.users[Mapper.MapInt(SF_PROC_UID)(r)]; ok {
+		r.Ctx.AddEnrichment(EnrichProcUser, n)
+	}
+	return r, nil
+}
+
+// ProcessAsync enriches r and calls callback with the enriched record.
+func (h *PasswdHandler) ProcessAsync(r *Record, callback func(o interface{})) error {
+	return processAsync(h, r, callback)
+}
+
+// Cleanup releases the handler resources.
+func (h *PasswdHandler) Cleanup() error {
+	return nil
+}
+
+// HostMetaHandler adds static host metadata to all records.
+type HostMetaHandler struct {
+	meta map[string]string
+}
+
+// NewHostMetaHandler creates a new host metadata handler.
+func NewHostMetaHandler() Handler {
+	return &HostMetaHandler{meta: make(map[string]string)}
+}
+
+// Init parses host metadata given as comma-separated key=value pairs (e.g., cluster=prod,region=us-east).
+func (h *HostMetaHandler) Init(conf string) error {
+	for _, kv := range strings.Split(conf, ",") {
+		if kv = strings.TrimSpace(kv); kv == "" {
+			continue
+		}
+		p := strings.SplitN(kv, "=", 2)
+		if len(p) != 2 || strings.TrimSpace(p[0]) == "" {
+			return errors.New("Host metadata must be of the form key=value: " + kv)
+		}
+		h.meta[EnrichHostMeta+strings.TrimSpace(p[0])] = strings.TrimSpace(p[1])
+	}
+	return nil
+}
+
+// ProcessSync adds the host metadata.
+func (h *HostMetaHandler) ProcessSync(r *Record) (interface{}, error) {
+	for k, v := range h.meta {
+		r.Ctx.AddEnrichment(k, v)
+	}
+	return r, nil
+}
+
+// ProcessAsync enriches r and calls callback with the enriched record.
+func (h *HostMetaHandler) ProcessAsync(r *Record, callback func(o interface{})) error {
+	return processAsync(h, r, callback)
+}
+
+// Cleanup releases the handler resources.
+func (h *HostMetaHandler) Cleanup() error {
+	return nil
+}
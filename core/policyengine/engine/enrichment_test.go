@@ -0,0 +1,83 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	. "github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+func TestHostsHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "enrichment")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	h, err := NewHandler(HostsHandlerName)
+	assert.NoError(t, err)
+	path := writeListFile(t, dir, "hosts", "# hosts\n10.1.2.3 db.local db # database\n10.1.2.3 other.local\n192.168.1.20\tweb.local\n")
+	assert.NoError(t, h.Init(path))
+	r := newNetTestRecord("192.168.1.20", "10.1.2.3")
+	_, err = h.ProcessSync(r)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{EnrichNetSHost: "web.local", EnrichNetDHost: "db.local"}, r.Ctx.GetEnrichment())
+	r = newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/curl", "")
+	_, err = h.ProcessSync(r)
+	assert.NoError(t, err)
+	assert.Nil(t, r.Ctx.GetEnrichment())
+	assert.Error(t, h.Init(dir+"/missing"))
+}
+
+func TestPasswdHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "enrichment")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	h, err := NewHandler(PasswdHandlerName)
+	assert.NoError(t, err)
+	assert.NoError(t, h.Init(writeListFile(t, dir, "passwd", "root:x:0:0:root:/root:/bin/bash\nbad\nalice:x:1000:1000::/home/alice:/bin/sh\n")))
+	r := newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/sudo", "")
+	r.Fr.Ints[0][sfgo.PROC_UID_INT] = 1000
+	var out interface{}
+	assert.NoError(t, h.ProcessAsync(r, func(o interface{}) { out = o }))
+	assert.Equal(t, r, out)
+	assert.Equal(t, "alice", r.Ctx.GetEnrichment()[EnrichProcUser])
+	r = newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/sudo", "")
+	r.Fr.Ints[0][sfgo.PROC_UID_INT] = 1001
+	_, err = h.ProcessSync(r)
+	assert.NoError(t, err)
+	assert.Nil(t, r.Ctx.GetEnrichment())
+}
+
+func TestHostMetaHandler(t *testing.T) {
+	h, err := NewHandler(HostMetaHandlerName)
+	assert.NoError(t, err)
+	assert.NoError(t, h.Init("cluster=prod, region = us-east,"))
+	r := newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/bash", "")
+	_, err = h.ProcessSync(r)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"host.cluster": "prod", "host.region": "us-east"}, r.Ctx.GetEnrichment())
+	h, _ = NewHandler(HostMetaHandlerName)
+	assert.Error(t, h.Init("cluster"))
+	_, err = NewHandler("geoip")
+	assert.Error(t, err)
+}
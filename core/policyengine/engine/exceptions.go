@@ -0,0 +1,163 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/lang/parser"
+)
+
+// exception is a Falco rule exception, which excludes records whose fields compare to any tuple of values.
+type exception struct {
+	name   string
+	fields []string
+	comps  []string
+	values [][][]string
+}
+
+// exceptionOps maps exception comparison operators to criteria constructors.
+var exceptionOps = map[string]func(string, string) Criterion{
+	"=":          Eq,
+	"!=":         NEq,
+	"<":          Lt,
+	"<=":         Le,
+	">":          Gt,
+	">=":         Ge,
+	"contains":   Contains,
+	"icontains":  IContains,
+	"startswith": StartsWith,
+	"endswith":   EndsWith,
+}
+
+// mergeExceptions appends the values of appended exceptions to the exceptions with the same name,
+// and adds the exceptions not yet defined.
+func mergeExceptions(es []*exception, appended []*exception) []*exception {
+	for _, a := range appended {
+		merged := false
+		for _, e := range es {
+			if e.name == a.name {
+				e.values = append(e.values, a.values...)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			es = append(es, a)
+		}
+	}
+	return es
+}
+
+func (listener *sfplListener) getExceptions(ctx *parser.PruleContext) []*exception {
+	var es []*exception
+	for _, ectxs := range ctx.AllExceptions() {
+		for _, ectx := range ectxs.(*parser.ExceptionsContext).AllException() {
+			es = append(es, listener.getException(ectx.(*parser.ExceptionContext)))
+		}
+	}
+	return es
+}
+
+func (listener *sfplListener) getException(ctx *parser.ExceptionContext) *exception {
+	e := &exception{name: ctx.ID().GetText()}
+	single := false
+	if f := ctx.Fields(0); f != nil {
+		fctx := f.(*parser.FieldsContext)
+		if fctx.Items() != nil {
+			e.fields = listener.extractListFromItems(fctx.Items())
+		} else {
+			e.fields = []string{fctx.Atom().GetText()}
+			single = true
+		}
+	}
+	if c := ctx.Comps(0); c != nil {
+		for _, cctx := range c.(*parser.CompsContext).AllComp() {
+			e.comps = append(e.comps, cctx.GetText())
+		}
+	}
+	if v := ctx.Values(0); v != nil {
+		for _, vctx := range v.(*parser.ValuesContext).AllValue() {
+			vc := vctx.(*parser.ValueContext)
+			if single || vc.Atom() != nil {
+				e.values = append(e.values, [][]string{listener.extractValue(vc)})
+				continue
+			}
+			var row [][]string
+			for _, i := range vc.AllValue() {
+				row = append(row, listener.extractValue(i.(*parser.ValueContext)))
+			}
+			e.values = append(e.values, row)
+		}
+	}
+	return e
+}
+
+// extractValue returns the items of an exception value, flattening nested lists.
+func (listener *sfplListener) extractValue(ctx *parser.ValueContext) []string {
+	if ctx.Atom() != nil {
+		return []string{ctx.Atom().GetText()}
+	}
+	s := []string{}
+	for _, v := range ctx.AllValue() {
+		s = append(s, listener.extractValue(v.(*parser.ValueContext))...)
+	}
+	return s
+}
+
+// visitExceptions compiles a criterion matching records covered by any of the exceptions in es.
+func (listener *sfplListener) visitExceptions(es []*exception) Criterion {
+	var cs []Criterion
+	for _, e := range es {
+		for _, row := range e.values {
+			if len(row) != len(e.fields) {
+				logger.Warn.Printf("Exception %s has %d fields, but values %v\n", e.name, len(e.fields), row)
+				continue
+			}
+			terms := make([]Criterion, 0, len(row))
+			for i, attr := range e.fields {
+				comp := "="
+				if i < len(e.comps) {
+					comp = e.comps[i]
+				}
+				terms = append(terms, listener.visitComparison(attr, comp, row[i]))
+			}
+			cs = append(cs, All(terms))
+		}
+	}
+	return Any(cs)
+}
+
+func (listener *sfplListener) visitComparison(attr string, comp string, value []string) Criterion {
+	var list []string
+	var exts []*ExternalList
+	for _, v := range value {
+		list = append(list, listener.reduceList(v)...)
+		exts = append(exts, listener.reduceExternalLists(v)...)
+	}
+	switch comp {
+	case "in", "pmatch", "in_cidr":
+		return listCriterion(comp, attr, list, exts)
+	}
+	if op, ok := exceptionOps[comp]; ok && len(value) == 1 {
+		return op(attr, value[0])
+	}
+	logger.Warn.Printf("Unrecognized exception comparison %s %s %v\n", attr, comp, value)
+	return False
+}
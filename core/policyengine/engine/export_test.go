@@ -0,0 +1,46 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+// TypedPredicates toggles typed predicate compilation in tests.
+var TypedPredicates = &typedPredicates
+
+// RecompileConditions recompiles the conditions of the compiled rules and filters.
+func RecompileConditions() {
+	(&sfplListener{}).compileConditions(0, 0)
+	ruleIdx = NewRuleIndex(rules)
+}
+
+// SetNullSemantics sets the null semantics of predicates and returns the previous semantics.
+func SetNullSemantics(n NullSemantics) NullSemantics {
+	prev := nullSemantics
+	nullSemantics = n
+	return prev
+}
+
+// NewTestRule creates an enabled rule with condition c.
+func NewTestRule(name string, c Criterion) Rule {
+	return Rule{Name: name, condition: c, Enabled: true}
+}
+
+// WaitLoad waits for background pod reloads to complete.
+func (pr *PodResolver) WaitLoad() {
+	pr.wg.Wait()
+}
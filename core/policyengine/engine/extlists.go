@@ -0,0 +1,378 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+)
+
+// External list file formats.
+const (
+	TextListFormat string = "text"
+	CSVListFormat  string = "csv"
+	JSONListFormat string = "json"
+)
+
+// External lists referenced by policies, by list name.
+var extLists = make(map[string]*ExternalList)
+
+// ExternalList is a list whose items are loaded from an external file, and refreshed when the file changes.
+// Text files contain one item per line, CSV files an item per row in a given column, and JSON files an
+// array of items, or of objects whose items are in a given field. Empty lines and lines starting with #
+// are skipped in text and CSV files.
+type ExternalList struct {
+	Path    string
+	Format  string
+	Column  string
+	modTime time.Time
+	size    int64
+	lookups int
+	mu      sync.Mutex
+	data    atomic.Value
+}
+
+// Lookup structures built for the list operators applied to an external list.
+const (
+	setLookup int = 1 << iota
+	matchLookup
+	netsLookup
+)
+
+// listData holds a snapshot of the items of an external list, and the lookup structures built from them.
+type listData struct {
+	items  []string
+	hasSep bool
+	set    map[string]bool
+	match  itemMatcher
+	nets   *cidrTrie
+}
+
+// newListData creates a list snapshot of items, building the given lookup structures.
+func newListData(items []string, lookups int) *listData {
+	d := &listData{items: items}
+	for _, i := range items {
+		if strings.Contains(i, LISTSEP) {
+			d.hasSep = true
+			break
+		}
+	}
+	if lookups&setLookup != 0 {
+		d.set = toSet(items)
+	}
+	if lookups&matchLookup != 0 {
+		if len(items) == 0 {
+			d.match = func(string) bool { return false }
+		} else {
+			d.match = containsMatcher(items)
+		}
+	}
+	if lookups&netsLookup != 0 {
+		d.nets = newCIDRTrie(items)
+	}
+	return d
+}
+
+// NewExternalList creates and loads an external list from path. If format is empty,
+// it is inferred from the file extension.
+func NewExternalList(path string, format string, column string) (*ExternalList, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			format = CSVListFormat
+		case ".json":
+			format = JSONListFormat
+		default:
+			format = TextListFormat
+		}
+	}
+	if format != TextListFormat && format != CSVListFormat && format != JSONListFormat {
+		return nil, errors.New("Unrecognized list format '" + format + "' for list file " + path)
+	}
+	l := &ExternalList{Path: path, Format: format, Column: column}
+	if _, err := l.Refresh(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Refresh reloads the list if its file has changed since it was last loaded. On errors, the current items are kept.
+func (l *ExternalList) Refresh() (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fi, err := os.Stat(l.Path)
+	if err != nil {
+		return false, err
+	}
+	if l.data.Load() != nil && fi.ModTime().Equal(l.modTime) && fi.Size() == l.size {
+		return false, nil
+	}
+	f, err := os.Open(l.Path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	items, err := l.parse(f)
+	if err != nil {
+		return false, fmt.Errorf("Error parsing list file %s: %v", l.Path, err)
+	}
+	l.data.Store(newListData(items, l.lookups))
+	l.modTime, l.size = fi.ModTime(), fi.Size()
+	logger.Trace.Printf("Loaded %d items from list file %s\n", len(items), l.Path)
+	return true, nil
+}
+
+// Items returns the current items of the list.
+func (l *ExternalList) Items() []string {
+	return l.get().items
+}
+
+func (l *ExternalList) get() *listData {
+	return l.data.Load().(*listData)
+}
+
+// require builds a lookup structure for the list, and rebuilds it whenever the list is refreshed.
+func (l *ExternalList) require(lookup int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.lookups&lookup != 0 {
+		return
+	}
+	l.lookups |= lookup
+	l.data.Store(newListData(l.get().items, l.lookups))
+}
+
+func (l *ExternalList) parse(r io.Reader) ([]string, error) {
+	switch l.Format {
+	case CSVListFormat:
+		return parseCSVList(r, l.Column)
+	case JSONListFormat:
+		return parseJSONList(r, l.Column)
+	}
+	return parseTextList(r)
+}
+
+func parseTextList(r io.Reader) ([]string, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var items []string
+	for _, line := range strings.Split(string(b), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			items = append(items, line)
+		}
+	}
+	return items, nil
+}
+
+// parseCSVList extracts the items of a CSV column, given by a zero-based index or, if the file has a header, by name.
+func parseCSVList(r io.Reader, column string) ([]string, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	col, err := strconv.Atoi(column)
+	header := column != "" && err != nil
+	if column == "" {
+		col = 0
+	} else if !header && col < 0 {
+		return nil, errors.New("Negative column " + column)
+	}
+	var items []string
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if header {
+			header, col = false, -1
+			for i, name := range rec {
+				if strings.TrimSpace(name) == column {
+					col = i
+				}
+			}
+			if col < 0 {
+				return nil, errors.New("Missing column " + column)
+			}
+			continue
+		}
+		if col < len(rec) {
+			if v := strings.TrimSpace(rec[col]); v != "" {
+				items = append(items, v)
+			}
+		}
+	}
+	return items, nil
+}
+
+// parseJSONList extracts the items of a JSON array, or the values of field column of an array of objects.
+func parseJSONList(r io.Reader, column string) ([]string, error) {
+	var values []interface{}
+	if err := json.NewDecoder(r).Decode(&values); err != nil {
+		return nil, err
+	}
+	var items []string
+	for _, v := range values {
+		if o, ok := v.(map[string]interface{}); ok && column != "" {
+			v = o[column]
+		}
+		switch t := v.(type) {
+		case string:
+			items = append(items, t)
+		case float64, bool:
+			items = append(items, fmt.Sprintf("%v", t))
+		}
+	}
+	return items, nil
+}
+
+func (d *listData) contains(s string) bool {
+	return d.set[s]
+}
+
+func (d *listData) pmatch(s string) bool {
+	return d.match(s)
+}
+
+// matchValue matches an attribute value item by item, and as a whole if some list item contains a LISTSEP.
+func (d *listData) matchValue(s string, match itemMatcher) bool {
+	return (d.hasSep && match(s)) || anyItem(s, match)
+}
+
+// InExternal creates a criterion for inclusion in an external list.
+func InExternal(attr string, l *ExternalList) Criterion {
+	l.require(setLookup)
+	m := Mapper.MapStr(attr)
+	p := func(r *Record) bool {
+		d := l.get()
+		return d.matchValue(m(r), d.contains)
+	}
+	return guard(Criterion{Pred: p}, attr)
+}
+
+// PMatchExternal creates a criterion for pattern matching against an external list.
+func PMatchExternal(attr string, l *ExternalList) Criterion {
+	l.require(matchLookup)
+	m := Mapper.MapStr(attr)
+	p := func(r *Record) bool {
+		d := l.get()
+		return d.matchValue(m(r), d.pmatch)
+	}
+	return guard(Criterion{Pred: p}, attr)
+}
+
+// InCIDRExternal creates a criterion for membership in the networks of an external list.
+func InCIDRExternal(attr string, l *ExternalList) Criterion {
+	l.require(netsLookup)
+	ips := compileIPOperand(attr)
+	p := func(r *Record) bool {
+		d := l.get()
+		for _, ip := range ips(r) {
+			if d.nets.contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	return guard(Criterion{Pred: p}, attr)
+}
+
+// listCriterion creates a criterion for list operator op (in, pmatch, or in_cidr) over constant items and external lists.
+func listCriterion(op string, attr string, items []string, exts []*ExternalList) Criterion {
+	var cs []Criterion
+	if len(items) > 0 || len(exts) == 0 {
+		switch op {
+		case "in":
+			cs = append(cs, In(attr, items))
+		case "pmatch":
+			cs = append(cs, PMatch(attr, items))
+		case "in_cidr":
+			cs = append(cs, InCIDR(attr, items))
+		}
+	}
+	for _, l := range exts {
+		switch op {
+		case "in":
+			cs = append(cs, InExternal(attr, l))
+		case "pmatch":
+			cs = append(cs, PMatchExternal(attr, l))
+		case "in_cidr":
+			cs = append(cs, InCIDRExternal(attr, l))
+		}
+	}
+	return Any(cs)
+}
+
+// listRefresh stops the running external list refresher, if any.
+var listRefresh chan struct{}
+
+// startListRefresh periodically refreshes all external lists referenced by policies.
+func startListRefresh(interval time.Duration) {
+	stopListRefresh()
+	if interval <= 0 || len(extLists) == 0 {
+		return
+	}
+	ls := make([]*ExternalList, 0, len(extLists))
+	for _, l := range extLists {
+		ls = append(ls, l)
+	}
+	stop := make(chan struct{})
+	listRefresh = stop
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+				for _, l := range ls {
+					if _, err := l.Refresh(); err != nil {
+						logger.Warn.Println("Error refreshing list file: ", err)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// stopListRefresh stops refreshing external lists.
+func stopListRefresh() {
+	if listRefresh != nil {
+		close(listRefresh)
+		listRefresh = nil
+	}
+}
@@ -0,0 +1,201 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	. "github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+func writeListFile(tb testing.TB, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	assert.NoError(tb, ioutil.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestExternalListFormats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "extlists")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	l, err := NewExternalList(writeListFile(t, dir, "hosts.txt", "# comment\na.com\n\n b.com \n"), "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, TextListFormat, l.Format)
+	assert.Equal(t, []string{"a.com", "b.com"}, l.Items())
+	l, err = NewExternalList(writeListFile(t, dir, "users.csv", "name,uid\nroot,0\nalice,1000\n"), "", "uid")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0", "1000"}, l.Items())
+	l, err = NewExternalList(writeListFile(t, dir, "users.list", "root,0\nalice,1000\n"), CSVListFormat, "0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"root", "alice"}, l.Items())
+	l, err = NewExternalList(writeListFile(t, dir, "nets.json", `["10.0.0.0/8", 42]`), "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/8", "42"}, l.Items())
+	l, err = NewExternalList(writeListFile(t, dir, "iocs.json", `[{"ip": "1.2.3.4"}, {"host": "x"}]`), "", "ip")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.2.3.4"}, l.Items())
+	_, err = NewExternalList(filepath.Join(dir, "missing.txt"), "", "")
+	assert.Error(t, err)
+	_, err = NewExternalList(writeListFile(t, dir, "bad.json", `{"a": 1}`), "", "")
+	assert.Error(t, err)
+	_, err = NewExternalList(filepath.Join(dir, "users.csv"), "", "gid")
+	assert.Error(t, err)
+	_, err = NewExternalList(filepath.Join(dir, "users.csv"), "xml", "")
+	assert.Error(t, err)
+}
+
+func TestExternalListRefresh(t *testing.T) {
+	dir, err := ioutil.TempDir("", "extlists")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := writeListFile(t, dir, "users.txt", "root\n")
+	l, err := NewExternalList(path, "", "")
+	assert.NoError(t, err)
+	c := InExternal(SF_PROC_USER, l)
+	pc := PMatchExternal(SF_PROC_USER, l)
+	r := newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/sudo", "")
+	r.Fr.Strs[0][sfgo.PROC_USERNAME_STR] = "alice"
+	assert.False(t, c.Eval(r))
+	assert.False(t, pc.Eval(r))
+	changed, err := l.Refresh()
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	writeListFile(t, dir, "users.txt", "root\nalice\n")
+	changed, err = l.Refresh()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, c.Eval(r))
+	assert.True(t, pc.Eval(r))
+	assert.NoError(t, os.Remove(path))
+	_, err = l.Refresh()
+	assert.Error(t, err)
+	assert.True(t, c.Eval(r))
+	path = writeListFile(t, dir, "nets.txt", "10.0.0.0/8\n")
+	l, err = NewExternalList(path, "", "")
+	assert.NoError(t, err)
+	nc := InCIDRExternal(SF_NET_DIP, l)
+	r = newNetTestRecord("10.0.0.5", "192.168.0.1")
+	assert.False(t, nc.Eval(r))
+	writeListFile(t, dir, "nets.txt", "10.0.0.0/8\n192.168.0.0/16\n")
+	changed, err = l.Refresh()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, nc.Eval(r))
+}
+
+func TestExternalListPolicies(t *testing.T) {
+	compileTestPolicies(t)
+	rules := matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/xmrig", "-o stratum.example.net:3333"))
+	assert.True(t, rules["Miner pool in arguments"])
+	assert.False(t, matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/curl", "example.net"))["Miner pool in arguments"])
+	r := newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/sudo", "")
+	r.Fr.Strs[0][sfgo.PROC_USERNAME_STR] = "mallory"
+	assert.True(t, matchedRules(r)["Privileged tool by unexpected user"])
+	r = newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/sudo", "")
+	r.Fr.Strs[0][sfgo.PROC_USERNAME_STR] = "alice"
+	assert.False(t, matchedRules(r)["Privileged tool by unexpected user"])
+	assert.True(t, matchedRules(newNetTestRecord("10.0.0.5", "203.0.113.77"))["Connection to IOC network"])
+	assert.True(t, matchedRules(newNetTestRecord("10.0.0.1", "198.51.100.7"))["Connection to IOC network"])
+	assert.True(t, matchedRules(newNetTestRecord("10.0.0.5", "192.0.2.1"))["Connection to IOC network"])
+	assert.False(t, matchedRules(newNetTestRecord("10.0.0.5", "198.51.100.8"))["Connection to IOC network"])
+}
+
+func TestExternalListErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "extlists")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := writeListFile(t, dir, "policy.yaml", "- list: missing_hosts\n  source: missing.txt\n")
+	pi := NewPolicyInterpreter(Config{})
+	defer pi.Close()
+	assert.Error(t, pi.Compile(path))
+}
+
+const benchListSize = 100000
+
+func newBenchList(b *testing.B) *ExternalList {
+	dir, err := ioutil.TempDir("", "extlists")
+	assert.NoError(b, err)
+	b.Cleanup(func() { os.RemoveAll(dir) })
+	var sb strings.Builder
+	for i := 0; i < benchListSize; i++ {
+		fmt.Fprintf(&sb, "10.%d.%d.0/24\n", i/256%256, i%256)
+	}
+	l, err := NewExternalList(writeListFile(b, dir, "items.txt", sb.String()), "", "")
+	assert.NoError(b, err)
+	return l
+}
+
+func BenchmarkExternalListLoad(b *testing.B) {
+	l := newBenchList(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l2, _ := NewExternalList(l.Path, "", "")
+		InExternal(SF_NET_DIP, l2).Eval(newNetTestRecord("10.0.0.1", "10.0.0.1"))
+	}
+}
+
+func BenchmarkExternalListIn(b *testing.B) {
+	c := InExternal(SF_NET_DIP, newBenchList(b))
+	r := newNetTestRecord("192.168.0.1", "10.1.2.3")
+	c.Eval(r)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Eval(r)
+	}
+}
+
+func BenchmarkExternalListPMatch(b *testing.B) {
+	c := PMatchExternal(SF_NET_DIP, newBenchList(b))
+	r := newNetTestRecord("192.168.0.1", "10.1.2.3")
+	c.Eval(r)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Eval(r)
+	}
+}
+
+func BenchmarkExternalListInCIDR(b *testing.B) {
+	c := InCIDRExternal(SF_NET_DIP, newBenchList(b))
+	r := newNetTestRecord("192.168.0.1", "10.1.2.3")
+	c.Eval(r)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Eval(r)
+	}
+}
+
+func BenchmarkExternalListRefresh(b *testing.B) {
+	l := newBenchList(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Refresh()
+	}
+}
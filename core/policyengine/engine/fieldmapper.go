@@ -22,6 +22,7 @@ package engine
 import (
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -40,23 +41,116 @@ type IntFieldMap func(r *Record) int64
 // StrFieldMap is a functional type denoting a string attribute mapper.
 type StrFieldMap func(r *Record) string
 
+// PresenceMap is a functional type denoting an attribute presence test.
+type PresenceMap func(r *Record) bool
+
+// FieldType denotes the value type of an attribute mapper.
+type FieldType int
+
+// Field type enumeration.
+const (
+	DynType FieldType = iota
+	IntType
+	StrType
+	BoolType
+)
+
 // FieldMapper is an adapter for SysFlow attribute mappers.
 type FieldMapper struct {
-	Mappers map[string]FieldMap
+	Mappers  map[string]FieldMap
+	Types    map[string]FieldType
+	Presence map[string]PresenceMap
 }
 
-// Map retrieves a field map based on a SysFlow attribute.
+// IsField returns true if attr is a mapped attribute.
+func (m FieldMapper) IsField(attr string) bool {
+	if _, ok := m.Mappers[attr]; ok {
+		return true
+	}
+	_, _, ok := indexedAttribute(attr)
+	return ok
+}
+
+// Type returns the value type of attr, or DynType if the type can only be resolved at evaluation time.
+func (m FieldMapper) Type(attr string) FieldType {
+	if t, ok := m.Types[attr]; ok {
+		return t
+	}
+	if a, _, ok := indexedAttribute(attr); ok {
+		if a == ProcAPID {
+			return IntType
+		}
+		return StrType
+	}
+	return DynType
+}
+
+// Map retrieves a field map based on a SysFlow attribute. Constants map to their literal value.
 func (m FieldMapper) Map(attr string) FieldMap {
 	if mapper, ok := m.Mappers[attr]; ok {
 		return mapper
 	}
-	return func(r *Record) interface{} { return attr }
+	if a, n, ok := indexedAttribute(attr); ok {
+		return mapAncestorValue(sfgo.SYSFLOW_SRC, a, n)
+	}
+	v := unquote(attr)
+	return func(r *Record) interface{} { return v }
+}
+
+// MapPresent retrieves a presence test based on a SysFlow attribute.
+// Attributes are absent from records of types in which they are not defined, from records lacking
+// their source, container attributes from records without a container, pod attributes from records
+// whose container is not in a known pod, and cached attributes from records whose process ancestry
+// is unknown. Constants are always present.
+func (m FieldMapper) MapPresent(attr string) PresenceMap {
+	if p, ok := m.presence(attr); ok {
+		return p
+	}
+	return func(r *Record) bool { return true }
+}
+
+// presence retrieves the presence test of attr, if attr may be absent from a record.
+func (m FieldMapper) presence(attr string) (PresenceMap, bool) {
+	if p, ok := m.Presence[attr]; ok {
+		return p, true
+	}
+	if _, n, ok := indexedAttribute(attr); ok {
+		return mapHasAncestor(sfgo.SYSFLOW_SRC, n), true
+	}
+	return nil, false
+}
+
+// indexedre matches indexed attributes, e.g., sf.proc.aname[2].
+var indexedre = regexp.MustCompile(`^(.+)\[([0-9]+)\]$`)
+
+// indexedAttribute resolves an indexed ancestry attribute into its ancestry attribute and the index of the
+// ancestor, where 0 denotes the process itself, 1 its parent, and so on.
+func indexedAttribute(attr string) (RecAttribute, int, bool) {
+	if m := indexedre.FindStringSubmatch(attr); m != nil {
+		if a, ok := ancestryAttributes[m[1]]; ok {
+			if n, err := strconv.Atoi(m[2]); err == nil && n < maxAncestryDepth {
+				return a, n, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// ancestryAttributes maps ancestry attributes that can be indexed to their record attributes.
+var ancestryAttributes = map[string]RecAttribute{
+	SF_PROC_ANAME:    ProcAName,
+	SF_PROC_AEXE:     ProcAExe,
+	SF_PROC_ACMDLINE: ProcACmdLine,
+	SF_PROC_APID:     ProcAPID,
+	FALCO_PROC_ANAME: ProcAName,
+	FALCO_PROC_APID:  ProcAPID,
 }
 
 // MapInt retrieves a numerical field map based on a SysFlow attribute.
 func (m FieldMapper) MapInt(attr string) IntFieldMap {
+	mapper := m.Map(attr)
 	return func(r *Record) int64 {
-		if v, ok := m.Map(attr)(r).(int64); ok {
+		if v, ok := mapper(r).(int64); ok {
 			return v
 		} else if v, err := strconv.ParseInt(attr, 10, 64); err == nil {
 			return v
@@ -67,12 +161,14 @@ func (m FieldMapper) MapInt(attr string) IntFieldMap {
 
 // MapStr retrieves a string field map based on a SysFlow attribute.
 func (m FieldMapper) MapStr(attr string) StrFieldMap {
+	mapper := m.Map(attr)
 	return func(r *Record) string {
-		if v, ok := m.Map(attr)(r).(string); ok {
-			return trimBoundingQuotes(v)
-		} else if v, ok := m.Map(attr)(r).(int64); ok {
+		switch v := mapper(r).(type) {
+		case string:
+			return v
+		case int64:
 			return strconv.FormatInt(v, 10)
-		} else if v, ok := m.Map(attr)(r).(bool); ok {
+		case bool:
 			return strconv.FormatBool(v)
 		}
 		return sfgo.Zeros.String
@@ -82,12 +178,134 @@ func (m FieldMapper) MapStr(attr string) StrFieldMap {
 // Fields defines a sorted array of all exported field mapper keys.
 var Fields = getFields()
 
+// ExtFields defines a sorted array of all extended field mapper keys.
+var ExtFields = getExtFields()
+
 // Mapper defines a global attribute mapper instance.
-var Mapper = FieldMapper{getMappers()}
+var Mapper = newFieldMapper()
+
+func newFieldMapper() FieldMapper {
+	mappers := getMappers()
+	return FieldMapper{Mappers: mappers, Types: getFieldTypes(mappers), Presence: getPresenceMappers(mappers)}
+}
+
+// getPresenceMappers defines presence tests for attributes that may be absent from a record.
+func getPresenceMappers(mappers map[string]FieldMap) map[string]PresenceMap {
+	cached := getCachedAttributes()
+	presence := make(map[string]PresenceMap)
+	for k := range mappers {
+		var tests []PresenceMap
+		if types := attrTypes(k); types != nil {
+			tests = append(tests, mapRecTypeIn(sfgo.SYSFLOW_SRC, types))
+		}
+		for _, s := range attrSources {
+			if strings.HasPrefix(k, s.prefix) {
+				tests = append(tests, mapHasSource(s.src))
+			}
+		}
+		if strings.HasPrefix(k, "sf.container.") || strings.HasPrefix(k, "container.") {
+			tests = append(tests, mapHasContainer(sfgo.SYSFLOW_SRC))
+		}
+		if strings.HasPrefix(k, "sf.pod.") || strings.HasPrefix(k, "k8s.") {
+			tests = append(tests, mapHasPod(sfgo.SYSFLOW_SRC))
+		}
+		if strings.HasPrefix(k, "sf.rule.") {
+			tests = append(tests, mapHasRules())
+		}
+		if attr, ok := cached[k]; ok {
+			tests = append(tests, mapHasCachedValue(sfgo.SYSFLOW_SRC, attr))
+		}
+		if len(tests) == 1 {
+			presence[k] = tests[0]
+		} else if len(tests) > 1 {
+			presence[k] = mapAllPresent(tests)
+		}
+	}
+	return presence
+}
+
+// attrSources maps attribute prefixes to the record sources that define them.
+var attrSources = []struct {
+	prefix string
+	src    sfgo.Source
+}{
+	{"ext.proc.", sfgo.PROCESS_SRC},
+	{"ext.file.", sfgo.FILE_SRC},
+	{"ext.net.", sfgo.NETWORK_SRC},
+	{"ext.targetproc.", sfgo.TARG_PROC_SRC},
+	{"ext.targetpproc.", sfgo.TARG_PROC_SRC},
+	{"ext.targetcontainer.", sfgo.TARG_PROC_SRC},
+}
+
+// getCachedAttributes maps attributes resolved from the process cache to their record attributes.
+func getCachedAttributes() map[string]RecAttribute {
+	return map[string]RecAttribute{
+		SF_PROC_ANAME:       ProcAName,
+		SF_PROC_AEXE:        ProcAExe,
+		SF_PROC_ACMDLINE:    ProcACmdLine,
+		SF_PROC_APID:        ProcAPID,
+		SF_PROC_ADEPTH:      ProcADepth,
+		SF_PPROC_NAME:       PProcName,
+		SF_PPROC_EXE:        PProcExe,
+		SF_PPROC_ARGS:       PProcArgs,
+		SF_PPROC_UID:        PProcUID,
+		SF_PPROC_USER:       PProcUser,
+		SF_PPROC_GID:        PProcGID,
+		SF_PPROC_GROUP:      PProcGroup,
+		SF_PPROC_TTY:        PProcTTY,
+		SF_PPROC_ENTRY:      PProcEntry,
+		SF_PPROC_CMDLINE:    PProcCmdLine,
+		FALCO_PROC_TTY:      PProcTTY,
+		FALCO_PROC_ANAME:    ProcAName,
+		FALCO_PROC_APID:     ProcAPID,
+		FALCO_PROC_PGID:     PProcGID,
+		FALCO_PROC_PUID:     PProcUID,
+		FALCO_PROC_PGROUP:   PProcGroup,
+		FALCO_PROC_PTTY:     PProcTTY,
+		FALCO_PROC_PUSER:    PProcUser,
+		FALCO_PROC_PEXE:     PProcExe,
+		FALCO_PROC_PARGS:    PProcArgs,
+		FALCO_PROC_PNAME:    PProcName,
+		FALCO_PROC_PCMDLINE: PProcCmdLine,
+	}
+}
+
+// getFieldTypes resolves mapper value types by probing mappers with an empty record.
+func getFieldTypes(mappers map[string]FieldMap) map[string]FieldType {
+	fr := sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}
+	probe := NewRecord(fr, nil)
+	types := make(map[string]FieldType, len(mappers))
+	for k, m := range mappers {
+		switch m(probe).(type) {
+		case int64:
+			types[k] = IntType
+		case string:
+			types[k] = StrType
+		case bool:
+			types[k] = BoolType
+		default:
+			types[k] = DynType
+		}
+	}
+	return types
+}
 
 // getFields returns a sorted array of all exported field mapper keys.
 func getFields() []string {
-	mappers := getExportedMappers()
+	return sortFields(getExportedMappers())
+}
+
+// getExtFields returns a sorted array of all extended field mapper keys.
+func getExtFields() []string {
+	return sortFields(getExtendedMappers())
+}
+
+// sortFields returns the keys of mappers sorted by number of components and name.
+func sortFields(mappers map[string]FieldMap) []string {
 	keys := make([]string, 0, len(mappers))
 	for k := range mappers {
 		keys = append(keys, k)
@@ -105,11 +323,13 @@ func getFields() []string {
 
 func getMappers() map[string]FieldMap {
 	mappers := getExportedMappers()
-	for k, v := range getNonExportedMappers() {
-		if _, ok := mappers[k]; !ok {
-			mappers[k] = v
-		} else if ok {
-			logger.Warn.Println("Duplicate mapper key: ", k)
+	for _, m := range []map[string]FieldMap{getExtendedMappers(), getNonExportedMappers()} {
+		for k, v := range m {
+			if _, ok := mappers[k]; !ok {
+				mappers[k] = v
+			} else if ok {
+				logger.Warn.Println("Duplicate mapper key: ", k)
+			}
 		}
 	}
 	return mappers
@@ -187,6 +407,10 @@ func getExportedMappers() map[string]FieldMap {
 		SF_CONTAINER_IMAGE:      mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_IMAGE_STR),
 		SF_CONTAINER_TYPE:       mapContType(sfgo.SYSFLOW_SRC, sfgo.CONT_TYPE_INT),
 		SF_CONTAINER_PRIVILEGED: mapInt(sfgo.SYSFLOW_SRC, sfgo.CONT_PRIVILEGED_INT),
+		SF_POD_NAME:             mapPod(sfgo.SYSFLOW_SRC, func(p *Pod) string { return p.Name }),
+		SF_POD_NAMESPACE:        mapPod(sfgo.SYSFLOW_SRC, func(p *Pod) string { return p.Namespace }),
+		SF_POD_LABELS:           mapPod(sfgo.SYSFLOW_SRC, (*Pod).LabelString),
+		SF_POD_SERVICEACCOUNT:   mapPod(sfgo.SYSFLOW_SRC, func(p *Pod) string { return p.ServiceAccount }),
 		SF_NODE_ID:              mapStr(sfgo.SYSFLOW_SRC, sfgo.SFHE_EXPORTER_STR),
 		SF_NODE_IP:              mapStr(sfgo.SYSFLOW_SRC, sfgo.SFHE_IP_STR),
 		SF_SCHEMA_VERSION:       mapInt(sfgo.SYSFLOW_SRC, sfgo.SFHE_VERSION_INT),
@@ -228,6 +452,7 @@ func getExtendedMappers() map[string]FieldMap {
 		EXT_NET_DEST_PORT_NAME_STR:   mapStr(sfgo.NETWORK_SRC, sfgo.NET_DEST_PORT_NAME_STR),
 
 		//Ext target proc
+		EXT_TARG_PROC_STATE_INT:              mapInt(sfgo.TARG_PROC_SRC, sfgo.EVT_TARG_PROC_STATE_INT),
 		EXT_TARG_PROC_OID_CREATETS_INT:       mapInt(sfgo.TARG_PROC_SRC, sfgo.EVT_TARG_PROC_OID_CREATETS_INT),
 		EXT_TARG_PROC_OID_HPID_INT:           mapInt(sfgo.TARG_PROC_SRC, sfgo.EVT_TARG_PROC_OID_HPID_INT),
 		EXT_TARG_PROC_TS_INT:                 mapInt(sfgo.TARG_PROC_SRC, sfgo.EVT_TARG_PROC_TS_INT),
@@ -270,6 +495,20 @@ func getExtendedMappers() map[string]FieldMap {
 // getNonExportedMappers defines all mappers for non-exported (query-only) attributes.
 func getNonExportedMappers() map[string]FieldMap {
 	return map[string]FieldMap{
+		// SysFlow
+		SF_PROC_ADEPTH:      mapCachedValue(sfgo.SYSFLOW_SRC, ProcADepth),
+		SF_NET_SIP_PRIVATE:  mapInNets(sfgo.SYSFLOW_SRC, privateNets, sfgo.FL_NETW_SIP_INT),
+		SF_NET_DIP_PRIVATE:  mapInNets(sfgo.SYSFLOW_SRC, privateNets, sfgo.FL_NETW_DIP_INT),
+		SF_NET_IP_PRIVATE:   mapInNets(sfgo.SYSFLOW_SRC, privateNets, sfgo.FL_NETW_SIP_INT, sfgo.FL_NETW_DIP_INT),
+		SF_NET_SIP_LOOPBACK: mapInNets(sfgo.SYSFLOW_SRC, loopbackNets, sfgo.FL_NETW_SIP_INT),
+		SF_NET_DIP_LOOPBACK: mapInNets(sfgo.SYSFLOW_SRC, loopbackNets, sfgo.FL_NETW_DIP_INT),
+		SF_NET_IP_LOOPBACK:  mapInNets(sfgo.SYSFLOW_SRC, loopbackNets, sfgo.FL_NETW_SIP_INT, sfgo.FL_NETW_DIP_INT),
+		SF_RULE_NAME:        mapRuleNames(),
+		SF_RULE_TAGS:        mapRuleTags(),
+		SF_RULE_SEVERITY:    mapRuleSeverity(),
+		SF_RULE_PRIORITY:    mapRuleMax(func(rule Rule) int64 { return int64(rule.Priority) }),
+		SF_RULE_SCORE:       mapRuleMax(func(rule Rule) int64 { return int64(rule.Score) }),
+
 		// Falco
 		FALCO_EVT_TYPE:              mapEvtType(sfgo.SYSFLOW_SRC),
 		FALCO_EVT_RAW_RES:           mapRecType(sfgo.SYSFLOW_SRC),
@@ -332,6 +571,9 @@ func getNonExportedMappers() map[string]FieldMap {
 		FALCO_CONT_NAME:             mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_NAME_STR),
 		FALCO_CONT_TYPE:             mapContType(sfgo.SYSFLOW_SRC, sfgo.CONT_TYPE_INT),
 		FALCO_CONT_PRIVILEGED:       mapInt(sfgo.SYSFLOW_SRC, sfgo.CONT_PRIVILEGED_INT),
+		FALCO_K8S_POD_NAME:          mapPod(sfgo.SYSFLOW_SRC, func(p *Pod) string { return p.Name }),
+		FALCO_K8S_NS_NAME:           mapPod(sfgo.SYSFLOW_SRC, func(p *Pod) string { return p.Namespace }),
+		FALCO_K8S_POD_LABELS:        mapPod(sfgo.SYSFLOW_SRC, (*Pod).LabelString),
 	}
 }
 
@@ -529,6 +771,17 @@ func mapIP(src sfgo.Source, attrs ...sfgo.Attribute) FieldMap {
 	}
 }
 
+func mapInNets(src sfgo.Source, nets *cidrTrie, attrs ...sfgo.Attribute) FieldMap {
+	return func(r *Record) interface{} {
+		for _, attr := range attrs {
+			if nets.contains(flatIPToUint32(r.GetInt(attr, src))) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 func mapContType(src sfgo.Source, attr sfgo.Attribute) FieldMap {
 	return func(r *Record) interface{} {
 		return sfgo.GetContType(r.GetInt(attr, src))
@@ -537,11 +790,143 @@ func mapContType(src sfgo.Source, attr sfgo.Attribute) FieldMap {
 
 func mapCachedValue(src sfgo.Source, attr RecAttribute) FieldMap {
 	return func(r *Record) interface{} {
+		if r.Cr == nil {
+			return nil
+		}
 		oid := sfgo.OID{CreateTS: r.GetInt(sfgo.PROC_OID_CREATETS_INT, src), Hpid: r.GetInt(sfgo.PROC_OID_HPID_INT, src)}
 		return r.GetCachedValue(oid, attr)
 	}
 }
 
+func mapAncestorValue(src sfgo.Source, attr RecAttribute, n int) FieldMap {
+	return func(r *Record) interface{} {
+		if r.Cr == nil {
+			if attr == ProcAPID {
+				return sfgo.Zeros.Int64
+			}
+			return sfgo.Zeros.String
+		}
+		oid := sfgo.OID{CreateTS: r.GetInt(sfgo.PROC_OID_CREATETS_INT, src), Hpid: r.GetInt(sfgo.PROC_OID_HPID_INT, src)}
+		return r.GetAncestorValue(oid, attr, n)
+	}
+}
+
+func mapRuleNames() FieldMap {
+	return func(r *Record) interface{} {
+		var names []string
+		for _, rule := range r.Ctx.GetRules() {
+			names = append(names, rule.Name)
+		}
+		return strings.Join(names, LISTSEP)
+	}
+}
+
+func mapRuleTags() FieldMap {
+	return func(r *Record) interface{} {
+		var tags []string
+		for _, rule := range r.Ctx.GetRules() {
+			tags = append(tags, rule.TagNames()...)
+		}
+		return strings.Join(tags, LISTSEP)
+	}
+}
+
+func mapRuleSeverity() FieldMap {
+	return func(r *Record) interface{} {
+		rules := r.Ctx.GetRules()
+		if len(rules) == 0 {
+			return sfgo.Zeros.String
+		}
+		sev := rules[0].Severity
+		for _, rule := range rules[1:] {
+			if rule.Severity > sev {
+				sev = rule.Severity
+			}
+		}
+		return sev.String()
+	}
+}
+
+func mapRuleMax(value func(rule Rule) int64) FieldMap {
+	return func(r *Record) interface{} {
+		var max int64
+		for _, rule := range r.Ctx.GetRules() {
+			if v := value(rule); v > max {
+				max = v
+			}
+		}
+		return max
+	}
+}
+
+func mapPod(src sfgo.Source, value func(p *Pod) string) FieldMap {
+	return func(r *Record) interface{} {
+		if p := lookupPod(r.GetStr(sfgo.CONT_ID_STR, src)); p != nil {
+			return value(p)
+		}
+		return sfgo.Zeros.String
+	}
+}
+
+func mapRecTypeIn(src sfgo.Source, types map[string]bool) PresenceMap {
+	rtype := mapRecType(src)
+	return func(r *Record) bool { return types[rtype(r).(string)] }
+}
+
+func mapHasSource(src sfgo.Source) PresenceMap {
+	return func(r *Record) bool {
+		for _, s := range r.Fr.Sources {
+			if s == src {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func mapHasContainer(src sfgo.Source) PresenceMap {
+	return func(r *Record) bool { return r.GetStr(sfgo.CONT_ID_STR, src) != sfgo.Zeros.String }
+}
+
+func mapHasRules() PresenceMap {
+	return func(r *Record) bool { return len(r.Ctx.GetRules()) > 0 }
+}
+
+func mapHasPod(src sfgo.Source) PresenceMap {
+	return func(r *Record) bool { return lookupPod(r.GetStr(sfgo.CONT_ID_STR, src)) != nil }
+}
+
+func mapHasCachedValue(src sfgo.Source, attr RecAttribute) PresenceMap {
+	return func(r *Record) bool {
+		if r.Cr == nil {
+			return false
+		}
+		oid := sfgo.OID{CreateTS: r.GetInt(sfgo.PROC_OID_CREATETS_INT, src), Hpid: r.GetInt(sfgo.PROC_OID_HPID_INT, src)}
+		return r.HasCachedValue(oid, attr)
+	}
+}
+
+func mapHasAncestor(src sfgo.Source, n int) PresenceMap {
+	return func(r *Record) bool {
+		if r.Cr == nil {
+			return false
+		}
+		oid := sfgo.OID{CreateTS: r.GetInt(sfgo.PROC_OID_CREATETS_INT, src), Hpid: r.GetInt(sfgo.PROC_OID_HPID_INT, src)}
+		return r.HasAncestor(oid, n)
+	}
+}
+
+func mapAllPresent(tests []PresenceMap) PresenceMap {
+	return func(r *Record) bool {
+		for _, t := range tests {
+			if !t(r) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 func mapOID(src sfgo.Source, attrs ...sfgo.Attribute) FieldMap {
 	return func(r *Record) interface{} {
 		h := xxhash.New()
@@ -0,0 +1,183 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/cache"
+	. "github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+// newExtTestRecord creates a multi-source record with extended process and target process attributes.
+func newExtTestRecord() *Record {
+	ints := make([]int64, sfgo.INT_ARRAY_SIZE)
+	strs := make([]string, sfgo.STR_ARRAY_SIZE)
+	ints[sfgo.SF_REC_TYPE] = sfgo.PROC_EVT
+	strs[sfgo.PROC_EXE_STR] = "/usr/bin/bash"
+	pints := make([]int64, sfgo.NUM_EXT_PROC_ATTRS_INT)
+	pstrs := make([]string, sfgo.NUM_EXT_PROC_ATTRS_STR)
+	pstrs[sfgo.PROC_SIGNATURE_STATUS_STR] = "Unavailable"
+	pstrs[sfgo.PROC_SHA256_HASH_STR] = "cafe"
+	tints := make([]int64, sfgo.NUM_EXT_EVT_INT)
+	tstrs := make([]string, sfgo.NUM_EXT_EVT_STR)
+	tints[sfgo.EVT_TARG_PROC_OID_HPID_INT] = 640
+	tstrs[sfgo.EVT_TARG_PROC_IMAGE_STR] = `C:\Windows\System32\lsass.exe`
+	fr := sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC, sfgo.TARG_PROC_SRC, sfgo.PROCESS_SRC},
+		Ints:    [][]int64{ints, tints, pints},
+		Strs:    [][]string{strs, tstrs, pstrs},
+	}
+	return NewRecord(fr, cache.GetInstance())
+}
+
+func TestExtendedAttributes(t *testing.T) {
+	defer SetNullSemantics(SetNullSemantics(StrictNulls))
+	r := newExtTestRecord()
+	assert.Contains(t, ExtFields, EXT_PROC_SHA256_HASH_STR)
+	assert.NotContains(t, Fields, EXT_PROC_SHA256_HASH_STR)
+	assert.Equal(t, "cafe", Mapper.MapStr(EXT_PROC_SHA256_HASH_STR)(r))
+	assert.Equal(t, int64(640), Mapper.MapInt(EXT_TARG_PROC_OID_HPID_INT)(r))
+	assert.Equal(t, StrType, Mapper.Type(EXT_TARG_PROC_IMAGE_STR))
+	assert.Equal(t, true, Exists(EXT_PROC_SIGNED_INT).Eval(r))
+	assert.Equal(t, true, Eq(EXT_PROC_SIGNED_INT, "0").Eval(r))
+	assert.Equal(t, false, Exists(EXT_FILE_SHA256_HASH_STR).Eval(r))
+	assert.Equal(t, false, Eq(EXT_FILE_SHA256_HASH_STR, "").Eval(r))
+	assert.Equal(t, false, Exists(EXT_NET_DEST_HOST_NAME_STR).Eval(newTestRecord(42)))
+}
+
+func TestExtendedAttributesMalformed(t *testing.T) {
+	fr := sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC, sfgo.FILE_SRC, sfgo.NETWORK_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE), {}},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE), {}},
+	}
+	r := NewRecord(fr, nil)
+	assert.Equal(t, "", Mapper.MapStr(EXT_FILE_SHA256_HASH_STR)(r))
+	assert.Equal(t, "", Mapper.MapStr(EXT_NET_DEST_HOST_NAME_STR)(r))
+	assert.Equal(t, int64(0), Mapper.MapInt(EXT_FILE_SIGNED_INT)(r))
+}
+
+func TestExtendedPolicies(t *testing.T) {
+	compileTestPolicies(t)
+	rules := matchedRules(newExtTestRecord())
+	assert.True(t, rules["Unsigned process image"])
+	assert.True(t, rules["Remote thread into lsass"])
+	assert.False(t, rules["Known bad file hash"])
+	assert.False(t, matchedRules(newTestRecord(42))["Remote thread into lsass"])
+}
+
+// setTestProc adds a process with parent ppid to the process cache and returns its OID.
+func setTestProc(pid int64, ppid int64, exe string, user string) sfgo.OID {
+	oid := sfgo.OID{CreateTS: 77, Hpid: pid}
+	p := &sfgo.Process{Oid: &oid, Exe: exe, UserName: user}
+	if ppid != 0 {
+		p.Poid = &sfgo.UnionNullOID{OID: &sfgo.OID{CreateTS: 77, Hpid: ppid}, UnionType: sfgo.UnionNullOIDTypeEnumOID}
+	}
+	cache.GetInstance().SetProc(oid, p)
+	return oid
+}
+
+// newAncestryTestRecord creates a process event for python, spawned by a root shell through sshd.
+func newAncestryTestRecord() *Record {
+	setTestProc(9100, 0, "/sbin/init", "root")
+	setTestProc(9150, 9100, "/usr/sbin/sshd", "root")
+	setTestProc(9200, 9150, "/bin/bash", "root")
+	oid := setTestProc(9300, 9200, "/usr/bin/python", "alice")
+	r := newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/python", "run.py")
+	r.Fr.Ints[0][sfgo.PROC_OID_CREATETS_INT] = oid.CreateTS
+	r.Fr.Ints[0][sfgo.PROC_OID_HPID_INT] = oid.Hpid
+	r.Fr.Ints[0][sfgo.PROC_POID_CREATETS_INT] = 77
+	r.Fr.Ints[0][sfgo.PROC_POID_HPID_INT] = 9200
+	r.Fr.Strs[0][sfgo.PROC_USERNAME_STR] = "alice"
+	return r
+}
+
+func TestIndexedAncestry(t *testing.T) {
+	defer SetNullSemantics(SetNullSemantics(StrictNulls))
+	r := newAncestryTestRecord()
+	assert.True(t, Mapper.IsField("sf.proc.aname[2]"))
+	assert.False(t, Mapper.IsField("sf.proc.exe[2]"))
+	assert.Equal(t, IntType, Mapper.Type("proc.apid[1]"))
+	assert.Equal(t, "python,bash,sshd", Mapper.MapStr(SF_PROC_ANAME)(r))
+	assert.Equal(t, "python", Mapper.MapStr("sf.proc.aname[0]")(r))
+	assert.Equal(t, "/bin/bash", Mapper.MapStr("sf.proc.aexe[1]")(r))
+	assert.Equal(t, int64(9150), Mapper.MapInt("sf.proc.apid[2]")(r))
+	assert.Equal(t, int64(2), Mapper.MapInt(SF_PROC_ADEPTH)(r))
+	assert.Equal(t, true, Eq("proc.aname[2]", "sshd").Eval(r))
+	assert.Equal(t, true, Exists("sf.proc.aname[2]").Eval(r))
+	assert.Equal(t, false, Exists("sf.proc.aname[3]").Eval(r))
+	assert.Equal(t, false, NEq("sf.proc.aname[3]", "sshd").Eval(r))
+}
+
+func TestAncestryGuards(t *testing.T) {
+	setTestProc(9400, 9401, "/bin/a", "root")
+	oid := setTestProc(9401, 9400, "/bin/b", "root")
+	r := newIndexTestRecord(sfgo.PROC_EVT, "/bin/b", "")
+	r.Fr.Ints[0][sfgo.PROC_OID_CREATETS_INT] = oid.CreateTS
+	r.Fr.Ints[0][sfgo.PROC_OID_HPID_INT] = oid.Hpid
+	assert.Equal(t, "b,a", Mapper.MapStr(SF_PROC_ANAME)(r))
+	assert.Equal(t, false, AnyAncestor(Eq(SF_PROC_NAME, "c")).Eval(r))
+	for pid := int64(10000); pid < 10100; pid++ {
+		oid = setTestProc(pid, pid-1, "/bin/deep", "root")
+	}
+	r.Fr.Ints[0][sfgo.PROC_OID_HPID_INT] = oid.Hpid
+	assert.Equal(t, int64(63), Mapper.MapInt(SF_PROC_ADEPTH)(r))
+}
+
+func TestAnyAncestor(t *testing.T) {
+	r := newAncestryTestRecord()
+	assert.Equal(t, true, AnyAncestor(Eq(SF_PROC_NAME, "sshd")).Eval(r))
+	assert.Equal(t, false, AnyAncestor(Eq(SF_PROC_NAME, "python")).Eval(r))
+	assert.Equal(t, true, AnyAncestor(Eq(SF_PROC_NAME, "bash").And(Eq(SF_PROC_USER, "root"))).Eval(r))
+	assert.Equal(t, false, AnyAncestor(Eq(SF_PROC_NAME, "bash").And(Eq(SF_PROC_USER, "alice"))).Eval(r))
+	assert.Equal(t, true, AnyAncestor(Eq(SF_PPROC_NAME, "sshd")).Eval(r))
+	assert.Equal(t, true, AnyAncestor(Eq(SF_CONTAINER_NAME, "node")).Eval(r))
+	assert.Equal(t, "python", Mapper.MapStr(SF_PROC_NAME)(r))
+}
+
+func TestAnyAncestorMultiline(t *testing.T) {
+	r := newAncestryTestRecord()
+	for _, expr := range []string{
+		"any\n  ancestor matches (sf.proc.name = sshd)",
+		"any ancestor\r\n\tmatches (sf.proc.name = sshd)",
+	} {
+		c, err := CompileExpression(expr)
+		assert.NoError(t, err, expr)
+		assert.Equal(t, true, c.Eval(r), expr)
+	}
+	c, err := CompileExpression("any ancestor\nmatches (sf.proc.name = python)")
+	assert.NoError(t, err)
+	assert.Equal(t, false, c.Eval(r))
+}
+
+func TestAncestryPolicies(t *testing.T) {
+	compileTestPolicies(t)
+	rules := matchedRules(newAncestryTestRecord())
+	assert.True(t, rules["Grandparent is sshd"])
+	assert.True(t, rules["Root shell ancestor"])
+	assert.True(t, rules["Deep ancestry"])
+	assert.False(t, rules["Web server ancestor"])
+	rules = matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/python", "run.py"))
+	assert.False(t, rules["Grandparent is sshd"])
+	assert.False(t, rules["Root shell ancestor"])
+}
@@ -0,0 +1,313 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"sort"
+	"strings"
+)
+
+// recTypes lists all record types produced by the type mapper.
+var recTypes = []string{TyP, TyF, TyC, TyH, TyPE, TyFE, TyFF, TyNF, TyUnknow}
+
+// attrFamilies maps attribute prefixes to the record types in which they are defined.
+var attrFamilies = []struct {
+	prefix string
+	types  []string
+}{
+	{"sf.net.", []string{TyNF}},
+	{"sf.file.", []string{TyFF, TyFE}},
+	{"sf.flow.", []string{TyFF, TyNF}},
+	{"ext.net.", []string{TyNF}},
+	{"ext.file.", []string{TyFF, TyFE}},
+	{"sf.ret", []string{TyPE, TyFE}},
+	{"sf.endts", []string{TyFF, TyNF}},
+	{"fd.", []string{TyFF, TyFE, TyNF}},
+	{"fd.sport", []string{TyNF}},
+	{"fd.dport", []string{TyNF}},
+	{"fd.port", []string{TyNF}},
+	{"fd.sip", []string{TyNF}},
+	{"fd.dip", []string{TyNF}},
+	{"fd.ip", []string{TyNF}},
+	{"fd.proto", []string{TyNF}},
+	{"fd.lproto", []string{TyNF}},
+	{"fd.l4proto", []string{TyNF}},
+	{"fd.rproto", []string{TyNF}},
+	{"fd.sproto", []string{TyNF}},
+	{"fd.cproto", []string{TyNF}},
+}
+
+// attrTypes returns the record types in which attr is defined, or nil if attr is defined in all types.
+func attrTypes(attr string) map[string]bool {
+	var types map[string]bool
+	for _, f := range attrFamilies {
+		if strings.HasPrefix(attr, f.prefix) {
+			types = intersect(types, toSet(f.types))
+		}
+	}
+	return types
+}
+
+// indexHint describes necessary conditions implied by a criterion.
+// A nil hint implies nothing about the record.
+type indexHint struct {
+	none   bool            // criterion never holds
+	types  map[string]bool // record types for which the criterion may hold (nil means any)
+	attr   string          // discriminating attribute
+	values map[string]bool // values of attr for which the criterion may hold
+}
+
+// and computes the hint of a conjunction.
+func (h *indexHint) and(o *indexHint) *indexHint {
+	if h == nil {
+		return o
+	}
+	if o == nil {
+		return h
+	}
+	if h.none || o.none {
+		return &indexHint{none: true}
+	}
+	c := &indexHint{types: intersect(h.types, o.types)}
+	if h.attr != "" && (o.attr == "" || len(h.values) <= len(o.values)) {
+		c.attr, c.values = h.attr, h.values
+	} else {
+		c.attr, c.values = o.attr, o.values
+	}
+	return c
+}
+
+// or computes the hint of a disjunction.
+func (h *indexHint) or(o *indexHint) *indexHint {
+	if h == nil || o == nil {
+		return nil
+	}
+	if h.none {
+		return o
+	}
+	if o.none {
+		return h
+	}
+	c := &indexHint{types: union(h.types, o.types)}
+	if h.attr != "" && h.attr == o.attr {
+		c.attr, c.values = h.attr, union(h.values, o.values)
+	}
+	if c.types == nil && c.attr == "" {
+		return nil
+	}
+	return c
+}
+
+// fieldHint creates a hint restricting record types to those defining the referenced attributes.
+// With legacy null semantics, absent attributes compare as zero values, so no restriction applies.
+func fieldHint(attrs ...string) *indexHint {
+	if nullSemantics == LegacyNulls {
+		return nil
+	}
+	return typesHint(attrs...)
+}
+
+// typesHint creates a hint restricting record types to those defining the referenced attributes.
+func typesHint(attrs ...string) *indexHint {
+	var types map[string]bool
+	for _, attr := range attrs {
+		if Mapper.IsField(attr) {
+			types = intersect(types, attrTypes(attr))
+		}
+	}
+	if types == nil {
+		return nil
+	}
+	return &indexHint{types: types}
+}
+
+// eqHint creates a hint for an equality between an attribute and a constant.
+func eqHint(lattr string, rattr string) *indexHint {
+	if Mapper.IsField(lattr) && !Mapper.IsField(rattr) {
+		return valuesHint(lattr, []string{rattr})
+	} else if Mapper.IsField(rattr) && !Mapper.IsField(lattr) {
+		return valuesHint(rattr, []string{lattr})
+	}
+	return fieldHint(lattr, rattr)
+}
+
+// inHint creates a hint for a list-inclusion predicate.
+func inHint(attr string, list []string) *indexHint {
+	if !Mapper.IsField(attr) {
+		return nil
+	}
+	return valuesHint(attr, list)
+}
+
+func valuesHint(attr string, list []string) *indexHint {
+	values := make(map[string]bool)
+	for _, v := range splitItems(list) {
+		values[v] = true
+	}
+	if attr == SF_TYPE {
+		return &indexHint{types: values}
+	}
+	h := fieldHint(attr)
+	if h == nil {
+		h = &indexHint{}
+	}
+	h.attr, h.values = attr, values
+	return h
+}
+
+func toSet(l []string) map[string]bool {
+	s := make(map[string]bool, len(l))
+	for _, v := range l {
+		s[v] = true
+	}
+	return s
+}
+
+// intersect returns the intersection of two sets, where nil denotes the universe.
+func intersect(a map[string]bool, b map[string]bool) map[string]bool {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	c := make(map[string]bool)
+	for k := range a {
+		if b[k] {
+			c[k] = true
+		}
+	}
+	return c
+}
+
+// union returns the union of two sets, where nil denotes the universe.
+func union(a map[string]bool, b map[string]bool) map[string]bool {
+	if a == nil || b == nil {
+		return nil
+	}
+	c := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		c[k] = true
+	}
+	for k := range b {
+		c[k] = true
+	}
+	return c
+}
+
+// ruleBucket holds candidate rules for a record type.
+type ruleBucket struct {
+	scan  []int
+	attrs []string
+	maps  []StrFieldMap
+	keyed []map[string][]int
+}
+
+// RuleIndex buckets compiled rules by record type and discriminating attribute values,
+// so that only candidate rules are evaluated against a record.
+type RuleIndex struct {
+	buckets map[string]*ruleBucket
+}
+
+// NewRuleIndex builds an index over the enabled rules in rs.
+func NewRuleIndex(rs []Rule) *RuleIndex {
+	idx := &RuleIndex{buckets: make(map[string]*ruleBucket)}
+	for _, t := range recTypes {
+		idx.buckets[t] = new(ruleBucket)
+	}
+	for i, rule := range rs {
+		if !rule.Enabled {
+			continue
+		}
+		h := rule.condition.hint
+		if h != nil && h.none {
+			continue
+		}
+		types := toSet(recTypes)
+		if h != nil && h.types != nil {
+			types = intersect(types, h.types)
+		}
+		if len(rule.Prefilter) > 0 {
+			types = intersect(types, toSet(rule.Prefilter))
+		}
+		for t := range types {
+			b := idx.buckets[t]
+			if h == nil || h.attr == "" {
+				b.scan = append(b.scan, i)
+			} else {
+				b.add(h.attr, h.values, i)
+			}
+		}
+	}
+	return idx
+}
+
+// add registers rule i under attribute attr for each value in values.
+func (b *ruleBucket) add(attr string, values map[string]bool, i int) {
+	k := -1
+	for j, a := range b.attrs {
+		if a == attr {
+			k = j
+			break
+		}
+	}
+	if k < 0 {
+		k = len(b.attrs)
+		b.attrs = append(b.attrs, attr)
+		b.maps = append(b.maps, Mapper.MapStr(attr))
+		b.keyed = append(b.keyed, make(map[string][]int))
+	}
+	for v := range values {
+		b.keyed[k][v] = append(b.keyed[k][v], i)
+	}
+}
+
+// Candidates returns the positions of the rules that may match record r, in rule order.
+func (idx *RuleIndex) Candidates(r *Record) []int {
+	b, ok := idx.buckets[Mapper.MapStr(SF_TYPE)(r)]
+	if !ok {
+		return nil
+	}
+	if len(b.attrs) == 0 {
+		return b.scan
+	}
+	c := append([]int(nil), b.scan...)
+	for k, m := range b.maps {
+		s := m(r)
+		if strings.Contains(s, LISTSEP) {
+			c = append(c, b.keyed[k][s]...)
+		}
+		for _, v := range strings.Split(s, LISTSEP) {
+			c = append(c, b.keyed[k][v]...)
+		}
+	}
+	if len(c) == len(b.scan) {
+		return b.scan
+	}
+	sort.Ints(c)
+	n := 0
+	for i, v := range c {
+		if i == 0 || v != c[n-1] {
+			c[n] = v
+			n++
+		}
+	}
+	return c[:n]
+}
@@ -0,0 +1,113 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/cache"
+	. "github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+func newIndexTestRecord(rtype int64, exe string, args string) *Record {
+	ints := make([]int64, sfgo.INT_ARRAY_SIZE)
+	strs := make([]string, sfgo.STR_ARRAY_SIZE)
+	ints[sfgo.SF_REC_TYPE] = rtype
+	strs[sfgo.PROC_EXE_STR] = exe
+	strs[sfgo.PROC_EXEARGS_STR] = args
+	strs[sfgo.CONT_ID_STR] = "cont"
+	strs[sfgo.CONT_NAME_STR] = "node"
+	fr := sfgo.FlatRecord{Sources: []sfgo.Source{sfgo.SYSFLOW_SRC}, Ints: [][]int64{ints}, Strs: [][]string{strs}}
+	return NewRecord(fr, cache.GetInstance())
+}
+
+func matchedRules(r *Record) map[string]bool {
+	names := make(map[string]bool)
+	if match, _ := pi.Process(false, false, r); match {
+		for _, rule := range r.Ctx.GetRules() {
+			names[rule.Name] = true
+		}
+	}
+	return names
+}
+
+func TestRuleIndexByType(t *testing.T) {
+	compileTestPolicies(t)
+	pe := matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/python", "cos-write.py"))
+	assert.True(t, pe["In rule"])
+	assert.False(t, pe["Network Flows on specific port"])
+	nf := matchedRules(newIndexTestRecord(sfgo.NET_FLOW, "/usr/bin/python", "cos-write.py"))
+	assert.True(t, nf["Network Flows on specific port"])
+	assert.False(t, nf["In rule"])
+}
+
+func TestRuleIndexByValue(t *testing.T) {
+	compileTestPolicies(t)
+	assert.True(t, matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/bin/node", "cos-write.py"))["In rule"])
+	assert.False(t, matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/bin/sh", "cos-write.py"))["In rule"])
+}
+
+// candidateRules returns the names of the rules in rs that idx selects for record r.
+func candidateRules(idx *RuleIndex, rs []Rule, r *Record) []string {
+	var names []string
+	for _, i := range idx.Candidates(r) {
+		names = append(names, rs[i].Name)
+	}
+	return names
+}
+
+func TestRuleIndexCandidates(t *testing.T) {
+	defer SetNullSemantics(SetNullSemantics(StrictNulls))
+	rs := []Rule{
+		NewTestRule("net exists", Exists(SF_NET_DPORT)),
+		NewTestRule("net port", Eq(SF_NET_DPORT, "80")),
+		NewTestRule("file path", Eq(SF_FILE_PATH, "/etc/passwd")),
+		NewTestRule("and", Exists(SF_FILE_PATH).And(Eq(SF_PROC_EXE, "/usr/bin/cat"))),
+		NewTestRule("or types", Exists(SF_NET_DPORT).Or(Exists(SF_FILE_PATH))),
+		NewTestRule("or values", Eq(SF_PROC_EXE, "/bin/sh").Or(Eq(SF_PROC_EXE, "/usr/bin/cat"))),
+		NewTestRule("not", Exists(SF_NET_DPORT).Not()),
+		NewTestRule("never", Exists(SF_NET_DPORT).And(False)),
+	}
+	idx := NewRuleIndex(rs)
+	ff := newIndexTestRecord(sfgo.FILE_FLOW, "/usr/bin/cat", "")
+	ff.Fr.Strs[0][sfgo.FILE_PATH_STR] = "/tmp/out"
+	assert.Equal(t, []string{"and", "or types", "or values", "not"}, candidateRules(idx, rs, ff))
+	ff = newIndexTestRecord(sfgo.FILE_FLOW, "/usr/bin/vi", "")
+	assert.Equal(t, []string{"or types", "not"}, candidateRules(idx, rs, ff))
+	assert.Equal(t, []string{"net exists", "or types", "not"}, candidateRules(idx, rs, newNetTestRecord("10.0.0.5", "10.0.0.6")))
+	assert.Equal(t, []string{"or values", "not"}, candidateRules(idx, rs, newIndexTestRecord(sfgo.PROC_EVT, "/bin/sh", "")))
+	assert.Equal(t, []string{"not"}, candidateRules(idx, rs, newIndexTestRecord(sfgo.PROC_EVT, "/bin/zsh", "")))
+}
+
+func TestCIDRPolicies(t *testing.T) {
+	compileTestPolicies(t)
+	rules := matchedRules(newNetTestRecord("192.168.1.20", "10.1.2.3"))
+	assert.True(t, rules["Connection to internal network"])
+	assert.False(t, rules["Connection to cloud metadata"])
+	assert.False(t, rules["Outbound connection"])
+	rules = matchedRules(newNetTestRecord("10.0.0.5", "169.254.169.254"))
+	assert.True(t, rules["Connection to cloud metadata"])
+	assert.True(t, rules["Outbound connection"])
+	assert.False(t, rules["Connection to internal network"])
+	assert.False(t, matchedRules(newNetTestRecord("127.0.0.1", "10.0.0.1"))["Connection to internal network"])
+	assert.False(t, matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/curl", ""))["Connection to cloud metadata"])
+}
@@ -20,9 +20,12 @@
 package engine
 
 import (
-	"regexp"
+	"errors"
+	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
@@ -33,22 +36,39 @@ import (
 var rules = make([]Rule, 0)
 var filters = make([]Filter, 0)
 
+// Rule index built from compiled rules.
+var ruleIdx *RuleIndex
+
+// Null semantics applied to compiled predicates.
+var nullSemantics = LegacyNulls
+
 // Accessory parsing maps.
 var lists = make(map[string][]string)
 var macroCtxs = make(map[string]parser.IExpressionContext)
 
-// Regular expression for pasting lists.
-var itemsre = regexp.MustCompile(`(^\[)(.*)(\]$?)`)
+// Rule and filter conditions, compiled once their policy is parsed.
+var ruleDecls = make([]ruleDecl, 0)
+var filterCtxs = make([]parser.IExpressionContext, 0)
+
+// ruleDecl holds the declared condition and exceptions of a rule.
+type ruleDecl struct {
+	cond       parser.IExpressionContext
+	exceptions []*exception
+}
 
 // PolicyInterpreter defines a rules engine for SysFlow data streams.
 type PolicyInterpreter struct {
-	ahdl ActionHandler
+	ahdl    ActionHandler
+	nulls   NullSemantics
+	refresh time.Duration
+	sevs    map[string]Severity
+	scores  map[string]int
 }
 
 // NewPolicyInterpreter constructs a new interpreter instance.
 func NewPolicyInterpreter(conf Config) PolicyInterpreter {
 	ah := NewActionHandler(conf)
-	return PolicyInterpreter{ah}
+	return PolicyInterpreter{ahdl: ah, nulls: conf.NullSemantics, refresh: conf.ListRefresh, sevs: conf.SeverityOverrides, scores: conf.ScoreOverrides}
 }
 
 // Compile parses and interprets an input policy defined in path.
@@ -66,28 +86,99 @@ func (pi PolicyInterpreter) compile(path string) error {
 
 	// Create the Parser
 	p := parser.NewSfplParser(stream)
+	listener := &sfplListener{dir: filepath.Dir(path)}
+	nrules, nfilters := len(rules), len(filters)
 
 	// Pre-processing (to deal with usage before definitions of macros and lists)
-	antlr.ParseTreeWalkerDefault.Walk(&sfplListener{}, p.Defs())
+	antlr.ParseTreeWalkerDefault.Walk(listener, p.Defs())
 	p.GetInputStream().Seek(0)
 
 	// Parse the policy
-	antlr.ParseTreeWalkerDefault.Walk(&sfplListener{}, p.Policy())
+	antlr.ParseTreeWalkerDefault.Walk(listener, p.Policy())
+	if listener.err != nil {
+		return listener.err
+	}
+
+	// Compile the conditions, including those of previous policies if definitions have been appended to
+	if listener.appended {
+		nrules, nfilters = 0, 0
+	}
+	listener.compileConditions(nrules, nfilters)
 
 	return nil
 }
 
+// syntaxErrorListener records the first syntax error reported by a parser.
+type syntaxErrorListener struct {
+	*antlr.DefaultErrorListener
+	err error
+}
+
+// SyntaxError records a syntax error.
+func (l *syntaxErrorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
+	if l.err == nil {
+		l.err = fmt.Errorf("%d:%d %s", line, column, msg)
+	}
+}
+
+// CompileExpression compiles a standalone policy condition, which may reference the lists and macros of compiled policies.
+func CompileExpression(expr string) (Criterion, error) {
+	lexer := parser.NewSfplLexer(antlr.NewInputStream(expr))
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+	p := parser.NewSfplParser(stream)
+	el := &syntaxErrorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+	p.RemoveErrorListeners()
+	p.AddErrorListener(el)
+	ctx := p.Expression()
+	if el.err == nil && stream.LA(1) != antlr.TokenEOF {
+		el.err = errors.New("unexpected input " + stream.LT(1).GetText())
+	}
+	if el.err != nil {
+		return False, fmt.Errorf("Error parsing expression '%s': %v", expr, el.err)
+	}
+	return (&sfplListener{}).visitExpression(ctx), nil
+}
+
 // Compile parses and interprets a set of input policies defined in paths.
+// Lists, macros and rules may be appended to by subsequent policies, as in Falco.
 func (pi PolicyInterpreter) Compile(paths ...string) error {
+	nullSemantics = pi.nulls
 	for _, path := range paths {
 		logger.Trace.Println("Parsing policy file ", path)
 		if err := pi.compile(path); err != nil {
 			return err
 		}
 	}
+	pi.applyOverrides()
+	ruleIdx = NewRuleIndex(rules)
+	startListRefresh(pi.refresh)
 	return nil
 }
 
+// applyOverrides overrides the severity and score of rules by tag. If several tags
+// of a rule are overridden, the highest severity and score apply.
+func (pi PolicyInterpreter) applyOverrides() {
+	if len(pi.sevs) == 0 && len(pi.scores) == 0 {
+		return
+	}
+	for i := range rules {
+		sevSet, scoreSet := false, false
+		for _, t := range rules[i].TagNames() {
+			if sev, ok := pi.sevs[t]; ok && (!sevSet || sev > rules[i].Severity) {
+				rules[i].Severity, rules[i].Priority, sevSet = sev, sev.Priority(), true
+			}
+			if score, ok := pi.scores[t]; ok && (!scoreSet || score > rules[i].Score) {
+				rules[i].Score, scoreSet = score, true
+			}
+		}
+	}
+}
+
+// Close stops refreshing external lists.
+func (pi PolicyInterpreter) Close() {
+	stopListRefresh()
+}
+
 // ProcessAsync executes all compiled policies against record r.
 func (pi PolicyInterpreter) ProcessAsync(applyFilters bool, filterOnly bool, r *Record, out func(r *Record)) {
 	if applyFilters && pi.EvalFilters(r) {
@@ -96,12 +187,7 @@ func (pi PolicyInterpreter) ProcessAsync(applyFilters bool, filterOnly bool, r *
 	if filterOnly {
 		out(r)
 	}
-	for _, rule := range rules {
-		//fmt.Println("Rule: ", rule.Name, rule.Enabled, rule.isApplicable(r))
-		if rule.Enabled && rule.isApplicable(r) && rule.condition.Eval(r) {
-			pi.ahdl.HandleActionAsync(rule, r, out)
-		}
-	}
+	matchRules(r, func(rule Rule) { pi.ahdl.HandleActionAsync(rule, r, out) })
 }
 
 // Process executes all compiled policies against record r.
@@ -113,13 +199,28 @@ func (pi PolicyInterpreter) Process(applyFilters bool, filterOnly bool, r *Recor
 	if filterOnly {
 		return true, r
 	}
-	for _, rule := range rules {
-		if rule.Enabled && rule.isApplicable(r) && rule.condition.Eval(r) {
-			pi.ahdl.HandleAction(rule, r)
-			match = true
+	matchRules(r, func(rule Rule) {
+		pi.ahdl.HandleAction(rule, r)
+		match = true
+	})
+	return match, r
+}
+
+// matchRules calls f for each enabled rule matching record r, using the rule index when available.
+func matchRules(r *Record, f func(rule Rule)) {
+	if ruleIdx == nil {
+		for _, rule := range rules {
+			if rule.Enabled && rule.isApplicable(r) && rule.condition.Eval(r) {
+				f(rule)
+			}
+		}
+		return
+	}
+	for _, i := range ruleIdx.Candidates(r) {
+		if rules[i].condition.Eval(r) {
+			f(rules[i])
 		}
 	}
-	return match, r
 }
 
 // EvalFilters executes compiled policy filters against record r.
@@ -134,49 +235,204 @@ func (pi PolicyInterpreter) EvalFilters(r *Record) bool {
 
 type sfplListener struct {
 	*parser.BaseSfplListener
+	appended bool
+	dir      string
+	err      error
 }
 
 // ExitList is called when production list is exited.
 func (listener *sfplListener) ExitPlist(ctx *parser.PlistContext) {
 	logger.Trace.Println("Parsing list ", ctx.GetText())
-	lists[ctx.ID().GetText()] = listener.extractListFromItems(ctx.Items())
+	name := ctx.ID().GetText()
+	if ctx.Listsource() != nil {
+		listener.defineExternalList(name, ctx.Listsource())
+		return
+	}
+	items := listener.extractListFromItems(ctx.Items())
+	if listener.isAppend(ctx.AllFappend()) {
+		if l, ok := lists[name]; ok {
+			lists[name] = append(l, items...)
+			listener.appended = true
+			return
+		}
+		logger.Warn.Println("Append to undefined list ", name)
+	}
+	lists[name] = items
+	delete(extLists, name)
+}
+
+// defineExternalList defines list name with items loaded from the external file described by ctx.
+// Relative paths are resolved against the directory of the policy file.
+func (listener *sfplListener) defineExternalList(name string, ctx parser.IListsourceContext) {
+	sctx := ctx.(*parser.ListsourceContext)
+	path := listener.getAtomText(sctx, parser.SfplParserSOURCE)
+	if path != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(listener.dir, path)
+	}
+	l, err := NewExternalList(path, listener.getAtomText(sctx, parser.SfplParserFORMAT), listener.getAtomText(sctx, parser.SfplParserCOLUMN))
+	if err != nil {
+		logger.Error.Println("Error loading list ", name, ": ", err)
+		listener.err = err
+		return
+	}
+	lists[name] = []string{}
+	extLists[name] = l
 }
 
 // ExitMacro is called when production macro is exited.
 func (listener *sfplListener) ExitPmacro(ctx *parser.PmacroContext) {
 	logger.Trace.Println("Parsing macro ", ctx.GetText())
-	macroCtxs[ctx.ID().GetText()] = ctx.Expression()
+	name := ctx.ID().GetText()
+	if listener.isAppend(ctx.AllFappend()) {
+		if m, ok := macroCtxs[name]; ok {
+			macroCtxs[name] = listener.appendCondition(m, ctx.Condition())
+			listener.appended = true
+			return
+		}
+		logger.Warn.Println("Append to undefined macro ", name)
+	}
+	macroCtxs[name] = listener.getCondition(ctx.Condition())
 }
 
 // ExitFilter is called when production filter is exited.
 func (listener *sfplListener) ExitPfilter(ctx *parser.PfilterContext) {
 	logger.Trace.Println("Parsing filter ", ctx.GetText())
 	f := Filter{
-		Name:      ctx.ID().GetText(),
-		condition: listener.visitExpression(ctx.Expression()),
-		Enabled:   ctx.ENABLED() == nil || listener.getEnabledFlag(ctx.Enabled()),
+		Name:    ctx.ID().GetText(),
+		Enabled: ctx.ENABLED() == nil || listener.getEnabledFlag(ctx.Enabled()),
 	}
 	filters = append(filters, f)
+	filterCtxs = append(filterCtxs, ctx.Expression())
 }
 
 // ExitFilter is called when production filter is exited.
 func (listener *sfplListener) ExitPrule(ctx *parser.PruleContext) {
 	logger.Trace.Println("Parsing rule ", ctx.GetText())
+	name := listener.getOffChannelText(ctx.Text(0))
+	if listener.isAppend(ctx.AllFappend()) {
+		listener.appendRule(name, ctx)
+		return
+	}
 	r := Rule{
-		Name:      listener.getOffChannelText(ctx.Text(0)),
-		Desc:      listener.getOffChannelText(ctx.Text(1)),
-		condition: listener.visitExpression(ctx.Expression()),
+		Name:      name,
+		Desc:      listener.getFieldText(ctx, parser.SfplParserDESC),
 		Actions:   listener.getActions(ctx),
+		Output:    strings.Join(strings.Fields(listener.getFieldText(ctx, parser.SfplParserOUTPUT)), " "),
 		Tags:      listener.getTags(ctx),
-		Priority:  listener.getPriority(ctx),
+		Severity:  listener.getSeverity(ctx),
+		Score:     listener.getScore(ctx),
 		Prefilter: listener.getPrefilter(ctx),
 		Enabled:   ctx.ENABLED(0) == nil || listener.getEnabledFlag(ctx.Enabled(0)),
 	}
+	r.Priority = r.Severity.Priority()
+	var cond parser.IExpressionContext
+	if ctx.Condition(0) != nil {
+		cond = listener.getCondition(ctx.Condition(0))
+	}
 	rules = append(rules, r)
+	ruleDecls = append(ruleDecls, ruleDecl{cond: cond, exceptions: listener.getExceptions(ctx)})
+}
+
+// appendRule appends the condition and exceptions of ctx to the last rule defined with name.
+func (listener *sfplListener) appendRule(name string, ctx *parser.PruleContext) {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].Name == name {
+			if ctx.Condition(0) != nil {
+				ruleDecls[i].cond = listener.appendCondition(ruleDecls[i].cond, ctx.Condition(0))
+			}
+			ruleDecls[i].exceptions = mergeExceptions(ruleDecls[i].exceptions, listener.getExceptions(ctx))
+			listener.appended = true
+			return
+		}
+	}
+	logger.Warn.Println("Append to undefined rule ", name)
+}
+
+// compileConditions compiles the conditions of the rules and filters starting at positions nrules and nfilters.
+func (listener *sfplListener) compileConditions(nrules int, nfilters int) {
+	for i := nrules; i < len(ruleDecls); i++ {
+		d := ruleDecls[i]
+		c := False
+		if d.cond != nil {
+			c = listener.visitExpression(d.cond)
+		} else {
+			logger.Warn.Println("Missing condition in rule ", rules[i].Name)
+		}
+		if len(d.exceptions) > 0 {
+			c = c.And(listener.visitExceptions(d.exceptions).Not())
+		}
+		rules[i].condition = c
+	}
+	for i := nfilters; i < len(filterCtxs); i++ {
+		filters[i].condition = listener.visitExpression(filterCtxs[i])
+	}
+}
+
+func (listener *sfplListener) isAppend(ctxs []parser.IFappendContext) bool {
+	for _, ctx := range ctxs {
+		flag := unquote(ctx.GetText())
+		if b, err := strconv.ParseBool(flag); err == nil {
+			return b
+		}
+		logger.Warn.Println("Unrecognized append flag: ", flag)
+	}
+	return false
+}
+
+func (listener *sfplListener) getCondition(ctx parser.IConditionContext) parser.IExpressionContext {
+	cctx := ctx.(*parser.ConditionContext)
+	if cctx.AND() != nil || cctx.OR() != nil {
+		logger.Warn.Println("Unexpected leading operator in condition ", ctx.GetText())
+	}
+	return cctx.Expression()
+}
+
+// appendCondition concatenates the text of condition ctx to expression expr, as in Falco appends.
+func (listener *sfplListener) appendCondition(expr parser.IExpressionContext, ctx parser.IConditionContext) parser.IExpressionContext {
+	if expr == nil {
+		return listener.getCondition(ctx)
+	}
+	text := listener.getOffChannelText(expr) + " " + listener.getOffChannelText(ctx)
+	lexer := parser.NewSfplLexer(antlr.NewInputStream(text))
+	p := parser.NewSfplParser(antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel))
+	return p.Expression()
+}
+
+// getField returns the text value of key ttype in ctx, or nil if the key is absent.
+func (listener *sfplListener) getField(ctx antlr.ParserRuleContext, ttype int) parser.ITextContext {
+	children := ctx.GetChildren()
+	for i, c := range children {
+		if t, ok := c.(antlr.TerminalNode); ok && t.GetSymbol().GetTokenType() == ttype && i+2 < len(children) {
+			if tctx, ok := children[i+2].(parser.ITextContext); ok {
+				return tctx
+			}
+		}
+	}
+	return nil
+}
+
+// getAtomText returns the unquoted atom following the token of type ttype in ctx, or an empty string.
+func (listener *sfplListener) getAtomText(ctx antlr.ParserRuleContext, ttype int) string {
+	children := ctx.GetChildren()
+	for i, c := range children {
+		if t, ok := c.(antlr.TerminalNode); ok && t.GetSymbol().GetTokenType() == ttype && i+2 < len(children) {
+			if actx, ok := children[i+2].(parser.IAtomContext); ok {
+				return unquote(actx.GetText())
+			}
+		}
+	}
+	return ""
+}
+
+func (listener *sfplListener) getFieldText(ctx antlr.ParserRuleContext, ttype int) string {
+	if tctx := listener.getField(ctx, ttype); tctx != nil {
+		return listener.getOffChannelText(tctx)
+	}
+	return ""
 }
 
 func (listener *sfplListener) getEnabledFlag(ctx parser.IEnabledContext) bool {
-	flag := trimBoundingQuotes(ctx.GetText())
+	flag := unquote(ctx.GetText())
 	if b, err := strconv.ParseBool(flag); err == nil {
 		return b
 	}
@@ -184,7 +440,7 @@ func (listener *sfplListener) getEnabledFlag(ctx parser.IEnabledContext) bool {
 	return true
 }
 
-func (listener *sfplListener) getOffChannelText(ctx parser.ITextContext) string {
+func (listener *sfplListener) getOffChannelText(ctx antlr.ParserRuleContext) string {
 	a := ctx.GetStart().GetStart()
 	b := ctx.GetStop().GetStop()
 	interval := antlr.Interval{Start: a, Stop: b}
@@ -204,44 +460,36 @@ func (listener *sfplListener) getPrefilter(ctx *parser.PruleContext) []string {
 	var pfs = make([]string, 0)
 	ictx := ctx.Prefilter(0)
 	if ictx != nil {
-		return append(pfs, listener.extractList(ictx.GetText())...)
+		return append(pfs, listener.extractValues(ictx.(*parser.PrefilterContext).Items())...)
 	}
 	return pfs
 }
 
-func (listener *sfplListener) getPriority(ctx *parser.PruleContext) Priority {
+func (listener *sfplListener) getSeverity(ctx *parser.PruleContext) Severity {
 	ictx := ctx.Severity(0)
 	if ictx != nil {
 		p := ictx.GetText()
-		switch strings.ToLower(p) {
-		case Low.String():
-			return Low
-		case Medium.String():
-			return Medium
-		case High.String():
-			return High
-		case FPriorityDebug:
-			return Low
-		case FPriorityInfo:
-			return Low
-		case FPriorityInformational:
-			return Low
-		case FPriorityNotice:
-			return Low
-		case FPriorityWarning:
-			return Medium
-		case FPriorityError:
-			return High
-		case FPriorityCritical:
-			return High
-		case FPriorityEmergency:
-			return High
-		default:
+		sev, err := ParseSeverity(p)
+		if err != nil {
 			logger.Warn.Printf("Unrecognized priority value %s. Deferring to %s\n", p, Low.String())
-			break
 		}
+		return sev
 	}
-	return Low
+	return SevNotice
+}
+
+func (listener *sfplListener) getScore(ctx *parser.PruleContext) int {
+	ictx := ctx.Score(0)
+	if ictx != nil {
+		s := ictx.GetText()
+		score, err := strconv.Atoi(s)
+		if err != nil || score < 0 {
+			logger.Warn.Printf("Invalid score value %s. Deferring to 0\n", s)
+			return 0
+		}
+		return score
+	}
+	return 0
 }
 
 func (listener *sfplListener) getActions(ctx *parser.PruleContext) []Action {
@@ -249,9 +497,7 @@ func (listener *sfplListener) getActions(ctx *parser.PruleContext) []Action {
 	if ctx.OUTPUT(0) != nil {
 		actions = append(actions, Alert)
 	} else if ctx.ACTION(0) != nil {
-		astr := ctx.Text(2).GetText()
-		l := listener.extractList(astr)
-		for _, v := range l {
+		for _, v := range listener.extractTextValues(listener.getField(ctx, parser.SfplParserACTION)) {
 			switch strings.ToLower(v) {
 			case Alert.String():
 				actions = append(actions, Alert)
@@ -268,37 +514,66 @@ func (listener *sfplListener) getActions(ctx *parser.PruleContext) []Action {
 	return actions
 }
 
-func (listener *sfplListener) extractList(str string) []string {
+func (listener *sfplListener) extractListFromItems(ctx parser.IItemsContext) []string {
 	s := []string{}
-	ls := strings.Split(itemsre.ReplaceAllString(str, "$2"), LISTSEP)
-	for _, v := range ls {
-		s = append(s, v)
+	if ctx != nil {
+		for _, v := range ctx.(*parser.ItemsContext).AllAtom() {
+			s = append(s, v.GetText())
+		}
 	}
 	return s
 }
 
-func (listener *sfplListener) extractListFromItems(ctx parser.IItemsContext) []string {
-	if ctx != nil {
-		return listener.extractList(ctx.GetText())
+func (listener *sfplListener) extractValues(ctx parser.IItemsContext) []string {
+	s := []string{}
+	for _, v := range listener.extractListFromItems(ctx) {
+		s = append(s, unquote(v))
 	}
-	return []string{}
+	return s
 }
 
 func (listener *sfplListener) extractTags(ctx parser.ITagsContext) []string {
+	s := []string{}
 	if ctx != nil {
-		return listener.extractList(ctx.GetText())
+		for _, v := range ctx.(*parser.TagsContext).AllAtom() {
+			s = append(s, unquote(v.GetText()))
+		}
 	}
-	return []string{}
+	return s
 }
 
-func (listener *sfplListener) extractListFromAtoms(ctxs []parser.IAtomContext) []string {
+func (listener *sfplListener) extractTextValues(ctx parser.ITextContext) []string {
 	s := []string{}
-	for _, v := range ctxs {
-		s = append(s, listener.reduceList(v.GetText())...)
+	for _, c := range ctx.GetChildren() {
+		if t, ok := c.(antlr.TerminalNode); ok {
+			switch t.GetSymbol().GetTokenType() {
+			case parser.SfplParserLBRACK, parser.SfplParserRBRACK, parser.SfplParserLISTSEP:
+			default:
+				s = append(s, unquote(t.GetText()))
+			}
+		}
 	}
 	return s
 }
 
+func (listener *sfplListener) extractListFromTerm(ctx *parser.TermContext) ([]string, []*ExternalList) {
+	s := []string{}
+	var exts []*ExternalList
+	for _, c := range ctx.GetChildren()[1:] {
+		switch v := c.(type) {
+		case parser.IAtomContext:
+			s = append(s, listener.reduceList(v.GetText())...)
+			exts = append(exts, listener.reduceExternalLists(v.GetText())...)
+		case parser.IItemsContext:
+			for _, i := range listener.extractListFromItems(v) {
+				s = append(s, listener.reduceList(i)...)
+				exts = append(exts, listener.reduceExternalLists(i)...)
+			}
+		}
+	}
+	return s, exts
+}
+
 func (listener *sfplListener) reduceList(sl string) []string {
 	s := []string{}
 	if l, ok := lists[sl]; ok {
@@ -311,6 +586,18 @@ func (listener *sfplListener) reduceList(sl string) []string {
 	return s
 }
 
+// reduceExternalLists returns the external lists referenced by list sl, directly or through nested lists.
+func (listener *sfplListener) reduceExternalLists(sl string) []*ExternalList {
+	if l, ok := extLists[sl]; ok {
+		return []*ExternalList{l}
+	}
+	var exts []*ExternalList
+	for _, v := range lists[sl] {
+		exts = append(exts, listener.reduceExternalLists(v)...)
+	}
+	return exts
+}
+
 func (listener *sfplListener) visitExpression(ctx parser.IExpressionContext) Criterion {
 	orCtx := ctx.GetChild(0).(parser.IOr_expressionContext)
 	orPreds := make([]Criterion, 0)
@@ -370,16 +657,14 @@ func (listener *sfplListener) visitTerm(ctx parser.ITermContext) Criterion {
 			return Le(lop, rop)
 		}
 		logger.Error.Println("Unrecognized binary operator ", opCtx.GetText())
+	} else if termCtx.ANCESTOR() != nil {
+		return AnyAncestor(listener.visitExpression(termCtx.Expression()))
 	} else if termCtx.Expression() != nil {
 		return listener.visitExpression(termCtx.Expression())
-	} else if termCtx.IN() != nil {
-		lop := termCtx.Atom(0).(*parser.AtomContext).GetText()
-		rop := termCtx.AllAtom()[1:]
-		return In(lop, listener.extractListFromAtoms(rop))
-	} else if termCtx.PMATCH() != nil {
+	} else if termCtx.IN() != nil || termCtx.PMATCH() != nil || termCtx.INCIDR() != nil {
 		lop := termCtx.Atom(0).(*parser.AtomContext).GetText()
-		rop := termCtx.AllAtom()[1:]
-		return PMatch(lop, listener.extractListFromAtoms(rop))
+		items, exts := listener.extractListFromTerm(termCtx)
+		return listCriterion(termCtx.GetChild(1).(antlr.TerminalNode).GetText(), lop, items, exts)
 	} else {
 		logger.Warn.Println("Unrecognized term ", termCtx.GetText())
 	}
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/sysflow-telemetry/sf-apis/go/ioutils"
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
 	. "github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
 )
 
@@ -49,3 +50,102 @@ func TestCompileDist(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, pi.Compile(paths...))
 }
+
+func TestStringLiterals(t *testing.T) {
+	compileTestPolicies(t)
+	assert.True(t, matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/bin/sh", "-c ls, -la"))["Quoted list items"])
+	assert.True(t, matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/bin/sh", `echo "hi"`))["Quoted list items"])
+	assert.True(t, matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/bin/sh", `C:\Windows\system32\cmd.exe /c dir`))["Quoted list items"])
+	assert.False(t, matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/bin/sh", "-c ls"))["Quoted list items"])
+	assert.True(t, matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/bin/sh", `say "bye" \o/`))["Escaped literal"])
+	assert.False(t, matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/bin/sh", `say \"bye\" \\o/`))["Escaped literal"])
+	assert.True(t, matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/opt/my app/run", ""))["Quoted pmatch items"])
+	assert.True(t, matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/srv/a,b/run", ""))["Quoted pmatch items"])
+	assert.False(t, matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/opt/my", ""))["Quoted pmatch items"])
+}
+
+func TestFalcoCompatibility(t *testing.T) {
+	compileTestPolicies(t)
+	match := func(exe string, args string) bool {
+		return matchedRules(newIndexTestRecord(sfgo.PROC_EVT, exe, args))["Compat rule"]
+	}
+	assert.True(t, match("/usr/bin/python", "run.py"))
+	assert.True(t, match("/usr/bin/perl", "run.pl"))
+	assert.True(t, match("/bin/bash", "run.sh"))
+	assert.False(t, match("/bin/bash", "skip.sh"))
+	assert.False(t, match("/usr/bin/python", "safe-run.py"))
+	assert.True(t, match("/bin/sh", "safe-run.py"))
+	assert.False(t, match("/bin/sh", "trusted run.sh"))
+	assert.False(t, match("/bin/sh", "excluded.py"))
+	assert.False(t, match("/bin/sh", "excluded, quoted.py"))
+	assert.False(t, match("/usr/bin/perl", "-v"))
+	assert.False(t, match("/bin/zsh", "run.sh"))
+}
+
+func TestContextualKeywords(t *testing.T) {
+	compileTestPolicies(t)
+	match := func(args string) bool {
+		return matchedRules(newIndexTestRecord(sfgo.PROC_EVT, "/bin/kwsh", args))["Keyword rule"]
+	}
+	assert.True(t, match("exceptions"))
+	assert.True(t, match("values"))
+	assert.True(t, match("source"))
+	assert.True(t, match("column"))
+	assert.False(t, match("comps"))
+	assert.False(t, match("other"))
+	r := newIndexTestRecord(sfgo.PROC_EVT, "/bin/kwsh", "score")
+	pi.Process(false, false, r)
+	var scored []Rule
+	for _, rule := range r.Ctx.GetRules() {
+		if rule.Name == "Score keyword rule" {
+			scored = append(scored, rule)
+		}
+	}
+	assert.NotEmpty(t, scored)
+	for _, rule := range scored {
+		assert.Equal(t, "flag shells launched by a score keeper", rule.Desc)
+		assert.Equal(t, 40, rule.Score)
+	}
+}
+
+func TestOutputFormat(t *testing.T) {
+	compileTestPolicies(t)
+	r := newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/python", "run.py")
+	pi.Process(false, false, r)
+	var outputs []string
+	for _, rule := range r.Ctx.GetRules() {
+		if rule.Name == "Compat rule" {
+			outputs = append(outputs, rule.FormatOutput(r))
+		}
+	}
+	assert.NotEmpty(t, outputs)
+	for _, o := range outputs {
+		assert.Equal(t, "Compat /usr/bin/python ran run.py (user=<NA> container=node unknown=%foo.bar)", o)
+	}
+}
+
+func TestCompileExpression(t *testing.T) {
+	defer SetNullSemantics(SetNullSemantics(StrictNulls))
+	compileTestPolicies(t)
+	c, err := CompileExpression("sf.proc.exe = /usr/bin/python and sf.rule.severity in (critical, alert)")
+	assert.NoError(t, err)
+	r := newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/python", "run.py")
+	assert.False(t, c.Eval(r))
+	r.Ctx.AddRule(Rule{Name: "a", Severity: SevCritical, Priority: High, Score: 70, Tags: []EnrichmentTag{[]string{"mitre:T1059", "pci"}}})
+	r.Ctx.AddRule(Rule{Name: "b", Severity: SevWarning, Priority: Medium, Tags: []EnrichmentTag{"test"}})
+	assert.True(t, c.Eval(r))
+	assert.Equal(t, "a,b", Mapper.MapStr(SF_RULE_NAME)(r))
+	assert.Equal(t, "mitre:T1059,pci,test", Mapper.MapStr(SF_RULE_TAGS)(r))
+	assert.Equal(t, int64(2), Mapper.MapInt(SF_RULE_PRIORITY)(r))
+	assert.Equal(t, int64(70), Mapper.MapInt(SF_RULE_SCORE)(r))
+	c, err = CompileExpression("sf.rule.tags in (pci) and sf.rule.score >= 50 and is_python")
+	assert.NoError(t, err)
+	assert.True(t, c.Eval(r))
+	c, err = CompileExpression("sf.rule.priority < 3")
+	assert.NoError(t, err)
+	assert.False(t, c.Eval(newIndexTestRecord(sfgo.PROC_EVT, "/usr/bin/python", "")))
+	_, err = CompileExpression("sf.proc.exe = ")
+	assert.Error(t, err)
+	_, err = CompileExpression("sf.proc.exe = /bin/sh )")
+	assert.Error(t, err)
+}
@@ -0,0 +1,289 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+)
+
+// Kubernetes pod source defaults.
+const (
+	DefaultPodRefresh = 30 * time.Second
+	serviceTokenPath  = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceCAPath     = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+	shortContIDLen    = 12
+)
+
+// Pod holds Kubernetes pod metadata.
+type Pod struct {
+	Name           string
+	Namespace      string
+	UID            string
+	ServiceAccount string
+	Labels         map[string]string
+	Containers     []string
+}
+
+// LabelString returns the pod labels as a sorted list of key=value items separated by LISTSEP.
+func (p *Pod) LabelString() string {
+	labels := make([]string, 0, len(p.Labels))
+	for k, v := range p.Labels {
+		labels = append(labels, k+"="+v)
+	}
+	sort.Strings(labels)
+	return strings.Join(labels, LISTSEP)
+}
+
+// PodSource defines an interface for retrieving the pods running on a node.
+type PodSource interface {
+	Pods() ([]*Pod, error)
+}
+
+// FilePodSource reads pods from a static JSON dump of a kubelet /pods response.
+type FilePodSource struct {
+	Path string
+}
+
+// Pods reads pods from the source file.
+func (s FilePodSource) Pods() ([]*Pod, error) {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parsePodList(f)
+}
+
+// KubeletPodSource retrieves pods from a kubelet /pods endpoint.
+type KubeletPodSource struct {
+	URL    string
+	Token  string
+	client *http.Client
+}
+
+// NewKubeletPodSource creates a kubelet pod source for url, authenticating with the pod's service account token, if any.
+// The kubelet certificate is verified against the CA certificates in caPath, the service account CA if caPath is empty,
+// or the system roots if neither exists. Verification is skipped only if insecure is set.
+func NewKubeletPodSource(url string, caPath string, insecure bool) (*KubeletPodSource, error) {
+	s := &KubeletPodSource{URL: url}
+	if b, err := ioutil.ReadFile(serviceTokenPath); err == nil {
+		s.Token = strings.TrimSpace(string(b))
+	}
+	tc := &tls.Config{InsecureSkipVerify: insecure}
+	if caPath == "" {
+		if _, err := os.Stat(serviceCAPath); err == nil {
+			caPath = serviceCAPath
+		}
+	}
+	if caPath != "" && !insecure {
+		b, err := ioutil.ReadFile(caPath)
+		if err != nil {
+			return nil, err
+		}
+		tc.RootCAs = x509.NewCertPool()
+		if !tc.RootCAs.AppendCertsFromPEM(b) {
+			return nil, errors.New("No valid CA certificates found in: " + caPath)
+		}
+	}
+	s.client = &http.Client{Transport: &http.Transport{TLSClientConfig: tc}, Timeout: 10 * time.Second}
+	return s, nil
+}
+
+// Pods retrieves pods from the kubelet.
+func (s *KubeletPodSource) Pods() ([]*Pod, error) {
+	req, err := http.NewRequest(http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("Unexpected kubelet response status: " + resp.Status)
+	}
+	return parsePodList(resp.Body)
+}
+
+// NewPodSource creates a pod source from uri, which is either a kubelet http(s) URL or a file path.
+// caPath and insecure configure the verification of kubelet certificates (see NewKubeletPodSource).
+func NewPodSource(uri string, caPath string, insecure bool) (PodSource, error) {
+	if strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
+		return NewKubeletPodSource(uri, caPath, insecure)
+	}
+	return FilePodSource{Path: strings.TrimPrefix(uri, "file://")}, nil
+}
+
+type podList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string            `json:"name"`
+			Namespace string            `json:"namespace"`
+			UID       string            `json:"uid"`
+			Labels    map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Spec struct {
+			ServiceAccountName string `json:"serviceAccountName"`
+		} `json:"spec"`
+		Status struct {
+			ContainerStatuses     []containerStatus `json:"containerStatuses"`
+			InitContainerStatuses []containerStatus `json:"initContainerStatuses"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+type containerStatus struct {
+	ContainerID string `json:"containerID"`
+}
+
+// parsePodList decodes a kubelet /pods response (a Kubernetes PodList).
+func parsePodList(r io.Reader) ([]*Pod, error) {
+	var pl podList
+	if err := json.NewDecoder(r).Decode(&pl); err != nil {
+		return nil, err
+	}
+	pods := make([]*Pod, 0, len(pl.Items))
+	for _, i := range pl.Items {
+		p := &Pod{
+			Name:           i.Metadata.Name,
+			Namespace:      i.Metadata.Namespace,
+			UID:            i.Metadata.UID,
+			ServiceAccount: i.Spec.ServiceAccountName,
+			Labels:         i.Metadata.Labels,
+		}
+		for _, cs := range append(i.Status.ContainerStatuses, i.Status.InitContainerStatuses...) {
+			if cs.ContainerID != "" {
+				p.Containers = append(p.Containers, cs.ContainerID)
+			}
+		}
+		pods = append(pods, p)
+	}
+	return pods, nil
+}
+
+// PodResolver resolves container IDs to pods. Pods are loaded when the resolver is created, and reloaded
+// in the background when a container cannot be resolved, at most once per refresh interval.
+type PodResolver struct {
+	src     PodSource
+	refresh time.Duration
+	mu      sync.RWMutex
+	pods    map[string]*Pod
+	last    time.Time
+	loading int32
+	wg      sync.WaitGroup
+}
+
+// NewPodResolver creates a pod resolver for src.
+func NewPodResolver(src PodSource, refresh time.Duration) *PodResolver {
+	pr := &PodResolver{src: src, refresh: refresh, pods: make(map[string]*Pod), last: time.Now()}
+	pr.load()
+	return pr
+}
+
+// Lookup returns the pod running container id, or nil. Lookup does not wait for pods to be reloaded;
+// containers started since the last reload resolve once the reload completes.
+func (pr *PodResolver) Lookup(id string) *Pod {
+	if id == "" {
+		return nil
+	}
+	pr.mu.RLock()
+	p, ok := pr.pods[id]
+	stale := time.Since(pr.last) >= pr.refresh
+	pr.mu.RUnlock()
+	if !ok && stale && atomic.CompareAndSwapInt32(&pr.loading, 0, 1) {
+		pr.mu.Lock()
+		pr.last = time.Now()
+		pr.mu.Unlock()
+		pr.wg.Add(1)
+		go func() {
+			defer pr.wg.Done()
+			defer atomic.StoreInt32(&pr.loading, 0)
+			pr.load()
+		}()
+	}
+	return p
+}
+
+// load reloads pods from the source and swaps them in; the current pods are kept on errors.
+func (pr *PodResolver) load() {
+	pods, err := pr.src.Pods()
+	if err != nil {
+		logger.Warn.Println("Error retrieving pods: ", err)
+		return
+	}
+	m := make(map[string]*Pod)
+	for _, p := range pods {
+		for _, c := range p.Containers {
+			if i := strings.Index(c, "://"); i >= 0 {
+				c = c[i+3:]
+			}
+			m[c] = p
+			if len(c) > shortContIDLen {
+				m[c[:shortContIDLen]] = p
+			}
+		}
+	}
+	pr.mu.Lock()
+	pr.pods = m
+	pr.mu.Unlock()
+	logger.Trace.Printf("Loaded %d pods\n", len(pods))
+}
+
+// Pod resolver used by pod attribute mappers.
+var pods struct {
+	sync.RWMutex
+	resolver *PodResolver
+}
+
+// SetPodResolver sets the resolver used for pod attributes. A nil resolver disables pod attributes.
+func SetPodResolver(pr *PodResolver) {
+	pods.Lock()
+	defer pods.Unlock()
+	pods.resolver = pr
+}
+
+// lookupPod returns the pod running container id, or nil.
+func lookupPod(id string) *Pod {
+	pods.RLock()
+	pr := pods.resolver
+	pods.RUnlock()
+	if pr == nil {
+		return nil
+	}
+	return pr.Lookup(id)
+}
@@ -0,0 +1,191 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine_test
+
+import (
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	. "github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+const testPods = `{"kind": "PodList", "items": [
+  {"metadata": {"name": "coredns-5d4f", "namespace": "kube-system", "uid": "u1", "labels": {"k8s-app": "kube-dns"}},
+   "spec": {"serviceAccountName": "coredns"},
+   "status": {"containerStatuses": [{"containerID": "docker://3f4e5d6c7b8a9f0e1d2c3b4a5f6e7d8c9b0a1f2e3d4c5b6a7f8e9d0c1b2a3f4e"}]}},
+  {"metadata": {"name": "web-7c9b", "namespace": "shop", "uid": "u2", "labels": {"tier": "frontend", "app": "web"}},
+   "spec": {"serviceAccountName": "default"},
+   "status": {"containerStatuses": [{"containerID": "containerd://aa11bb22cc33"}],
+              "initContainerStatuses": [{"containerID": "containerd://dd44ee55ff66"}]}}
+]}`
+
+func newPodSource(t *testing.T, uri string) PodSource {
+	src, err := NewPodSource(uri, "", false)
+	assert.NoError(t, err)
+	return src
+}
+
+func newPodTestRecord(exe string, contID string) *Record {
+	r := newIndexTestRecord(sfgo.PROC_EVT, exe, "")
+	r.Fr.Strs[0][sfgo.CONT_ID_STR] = contID
+	return r
+}
+
+func TestPodAttributes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pods")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	SetPodResolver(NewPodResolver(newPodSource(t, writeListFile(t, dir, "pods.json", testPods)), time.Minute))
+	defer SetPodResolver(nil)
+	r := newPodTestRecord("/bin/sh", "3f4e5d6c7b8a")
+	assert.Equal(t, "coredns-5d4f", Mapper.MapStr(SF_POD_NAME)(r))
+	assert.Equal(t, "kube-system", Mapper.MapStr(SF_POD_NAMESPACE)(r))
+	assert.Equal(t, "coredns", Mapper.MapStr(SF_POD_SERVICEACCOUNT)(r))
+	assert.Equal(t, "k8s-app=kube-dns", Mapper.MapStr(SF_POD_LABELS)(r))
+	assert.True(t, Mapper.MapPresent(SF_POD_NAME)(r))
+	assert.Equal(t, "kube-system", Mapper.MapStr(FALCO_K8S_NS_NAME)(r))
+	r = newPodTestRecord("/bin/sh", "dd44ee55ff66")
+	assert.Equal(t, "app=web,tier=frontend", Mapper.MapStr(SF_POD_LABELS)(r))
+	r = newPodTestRecord("/bin/sh", "unknown")
+	assert.Equal(t, "", Mapper.MapStr(SF_POD_NAME)(r))
+	assert.False(t, Mapper.MapPresent(SF_POD_NAME)(r))
+	assert.False(t, Mapper.MapPresent(SF_POD_NAMESPACE)(newPodTestRecord("/bin/sh", "")))
+}
+
+func TestPodPolicies(t *testing.T) {
+	compileTestPolicies(t)
+	dir, err := ioutil.TempDir("", "pods")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	SetPodResolver(NewPodResolver(FilePodSource{Path: writeListFile(t, dir, "pods.json", testPods)}, time.Minute))
+	defer SetPodResolver(nil)
+	rules := matchedRules(newPodTestRecord("/bin/sh", "3f4e5d6c7b8a"))
+	assert.True(t, rules["Shell in system pod"])
+	assert.False(t, rules["Frontend pod by unexpected account"])
+	rules = matchedRules(newPodTestRecord("/bin/sh", "aa11bb22cc33"))
+	assert.False(t, rules["Shell in system pod"])
+	assert.True(t, rules["Frontend pod by unexpected account"])
+	assert.False(t, matchedRules(newPodTestRecord("/bin/sh", "cont"))["Frontend pod by unexpected account"])
+}
+
+func TestKubeletPodSource(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/pods" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(testPods))
+	}))
+	defer srv.Close()
+	pods, err := newPodSource(t, srv.URL+"/pods").Pods()
+	assert.NoError(t, err)
+	assert.Len(t, pods, 2)
+	assert.Equal(t, "shop", pods[1].Namespace)
+	assert.Equal(t, []string{"containerd://aa11bb22cc33", "containerd://dd44ee55ff66"}, pods[1].Containers)
+	_, err = newPodSource(t, srv.URL+"/other").Pods()
+	assert.Error(t, err)
+	requests = 0
+	pr := NewPodResolver(newPodSource(t, srv.URL+"/pods"), time.Hour)
+	assert.Equal(t, "web-7c9b", pr.Lookup("aa11bb22cc33").Name)
+	assert.Nil(t, pr.Lookup("unknown"))
+	assert.Nil(t, pr.Lookup("unknown"))
+	pr.WaitLoad()
+	assert.Equal(t, 1, requests)
+	requests = 0
+	pr = NewPodResolver(newPodSource(t, srv.URL+"/pods"), 0)
+	assert.Nil(t, pr.Lookup("unknown"))
+	pr.WaitLoad()
+	assert.Nil(t, pr.Lookup("unknown"))
+	pr.WaitLoad()
+	assert.Equal(t, 3, requests)
+}
+
+func TestKubeletPodSourceTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testPods))
+	}))
+	defer srv.Close()
+	dir, err := ioutil.TempDir("", "pods")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ca := filepath.Join(dir, "ca.crt")
+	assert.NoError(t, ioutil.WriteFile(ca, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0644))
+	src, err := NewPodSource(srv.URL, ca, false)
+	assert.NoError(t, err)
+	pods, err := src.Pods()
+	assert.NoError(t, err)
+	assert.Len(t, pods, 2)
+	src, err = NewPodSource(srv.URL, "", false)
+	assert.NoError(t, err)
+	_, err = src.Pods()
+	assert.Error(t, err)
+	src, err = NewPodSource(srv.URL, "", true)
+	assert.NoError(t, err)
+	_, err = src.Pods()
+	assert.NoError(t, err)
+	_, err = NewPodSource(srv.URL, filepath.Join(dir, "missing.crt"), false)
+	assert.Error(t, err)
+	_, err = NewPodSource(srv.URL, writeListFile(t, dir, "bad.crt", "not a certificate"), false)
+	assert.Error(t, err)
+}
+
+type blockingPodSource struct {
+	pods    []*Pod
+	release chan struct{}
+}
+
+func (s *blockingPodSource) Pods() ([]*Pod, error) {
+	if s.release == nil {
+		return nil, errors.New("unavailable")
+	}
+	<-s.release
+	return s.pods, nil
+}
+
+func TestPodResolverBackgroundReload(t *testing.T) {
+	src := &blockingPodSource{pods: []*Pod{{Name: "web-7c9b", Containers: []string{"containerd://aa11bb22cc33"}}}}
+	pr := NewPodResolver(src, 0)
+	src.release = make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Nil(t, pr.Lookup("aa11bb22cc33"))
+		assert.Nil(t, pr.Lookup("aa11bb22cc33"))
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Lookup blocked on pod reload")
+	}
+	close(src.release)
+	pr.WaitLoad()
+	assert.Equal(t, "web-7c9b", pr.Lookup("aa11bb22cc33").Name)
+}
@@ -21,7 +21,7 @@ package engine
 
 import (
 	"fmt"
-	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -29,14 +29,15 @@ import (
 type Predicate func(*Record) bool
 
 // True defines a functional predicate that always returns true.
-var True = Criterion{func(r *Record) bool { return true }}
+var True = Criterion{Pred: func(r *Record) bool { return true }}
 
 // False defines a functional predicate that always returns false.
-var False = Criterion{func(r *Record) bool { return false }}
+var False = Criterion{Pred: func(r *Record) bool { return false }, hint: &indexHint{none: true}}
 
 // Criterion defines an interface for functional predicate operations.
 type Criterion struct {
 	Pred Predicate
+	hint *indexHint
 }
 
 // Eval evaluates a functional predicate.
@@ -47,19 +48,19 @@ func (c Criterion) Eval(r *Record) bool {
 // And computes the conjunction of two functional predicates.
 func (c Criterion) And(cr Criterion) Criterion {
 	var p Predicate = func(r *Record) bool { return c.Eval(r) && cr.Eval(r) }
-	return Criterion{p}
+	return Criterion{Pred: p, hint: c.hint.and(cr.hint)}
 }
 
 // Or computes the conjunction of two functional predicates.
 func (c Criterion) Or(cr Criterion) Criterion {
 	var p Predicate = func(r *Record) bool { return c.Eval(r) || cr.Eval(r) }
-	return Criterion{p}
+	return Criterion{Pred: p, hint: c.hint.or(cr.hint)}
 }
 
 // Not computes the negation of the function predicate.
 func (c Criterion) Not() Criterion {
 	var p Predicate = func(r *Record) bool { return !c.Eval(r) }
-	return Criterion{p}
+	return Criterion{Pred: p}
 }
 
 // All derives the conjuctive clause of all predicates in a slice of predicates.
@@ -80,110 +81,350 @@ func Any(criteria []Criterion) Criterion {
 	return any
 }
 
-// Exists creates a criterion for an existential predicate.
+// Exists creates a criterion for an existential predicate, which holds if attr is present in a record
+// regardless of the null semantics in effect.
 func Exists(attr string) Criterion {
-	m := Mapper.Map(attr)
-	p := func(r *Record) bool { return reflect.ValueOf(m(r)).IsZero() }
-	return Criterion{p}
+	return Criterion{Pred: Predicate(Mapper.MapPresent(attr)), hint: typesHint(attr)}
 }
 
 // Eq creates a criterion for an equality predicate.
 func Eq(lattr string, rattr string) Criterion {
-	ml := Mapper.MapStr(lattr)
-	mr := Mapper.MapStr(rattr)
-	p := func(r *Record) bool { return eval(ml(r), mr(r), ops.eq) }
-	return Criterion{p}
+	p := compileStrOp(lattr, rattr, ops.eq, func(consts []string) itemMatcher {
+		set := toSet(consts)
+		return func(s string) bool { return set[s] }
+	})
+	p = compileIntEq(lattr, rattr, p)
+	return guard(Criterion{Pred: p, hint: eqHint(lattr, rattr)}, lattr, rattr)
 }
 
 // NEq creates a criterion for an inequality predicate.
 func NEq(lattr string, rattr string) Criterion {
-	return Eq(lattr, rattr).Not()
+	return guard(Eq(lattr, rattr).Not(), lattr, rattr)
 }
 
 // Ge creates a criterion for a greater-or-equal predicate.
 func Ge(lattr string, rattr string) Criterion {
-	ml := Mapper.MapInt(lattr)
-	mr := Mapper.MapInt(rattr)
+	ml := compileIntOperand(lattr)
+	mr := compileIntOperand(rattr)
 	p := func(r *Record) bool { return ml(r) >= mr(r) }
-	return Criterion{p}
+	return guard(Criterion{Pred: p}, lattr, rattr)
 }
 
 // Gt creates a criterion for a greater-than predicate.
 func Gt(lattr string, rattr string) Criterion {
-	ml := Mapper.MapInt(lattr)
-	mr := Mapper.MapInt(rattr)
+	ml := compileIntOperand(lattr)
+	mr := compileIntOperand(rattr)
 	p := func(r *Record) bool { return ml(r) > mr(r) }
-	return Criterion{p}
+	return guard(Criterion{Pred: p}, lattr, rattr)
 }
 
 // Le creates a criterion for a lower-or-equal predicate.
 func Le(lattr string, rattr string) Criterion {
-	return Gt(lattr, rattr).Not()
+	return guard(Gt(lattr, rattr).Not(), lattr, rattr)
 }
 
 // Lt creates a criterion for a lower-than predicate.
 func Lt(lattr string, rattr string) Criterion {
-	return Ge(lattr, rattr).Not()
+	return guard(Ge(lattr, rattr).Not(), lattr, rattr)
 }
 
 // StartsWith creates a criterion for a starts-with predicate.
 func StartsWith(lattr string, rattr string) Criterion {
-	ml := Mapper.MapStr(lattr)
-	mr := Mapper.MapStr(rattr)
-	p := func(r *Record) bool { return eval(ml(r), mr(r), ops.startswith) }
-	return Criterion{p}
+	p := compileStrOp(lattr, rattr, ops.startswith, func(consts []string) itemMatcher {
+		return func(s string) bool {
+			for _, c := range consts {
+				if strings.HasPrefix(s, c) {
+					return true
+				}
+			}
+			return false
+		}
+	})
+	return guard(Criterion{Pred: p}, lattr, rattr)
 }
 
 // EndsWith creates a criterion for a ends-with predicate.
 func EndsWith(lattr string, rattr string) Criterion {
-	ml := Mapper.MapStr(lattr)
-	mr := Mapper.MapStr(rattr)
-	p := func(r *Record) bool { return eval(ml(r), mr(r), ops.endswith) }
-	return Criterion{p}
+	p := compileStrOp(lattr, rattr, ops.endswith, func(consts []string) itemMatcher {
+		return func(s string) bool {
+			for _, c := range consts {
+				if strings.HasSuffix(s, c) {
+					return true
+				}
+			}
+			return false
+		}
+	})
+	return guard(Criterion{Pred: p}, lattr, rattr)
 }
 
 // Contains creates a criterion for a contains predicate.
 func Contains(lattr string, rattr string) Criterion {
-	ml := Mapper.MapStr(lattr)
-	mr := Mapper.MapStr(rattr)
-	p := func(r *Record) bool { return eval(ml(r), mr(r), ops.contains) }
-	return Criterion{p}
+	p := compileStrOp(lattr, rattr, ops.contains, containsMatcher)
+	return guard(Criterion{Pred: p}, lattr, rattr)
 }
 
 // IContains creates a criterion for a case-insensitive contains predicate.
 func IContains(lattr string, rattr string) Criterion {
-	ml := Mapper.MapStr(lattr)
-	mr := Mapper.MapStr(rattr)
-	p := func(r *Record) bool { return eval(ml(r), mr(r), ops.icontains) }
-	return Criterion{p}
+	p := compileStrOp(lattr, rattr, ops.icontains, func(consts []string) itemMatcher {
+		lconsts := make([]string, len(consts))
+		for i, c := range consts {
+			lconsts[i] = strings.ToLower(c)
+		}
+		m := containsMatcher(lconsts)
+		return func(s string) bool { return m(strings.ToLower(s)) }
+	})
+	return guard(Criterion{Pred: p}, lattr, rattr)
 }
 
 // In creates a criterion for a list-inclusion predicate.
 func In(attr string, list []string) Criterion {
-	m := Mapper.MapStr(attr)
+	items := splitItems(list)
+	set := toSet(items)
+	p := compileListOp(attr, items, ops.eq, func(s string) bool { return set[s] })
+	return guard(Criterion{Pred: p, hint: inHint(attr, list)}, attr)
+}
+
+// PMatch creates a criterion for a list-pattern-matching predicate.
+func PMatch(attr string, list []string) Criterion {
+	items := splitItems(list)
+	p := compileListOp(attr, items, ops.contains, containsMatcher(items))
+	return guard(Criterion{Pred: p}, attr)
+}
+
+// InCIDR creates a criterion for a CIDR membership predicate, which holds if some IPv4 address
+// denoted by attr belongs to some network in list.
+func InCIDR(attr string, list []string) Criterion {
+	nets := newCIDRTrie(splitItems(list))
+	ips := compileIPOperand(attr)
 	p := func(r *Record) bool {
-		for _, v := range list {
-			if eval(m(r), v, ops.eq) {
+		for _, ip := range ips(r) {
+			if nets.contains(ip) {
 				return true
 			}
 		}
 		return false
 	}
-	return Criterion{p}
+	return guard(Criterion{Pred: p}, attr)
 }
 
-// PMatch creates a criterion for a list-pattern-matching predicate.
-func PMatch(attr string, list []string) Criterion {
-	m := Mapper.MapStr(attr)
+// AnyAncestor creates a criterion for an ancestor-scoped subquery, which holds if c holds for some ancestor
+// of the record's process. Within c, process attributes refer to the ancestor.
+func AnyAncestor(c Criterion) Criterion {
 	p := func(r *Record) bool {
-		for _, v := range list {
-			if eval(m(r), v, ops.contains) {
+		for _, a := range r.ancestors() {
+			if c.Eval(a) {
 				return true
 			}
 		}
 		return false
 	}
-	return Criterion{p}
+	return Criterion{Pred: p}
+}
+
+// guard restricts criterion c to records in which all attributes in attrs are present,
+// so that comparisons involving absent attributes evaluate to false.
+func guard(c Criterion, attrs ...string) Criterion {
+	if nullSemantics == LegacyNulls {
+		return c
+	}
+	var tests []PresenceMap
+	for _, attr := range attrs {
+		if p, ok := Mapper.presence(attr); ok {
+			tests = append(tests, p)
+		}
+	}
+	h := c.hint.and(fieldHint(attrs...))
+	if len(tests) == 0 {
+		return Criterion{Pred: c.Pred, hint: h}
+	}
+	pred := c.Pred
+	p := func(r *Record) bool {
+		for _, t := range tests {
+			if !t(r) {
+				return false
+			}
+		}
+		return pred(r)
+	}
+	return Criterion{Pred: p, hint: h}
+}
+
+// itemMatcher matches a single list item against pre-compiled constants.
+type itemMatcher func(string) bool
+
+// typedPredicates enables predicates compiled for their operand types and constants. When disabled,
+// operands are compared by their string values, which serves as a reference in tests.
+var typedPredicates = true
+
+// opMatcher matches a single list item against constants using a string operator.
+func opMatcher(consts []string, op operator) itemMatcher {
+	return func(s string) bool {
+		for _, c := range consts {
+			if op(s, c) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// compileIntOperand resolves attr into a numerical accessor.
+// Constants are parsed once, and integer attributes bypass string conversions.
+func compileIntOperand(attr string) IntFieldMap {
+	if !typedPredicates {
+		return Mapper.MapInt(attr)
+	}
+	if !Mapper.IsField(attr) {
+		v := Mapper.MapInt(attr)(nil)
+		return func(r *Record) int64 { return v }
+	}
+	if Mapper.Type(attr) == IntType {
+		m := Mapper.Map(attr)
+		mi := Mapper.MapInt(attr)
+		return func(r *Record) int64 {
+			if v, ok := m(r).(int64); ok {
+				return v
+			}
+			return mi(r)
+		}
+	}
+	return Mapper.MapInt(attr)
+}
+
+// compileIntEq compiles an equality predicate over integer operands, comparing an integer
+// attribute with another integer attribute or with constant integers without string conversions.
+// Constant items that are not integers in canonical form never match. Operands that are not integers,
+// and records whose attributes do not map to integers, are compared using the string predicate p.
+func compileIntEq(lattr string, rattr string, p Predicate) Predicate {
+	if !typedPredicates {
+		return p
+	}
+	lf, rf := Mapper.IsField(lattr), Mapper.IsField(rattr)
+	lint, rint := lf && Mapper.Type(lattr) == IntType, rf && Mapper.Type(rattr) == IntType
+	switch {
+	case lint && rint:
+		ml, mr := Mapper.Map(lattr), Mapper.Map(rattr)
+		return func(r *Record) bool {
+			if l, ok := ml(r).(int64); ok {
+				if v, ok := mr(r).(int64); ok {
+					return l == v
+				}
+			}
+			return p(r)
+		}
+	case lint && !rf:
+		return compileIntConstEq(lattr, rattr, p)
+	case rint && !lf:
+		return compileIntConstEq(rattr, lattr, p)
+	}
+	return p
+}
+
+// compileIntConstEq compiles an equality predicate between integer attribute attr and constant c.
+func compileIntConstEq(attr string, c string, p Predicate) Predicate {
+	set := make(map[int64]bool)
+	for _, item := range constItems(c) {
+		if v, err := strconv.ParseInt(item, 10, 64); err == nil && strconv.FormatInt(v, 10) == item {
+			set[v] = true
+		}
+	}
+	m := Mapper.Map(attr)
+	return func(r *Record) bool {
+		if v, ok := m(r).(int64); ok {
+			return set[v]
+		}
+		return p(r)
+	}
+}
+
+// compileStrOp compiles a binary string predicate. When one operand is a constant,
+// its items are split at compile time and matched using the matcher built by mk.
+func compileStrOp(lattr string, rattr string, op operator, mk func(consts []string) itemMatcher) Predicate {
+	ml := Mapper.MapStr(lattr)
+	mr := Mapper.MapStr(rattr)
+	lf, rf := Mapper.IsField(lattr), Mapper.IsField(rattr)
+	if !typedPredicates {
+		mk = func(consts []string) itemMatcher { return opMatcher(consts, op) }
+	}
+	switch {
+	case lf && !rf:
+		consts := constItems(rattr)
+		match := valueMatcher(consts, mk(consts))
+		return func(r *Record) bool { return match(ml(r)) }
+	case !lf && !rf:
+		v := eval(ml(nil), mr(nil), op)
+		return func(r *Record) bool { return v }
+	default:
+		return func(r *Record) bool { return eval(ml(r), mr(r), op) }
+	}
+}
+
+// compileListOp compiles a predicate matching the items of attr against a constant list using
+// match, or op when predicates are not typed.
+func compileListOp(attr string, items []string, op operator, match itemMatcher) Predicate {
+	m := Mapper.MapStr(attr)
+	if !typedPredicates {
+		match = opMatcher(items, op)
+	}
+	match = valueMatcher(items, match)
+	if !Mapper.IsField(attr) {
+		v := match(m(nil))
+		return func(r *Record) bool { return v }
+	}
+	return func(r *Record) bool { return match(m(r)) }
+}
+
+// containsMatcher creates a matcher for substrings, using Aho-Corasick for multiple patterns.
+func containsMatcher(patterns []string) itemMatcher {
+	if len(patterns) == 1 {
+		p := patterns[0]
+		return func(s string) bool { return strings.Contains(s, p) }
+	}
+	ac := newACMatcher(patterns)
+	return ac.matchAny
+}
+
+// constItems returns the items of constant attr. A quoted literal denotes a single item.
+func constItems(attr string) []string {
+	if isQuoted(attr) {
+		return []string{unquote(attr)}
+	}
+	return strings.Split(attr, LISTSEP)
+}
+
+// splitItems splits each list element into its items.
+func splitItems(list []string) []string {
+	items := make([]string, 0, len(list))
+	for _, v := range list {
+		items = append(items, constItems(v)...)
+	}
+	return items
+}
+
+// valueMatcher extends match to attribute values. Values are matched item by item, and
+// also as a whole if a constant item contains a LISTSEP.
+func valueMatcher(consts []string, match itemMatcher) itemMatcher {
+	for _, c := range consts {
+		if strings.Contains(c, LISTSEP) {
+			return func(s string) bool { return match(s) || anyItem(s, match) }
+		}
+	}
+	return func(s string) bool { return anyItem(s, match) }
+}
+
+// anyItem returns true if match holds for any LISTSEP-separated item of s.
+func anyItem(s string, match itemMatcher) bool {
+	for {
+		i := strings.Index(s, LISTSEP)
+		if i < 0 {
+			return match(s)
+		}
+		if match(s[:i]) {
+			return true
+		}
+		s = s[i+len(LISTSEP):]
+	}
 }
 
 // operator type.
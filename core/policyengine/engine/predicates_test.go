@@ -20,10 +20,23 @@
 package engine_test
 
 import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
 	"testing"
 
+	"github.com/linkedin/goavro"
 	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/converter"
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/cache"
+	"github.com/sysflow-telemetry/sf-processor/core/flattener"
 	. "github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"github.com/sysflow-telemetry/sf-processor/core/processor"
 )
 
 func TestNot(t *testing.T) {
@@ -65,3 +78,264 @@ func TestAny(t *testing.T) {
 	assert.Equal(t, true, Any([]Criterion{False, True}).Eval(r))
 	assert.Equal(t, false, Any([]Criterion{False, False}).Eval(r))
 }
+
+func TestTypedPredicates(t *testing.T) {
+	r := newTestRecord(42)
+	assert.Equal(t, true, Eq(SF_PROC_EXE, "/usr/bin/bash").Eval(r))
+	assert.Equal(t, true, Eq(SF_PROC_EXE, "'/usr/bin/bash'").Eval(r))
+	assert.Equal(t, true, Eq(SF_TS, "42").Eval(r))
+	assert.Equal(t, true, Gt(SF_TS, "41").Eval(r))
+	assert.Equal(t, false, Gt(SF_TS, "42").Eval(r))
+	assert.Equal(t, true, Ge(SF_TS, SF_TS).Eval(r))
+	assert.Equal(t, true, Lt(SF_PROC_PID, "100").Eval(r))
+	assert.Equal(t, true, In(SF_PROC_NAME, []string{"sh", "bash,zsh"}).Eval(r))
+	assert.Equal(t, false, In(SF_PROC_NAME, []string{"sh", "zsh"}).Eval(r))
+	assert.Equal(t, true, PMatch(SF_PROC_EXE, []string{"/usr/sbin", "in/ba"}).Eval(r))
+	assert.Equal(t, false, PMatch(SF_PROC_EXE, []string{"/usr/sbin", "/bin/zsh"}).Eval(r))
+	assert.Equal(t, true, Contains(SF_PROC_ARGS, "foo,-c").Eval(r))
+	assert.Equal(t, true, IContains(SF_PROC_ARGS, "-C LS").Eval(r))
+	assert.Equal(t, true, StartsWith(SF_PROC_EXE, "/usr,/opt").Eval(r))
+	assert.Equal(t, true, EndsWith(SF_PROC_EXE, "bash").Eval(r))
+	assert.Equal(t, true, Eq("a", "a").Eval(r))
+	assert.Equal(t, false, Eq("a", "b").Eval(r))
+}
+
+func TestTypedIntEquality(t *testing.T) {
+	defer func(v bool) { *TypedPredicates = v }(*TypedPredicates)
+	r := newTestRecord(42)
+	for _, typed := range []bool{true, false} {
+		*TypedPredicates = typed
+		assert.Equal(t, true, Eq(SF_TS, "42").Eval(r))
+		assert.Equal(t, true, Eq("42", SF_TS).Eval(r))
+		assert.Equal(t, true, Eq(SF_TS, "7,42").Eval(r))
+		assert.Equal(t, true, Eq(SF_TS, SF_TS).Eval(r))
+		assert.Equal(t, false, Eq(SF_TS, "042").Eval(r))
+		assert.Equal(t, false, Eq(SF_TS, "abc").Eval(r))
+		assert.Equal(t, false, Eq(SF_TS, "'42 '").Eval(r))
+		assert.Equal(t, true, NEq(SF_TS, "abc").Eval(r))
+		assert.Equal(t, true, NEq(SF_TS, "41").Eval(r))
+		assert.Equal(t, false, NEq(SF_TS, "42").Eval(r))
+		assert.Equal(t, false, Eq(SF_TS, SF_RET).Eval(r))
+	}
+}
+
+func TestQuotedLiterals(t *testing.T) {
+	r := newTestRecord(42)
+	assert.Equal(t, true, Eq(SF_PROC_ARGS, `"-c ls"`).Eval(r))
+	assert.Equal(t, false, Eq(SF_PROC_EXE, `"/usr/bin/bash,/bin/sh"`).Eval(r))
+	assert.Equal(t, true, In(SF_PROC_EXE, []string{`"/bin/sh,/usr/bin/bash"`, "/usr/bin/bash"}).Eval(r))
+	assert.Equal(t, false, In(SF_PROC_EXE, []string{`"/bin/sh,/usr/bin/bash"`}).Eval(r))
+	assert.Equal(t, true, Contains(SF_PROC_ARGS, `"c \"ls\""`).Not().Eval(r))
+	assert.Equal(t, "a\"b\\c\\d", Mapper.MapStr(`"a\"b\\c\d"`)(r))
+	assert.Equal(t, `it's`, Mapper.MapStr(`'it''s'`)(r))
+	assert.Equal(t, `node -e "require('nan')"`, Mapper.MapStr(`'"node -e \"require(''nan'')\""'`)(r))
+}
+
+func newHostTestRecord() *Record {
+	r := newTestRecord(42)
+	r.Fr.Strs[0][sfgo.CONT_ID_STR] = ""
+	return r
+}
+
+// newNetTestRecord creates a network flow record between IPv4 addresses sip and dip.
+func newNetTestRecord(sip string, dip string) *Record {
+	r := newIndexTestRecord(sfgo.NET_FLOW, "/usr/bin/curl", "")
+	for attr, s := range map[sfgo.Attribute]string{sfgo.FL_NETW_SIP_INT: sip, sfgo.FL_NETW_DIP_INT: dip} {
+		ip := net.ParseIP(s).To4()
+		r.Fr.Ints[0][attr] = int64(int32(uint32(ip[0]) | uint32(ip[1])<<8 | uint32(ip[2])<<16 | uint32(ip[3])<<24))
+	}
+	return r
+}
+
+func TestInCIDR(t *testing.T) {
+	defer SetNullSemantics(SetNullSemantics(StrictNulls))
+	r := newNetTestRecord("192.168.1.20", "10.1.2.3")
+	assert.Equal(t, "10.1.2.3", Mapper.MapStr(SF_NET_DIP)(r))
+	assert.Equal(t, true, InCIDR(SF_NET_DIP, []string{"10.0.0.0/8"}).Eval(r))
+	assert.Equal(t, true, InCIDR(SF_NET_DIP, []string{"10.1.2.3"}).Eval(r))
+	assert.Equal(t, false, InCIDR(SF_NET_DIP, []string{"10.1.2.4/32", "11.0.0.0/8"}).Eval(r))
+	assert.Equal(t, true, InCIDR(SF_NET_SIP, []string{"172.16.0.0/12", "192.168.1.0/28,192.168.1.16/28"}).Eval(r))
+	assert.Equal(t, false, InCIDR(SF_NET_SIP, []string{"192.168.1.0/28"}).Eval(r))
+	assert.Equal(t, true, InCIDR(FALCO_FD_IP, []string{"10.1.0.0/16"}).Eval(r))
+	assert.Equal(t, true, InCIDR(SF_NET_IP, []string{"0.0.0.0/0"}).Eval(r))
+	assert.Equal(t, false, InCIDR(SF_NET_IP, []string{"not-a-network", "fe80::/10"}).Eval(r))
+	assert.Equal(t, true, InCIDR("'192.168.7.7'", []string{"192.168.0.0/16"}).Eval(r))
+	assert.Equal(t, false, InCIDR(SF_NET_DIP, []string{"0.0.0.0/0"}).Eval(newTestRecord(42)))
+	assert.Equal(t, true, Eq(SF_NET_SIP_PRIVATE, "true").Eval(r))
+	assert.Equal(t, true, Eq(SF_NET_DIP_PRIVATE, "true").Eval(r))
+	assert.Equal(t, false, Eq(SF_NET_IP_LOOPBACK, "true").Eval(r))
+	assert.Equal(t, true, Eq(SF_NET_IP_LOOPBACK, "true").Eval(newNetTestRecord("8.8.8.8", "127.0.0.53")))
+	assert.Equal(t, false, Eq(SF_NET_IP_PRIVATE, "true").Eval(newNetTestRecord("172.32.0.1", "8.8.8.8")))
+}
+
+func TestMissingAttributes(t *testing.T) {
+	defer SetNullSemantics(SetNullSemantics(StrictNulls))
+	r := newTestRecord(42)
+	assert.Equal(t, false, Eq(SF_NET_DPORT, "0").Eval(r))
+	assert.Equal(t, false, NEq(SF_NET_DPORT, "80").Eval(r))
+	assert.Equal(t, false, Gt(SF_NET_DPORT, "-1").Eval(r))
+	assert.Equal(t, false, Ge(SF_NET_DPORT, "0").Eval(r))
+	assert.Equal(t, false, Lt(SF_NET_DPORT, "80").Eval(r))
+	assert.Equal(t, false, Le(SF_NET_DPORT, "0").Eval(r))
+	assert.Equal(t, false, Ge(SF_ENDTS, SF_TS).Eval(r))
+	assert.Equal(t, false, In(SF_NET_DPORT, []string{"0", "80"}).Eval(r))
+	assert.Equal(t, false, PMatch(SF_FILE_PATH, []string{"", "/etc"}).Eval(r))
+	assert.Equal(t, false, Contains(SF_FILE_PATH, "").Eval(r))
+	assert.Equal(t, false, IContains(SF_FILE_PATH, "").Eval(r))
+	assert.Equal(t, false, StartsWith(SF_FILE_PATH, "").Eval(r))
+	assert.Equal(t, false, EndsWith(SF_FILE_PATH, "").Eval(r))
+	assert.Equal(t, false, Exists(SF_NET_DPORT).Eval(r))
+	assert.Equal(t, true, Exists(SF_NET_DPORT).Not().Eval(r))
+	assert.Equal(t, false, Exists(SF_PPROC_NAME).Eval(r))
+	assert.Equal(t, true, Exists(SF_PROC_EXE).Eval(r))
+	assert.Equal(t, true, Exists(SF_RET).Eval(r))
+	assert.Equal(t, true, Eq(SF_RET, "0").Eval(r))
+	assert.Equal(t, true, Exists(SF_CONTAINER_ID).Eval(r))
+	assert.Equal(t, true, NEq(FALCO_CONT_ID, "host").Eval(r))
+	h := newHostTestRecord()
+	assert.Equal(t, false, Exists(FALCO_CONT_ID).Eval(h))
+	assert.Equal(t, false, NEq(FALCO_CONT_ID, "host").Eval(h))
+	assert.Equal(t, true, NEq(FALCO_CONT_ID, "host").Not().Eval(h))
+}
+
+func TestMissingAttributesLegacy(t *testing.T) {
+	defer SetNullSemantics(SetNullSemantics(StrictNulls))
+	assert.NoError(t, NewPolicyInterpreter(Config{NullSemantics: LegacyNulls}).Compile())
+	r := newTestRecord(42)
+	assert.Equal(t, true, Eq(SF_NET_DPORT, "0").Eval(r))
+	assert.Equal(t, true, NEq(SF_NET_DPORT, "80").Eval(r))
+	assert.Equal(t, true, Gt(SF_NET_DPORT, "-1").Eval(r))
+	assert.Equal(t, true, Lt(SF_NET_DPORT, "80").Eval(r))
+	assert.Equal(t, true, In(SF_NET_DPORT, []string{"0", "80"}).Eval(r))
+	assert.Equal(t, true, PMatch(SF_FILE_PATH, []string{"", "/etc"}).Eval(r))
+	assert.Equal(t, true, StartsWith(SF_FILE_PATH, "").Eval(r))
+	assert.Equal(t, false, Exists(SF_NET_DPORT).Eval(r))
+	assert.Equal(t, true, Exists(SF_PROC_EXE).Eval(r))
+	assert.Equal(t, true, Exists(SF_FILE_PATH).Eval(newIndexTestRecord(sfgo.FILE_FLOW, "/usr/bin/cat", "")))
+	assert.Equal(t, true, NEq(FALCO_CONT_ID, "host").Eval(newHostTestRecord()))
+}
+
+func TestNullSemanticsConfig(t *testing.T) {
+	c, err := CreateConfig(map[string]string{PoliciesConfigKey: "."})
+	assert.NoError(t, err)
+	assert.Equal(t, LegacyNulls, c.NullSemantics)
+	c, err = CreateConfig(map[string]string{PoliciesConfigKey: ".", NullSemanticsKey: "strict"})
+	assert.NoError(t, err)
+	assert.Equal(t, StrictNulls, c.NullSemantics)
+	_, err = CreateConfig(map[string]string{PoliciesConfigKey: ".", NullSemanticsKey: "sql"})
+	assert.Error(t, err)
+}
+
+func BenchmarkEq(b *testing.B) {
+	r := newTestRecord(42)
+	c := Eq(SF_PROC_EXE, "/usr/bin/bash")
+	for i := 0; i < b.N; i++ {
+		c.Eval(r)
+	}
+}
+
+func BenchmarkEqInt(b *testing.B) {
+	r := newTestRecord(42)
+	c := Eq(SF_TS, "42")
+	for i := 0; i < b.N; i++ {
+		c.Eval(r)
+	}
+}
+
+func BenchmarkGt(b *testing.B) {
+	r := newTestRecord(42)
+	c := Gt(SF_TS, "41")
+	for i := 0; i < b.N; i++ {
+		c.Eval(r)
+	}
+}
+
+func BenchmarkIn(b *testing.B) {
+	r := newTestRecord(42)
+	list := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		list = append(list, "/usr/bin/bin"+strconv.Itoa(i))
+	}
+	c := In(SF_PROC_EXE, list)
+	for i := 0; i < b.N; i++ {
+		c.Eval(r)
+	}
+}
+
+func BenchmarkPMatch(b *testing.B) {
+	r := newTestRecord(42)
+	list := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		list = append(list, "/opt/bin"+strconv.Itoa(i))
+	}
+	c := PMatch(SF_PROC_EXE, list)
+	for i := 0; i < b.N; i++ {
+		c.Eval(r)
+	}
+}
+
+// readTraces flattens the records of the SysFlow traces matching pattern.
+func readTraces(t *testing.T, pattern string) []*sfgo.FlatRecord {
+	paths, err := filepath.Glob(pattern)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, paths)
+	ch := flattener.NewFlattenerChan(1024).(*flattener.FlatChannel)
+	proc := processor.NewSysFlowProcessor(flattener.NewFlattener())
+	assert.NoError(t, proc.Init(map[string]string{}))
+	proc.SetOutChan(ch)
+	in := processor.NewSysFlowChan(1024).(*plugins.SFChannel)
+	frs := make(chan []*sfgo.FlatRecord)
+	go func() {
+		var recs []*sfgo.FlatRecord
+		for fr := range ch.In {
+			recs = append(recs, fr)
+		}
+		frs <- recs
+	}()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go proc.Process(in, &wg)
+	sfobjcvter := converter.NewSFObjectConverter()
+	for _, path := range paths {
+		f, err := os.Open(path)
+		assert.NoError(t, err)
+		sreader, err := goavro.NewOCFReader(bufio.NewReader(f))
+		assert.NoError(t, err)
+		for sreader.Scan() {
+			datum, err := sreader.Read()
+			assert.NoError(t, err)
+			in.In <- sfobjcvter.ConvertToSysFlow(datum)
+		}
+		f.Close()
+	}
+	close(in.In)
+	wg.Wait()
+	proc.Cleanup()
+	return <-frs
+}
+
+// evalTraces returns the rules matched by each record when the compiled policies use typed or string predicates.
+func evalTraces(frs []*sfgo.FlatRecord, typed bool) []map[string]bool {
+	defer RecompileConditions()
+	defer func(v bool) { *TypedPredicates = v }(*TypedPredicates)
+	*TypedPredicates = typed
+	RecompileConditions()
+	matches := make([]map[string]bool, len(frs))
+	for i, fr := range frs {
+		matches[i] = matchedRules(NewRecord(*fr, cache.GetInstance()))
+	}
+	return matches
+}
+
+func TestTypedPredicatesEquivalence(t *testing.T) {
+	compileTestPolicies(t)
+	frs := readTraces(t, "../../../resources/traces/mon.*.sf")
+	typed := evalTraces(frs, true)
+	str := evalTraces(frs, false)
+	matched := 0
+	for i := range frs {
+		assert.Equal(t, str[i], typed[i], "record %d", i)
+		matched += len(typed[i])
+	}
+	assert.NotZero(t, matched)
+}
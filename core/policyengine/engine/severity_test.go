@@ -0,0 +1,105 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	. "github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+// matchedRule returns the rule named name matching record r.
+func matchedRule(p PolicyInterpreter, r *Record, name string) (Rule, bool) {
+	if match, _ := p.Process(false, false, r); match {
+		for _, rule := range r.Ctx.GetRules() {
+			if rule.Name == name {
+				return rule, true
+			}
+		}
+	}
+	return Rule{}, false
+}
+
+func TestParseSeverity(t *testing.T) {
+	for s, sev := range map[string]Severity{"debug": SevDebug, "info": SevInformational, "INFORMATIONAL": SevInformational,
+		"notice": SevNotice, "Warning": SevWarning, "error": SevError, "critical": SevCritical, "alert": SevAlert,
+		"emergency": SevEmergency, "low": SevNotice, "medium": SevWarning, "high": SevError} {
+		v, err := ParseSeverity(s)
+		assert.NoError(t, err)
+		assert.Equal(t, sev, v, s)
+	}
+	_, err := ParseSeverity("severe")
+	assert.Error(t, err)
+	assert.Equal(t, Low, SevNotice.Priority())
+	assert.Equal(t, Medium, SevWarning.Priority())
+	assert.Equal(t, High, SevAlert.Priority())
+	assert.Equal(t, "critical", SevCritical.String())
+}
+
+func TestSeverityPolicies(t *testing.T) {
+	compileTestPolicies(t)
+	r, ok := matchedRule(pi, newIndexTestRecord(sfgo.PROC_EVT, "/opt/severity/critical", ""), "Critical severity")
+	assert.True(t, ok)
+	assert.Equal(t, SevCritical, r.Severity)
+	assert.Equal(t, High, r.Priority)
+	assert.Equal(t, 90, r.Score)
+	r, ok = matchedRule(pi, newIndexTestRecord(sfgo.PROC_EVT, "/opt/severity/alert", ""), "Alert severity")
+	assert.True(t, ok)
+	assert.Equal(t, SevAlert, r.Severity)
+	assert.Equal(t, High, r.Priority)
+	assert.Equal(t, 0, r.Score)
+	r, ok = matchedRule(pi, newIndexTestRecord(sfgo.PROC_EVT, "/opt/severity/medium", ""), "Medium severity")
+	assert.True(t, ok)
+	assert.Equal(t, SevWarning, r.Severity)
+	assert.Equal(t, Medium, r.Priority)
+}
+
+func TestSeverityOverrides(t *testing.T) {
+	dir, err := ioutil.TempDir("", "severity")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := writeListFile(t, dir, "policy.yaml", `
+- rule: Overridden severity
+  desc: Unit test severity overrides by tag
+  condition: sf.type=PE and sf.proc.exe = /opt/severity/override
+  action: [alert]
+  priority: low
+  score: 10
+  tags: [override-test, override-test-pci]
+`)
+	conf, err := CreateConfig(map[string]string{PoliciesConfigKey: path,
+		SeverityOverridesKey: "override-test=warning, override-test-pci=critical", ScoreOverridesKey: "override-test=50"})
+	assert.NoError(t, err)
+	p := NewPolicyInterpreter(conf)
+	assert.NoError(t, p.Compile(path))
+	r, ok := matchedRule(p, newIndexTestRecord(sfgo.PROC_EVT, "/opt/severity/override", ""), "Overridden severity")
+	assert.True(t, ok)
+	assert.Equal(t, SevCritical, r.Severity)
+	assert.Equal(t, High, r.Priority)
+	assert.Equal(t, 50, r.Score)
+	_, err = CreateConfig(map[string]string{PoliciesConfigKey: path, SeverityOverridesKey: "override-test=severe"})
+	assert.Error(t, err)
+	_, err = CreateConfig(map[string]string{PoliciesConfigKey: path, ScoreOverridesKey: "override-test"})
+	assert.Error(t, err)
+}
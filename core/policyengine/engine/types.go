@@ -20,9 +20,13 @@
 package engine
 
 import (
+	"errors"
+	"fmt"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
 	"github.com/sysflow-telemetry/sf-processor/core/cache"
@@ -61,18 +65,86 @@ func (p Priority) String() string {
 	return [...]string{"low", "medium", "high"}[p]
 }
 
+// Severity denotes the type for rule severity, following Falco's severity scale.
+type Severity int
+
+// Severity enumeration, in increasing order of severity.
+const (
+	SevDebug Severity = iota
+	SevInformational
+	SevNotice
+	SevWarning
+	SevError
+	SevCritical
+	SevAlert
+	SevEmergency
+)
+
+// String returns the string representation of a severity instance.
+func (s Severity) String() string {
+	return [...]string{FPriorityDebug, FPriorityInformational, FPriorityNotice, FPriorityWarning,
+		FPriorityError, FPriorityCritical, FPriorityAlert, FPriorityEmergency}[s]
+}
+
+// Priority returns the SysFlow priority corresponding to a severity.
+func (s Severity) Priority() Priority {
+	if s >= SevError {
+		return High
+	} else if s == SevWarning {
+		return Medium
+	}
+	return Low
+}
+
+// ParseSeverity parses a Falco severity or SysFlow priority (low, medium, high) name, case insensitively.
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case Low.String():
+		return SevNotice, nil
+	case Medium.String():
+		return SevWarning, nil
+	case High.String():
+		return SevError, nil
+	case FPriorityInfo:
+		return SevInformational, nil
+	}
+	for sev := SevDebug; sev <= SevEmergency; sev++ {
+		if strings.EqualFold(sev.String(), s) {
+			return sev, nil
+		}
+	}
+	return SevNotice, errors.New("Unrecognized severity: " + s)
+}
+
 // Rule type
 type Rule struct {
 	Name      string
 	Desc      string
 	condition Criterion
 	Actions   []Action
+	Output    string
 	Tags      []EnrichmentTag
 	Priority  Priority
+	Severity  Severity
+	Score     int
 	Prefilter []string
 	Enabled   bool
 }
 
+// TagNames returns the tags of a rule as strings.
+func (s Rule) TagNames() []string {
+	var tags []string
+	for _, t := range s.Tags {
+		switch v := t.(type) {
+		case []string:
+			tags = append(tags, v...)
+		default:
+			tags = append(tags, fmt.Sprintf("%v", v))
+		}
+	}
+	return tags
+}
+
 func (s Rule) isApplicable(r *Record) bool {
 	if len(s.Prefilter) == 0 {
 		return true
@@ -86,6 +158,28 @@ func (s Rule) isApplicable(r *Record) bool {
 	return false
 }
 
+// outputre matches attribute references in rule output formats.
+var outputre = regexp.MustCompile(`%[A-Za-z0-9_.]+(\[[^\]]*\])?`)
+
+// FormatOutput renders the output format of a rule for record r, replacing %attribute references with
+// attribute values, as in Falco. Absent values are rendered as <NA>, and unknown attributes are kept as is.
+func (s Rule) FormatOutput(r *Record) string {
+	return outputre.ReplaceAllStringFunc(s.Output, func(ref string) string {
+		attr := strings.TrimRight(ref[1:], ".")
+		if !Mapper.IsField(attr) {
+			return ref
+		}
+		v := sfgo.Zeros.String
+		if Mapper.MapPresent(attr)(r) {
+			v = Mapper.MapStr(attr)(r)
+		}
+		if v == sfgo.Zeros.String {
+			v = "<NA>"
+		}
+		return v + ref[1+len(attr):]
+	})
+}
+
 // Filter type
 type Filter struct {
 	Name      string
@@ -99,6 +193,7 @@ type Record struct {
 	Cr    *cache.SFTables
 	Ptree map[sfgo.OID][]*sfgo.Process
 	Ctx   Context
+	ptmu  *sync.Mutex
 }
 
 // NewRecord creates a new Record isntance.
@@ -107,10 +202,30 @@ func NewRecord(fr sfgo.FlatRecord, cr *cache.SFTables) *Record {
 	r.Fr = fr
 	r.Cr = cr
 	r.Ptree = make(map[sfgo.OID][]*sfgo.Process)
-	r.Ctx = make(Context, 3)
+	r.ptmu = new(sync.Mutex)
+	r.Ctx = make(Context, numCtxKeys)
 	return r
 }
 
+// Copy returns a shallow copy of the record with its own context, so that stages receiving the
+// record on different channels can add rules and enrichments independently.
+func (r *Record) Copy() *Record {
+	c := *r
+	c.Ctx = make(Context, len(r.Ctx))
+	copy(c.Ctx, r.Ctx)
+	if rules := r.Ctx.GetRules(); rules != nil {
+		c.Ctx[ruleCtxKey] = append([]Rule(nil), rules...)
+	}
+	if e := r.Ctx.GetEnrichment(); e != nil {
+		m := make(map[string]interface{}, len(e))
+		for k, v := range e {
+			m[k] = v
+		}
+		c.Ctx[enrichCtxKey] = m
+	}
+	return &c
+}
+
 // RecordChannel type
 type RecordChannel struct {
 	In chan *Record
@@ -135,13 +250,21 @@ const (
 	ProcAName
 	ProcACmdLine
 	ProcAPID
+	ProcADepth
 )
 
+// maxAncestryDepth bounds the number of processes visited when walking a process ancestry.
+const maxAncestryDepth = 64
+
 // GetInt returns an integer value from internal flat record.
+// Values missing from the source's attribute array, e.g. in records produced by other data sources, are zero.
 func (r Record) GetInt(attr sfgo.Attribute, src sfgo.Source) int64 {
 	for idx, s := range r.Fr.Sources {
 		if s == src {
-			return r.Fr.Ints[idx][attr]
+			if idx < len(r.Fr.Ints) && int(attr) < len(r.Fr.Ints[idx]) {
+				return r.Fr.Ints[idx][attr]
+			}
+			break
 		}
 	}
 	return sfgo.Zeros.Int64
@@ -151,7 +274,10 @@ func (r Record) GetInt(attr sfgo.Attribute, src sfgo.Source) int64 {
 func (r Record) GetStr(attr sfgo.Attribute, src sfgo.Source) string {
 	for idx, s := range r.Fr.Sources {
 		if s == src {
-			return strings.TrimSpace(r.Fr.Strs[idx][attr])
+			if idx < len(r.Fr.Strs) && int(attr) < len(r.Fr.Strs[idx]) {
+				return strings.TrimSpace(r.Fr.Strs[idx][attr])
+			}
+			break
 		}
 	}
 	return sfgo.Zeros.String
@@ -162,15 +288,31 @@ func (r Record) GetProc(ID sfgo.OID) *sfgo.Process {
 	return r.Cr.GetProc(ID)
 }
 
+// getProcProv walks the ancestry of process ID, stopping at processes without a parent, at cycles,
+// and at maxAncestryDepth.
 func (r Record) getProcProv(ID sfgo.OID) []*sfgo.Process {
 	var ptree = make([]*sfgo.Process, 0)
-	if p := r.Cr.GetProc(ID); p != nil && p.Poid != nil && p.Poid.UnionType == sfgo.UnionNullOIDTypeEnumOID {
-		return append(append(ptree, p), r.getProcProv(*p.Poid.OID)...)
+	if r.Cr == nil {
+		return ptree
+	}
+	visited := make(map[sfgo.OID]bool)
+	for len(ptree) < maxAncestryDepth && !visited[ID] {
+		p := r.Cr.GetProc(ID)
+		if p == nil || p.Poid == nil || p.Poid.UnionType != sfgo.UnionNullOIDTypeEnumOID {
+			break
+		}
+		visited[ID] = true
+		ptree = append(ptree, p)
+		ID = *p.Poid.OID
 	}
 	return ptree
 }
 
 func (r Record) memoizePtree(ID sfgo.OID) []*sfgo.Process {
+	if r.ptmu != nil {
+		r.ptmu.Lock()
+		defer r.ptmu.Unlock()
+	}
 	if ptree, ok := r.Ptree[ID]; ok {
 		return ptree
 	}
@@ -256,11 +398,111 @@ func (r Record) GetCachedValue(ID sfgo.OID, attr RecAttribute) interface{} {
 				s = append(s, strconv.FormatInt(p.Oid.Hpid, 10))
 			}
 			return strings.Join(s, LISTSEP)
+		case ProcADepth:
+			return int64(len(ptree) - 1)
+		}
+	}
+	return sfgo.Zeros.String
+}
+
+// GetAncestorValue returns the value of ancestry attribute attr for the n-th process in the ancestry of
+// process ID, where 0 denotes the process itself, 1 its parent, and so on.
+func (r Record) GetAncestorValue(ID sfgo.OID, attr RecAttribute, n int) interface{} {
+	ptree := r.memoizePtree(ID)
+	if n >= len(ptree) {
+		if attr == ProcAPID {
+			return sfgo.Zeros.Int64
 		}
+		return sfgo.Zeros.String
+	}
+	p := ptree[n]
+	switch attr {
+	case ProcAName:
+		return filepath.Base(p.Exe)
+	case ProcAExe:
+		return p.Exe
+	case ProcACmdLine:
+		return p.Exe + SPACE + p.ExeArgs
+	case ProcAPID:
+		return p.Oid.Hpid
 	}
 	return sfgo.Zeros.String
 }
 
+// HasAncestor returns true if the n-th process in the ancestry of process ID can be resolved from cache.
+func (r Record) HasAncestor(ID sfgo.OID, n int) bool {
+	return n < len(r.memoizePtree(ID))
+}
+
+// ancestors returns a record for each ancestor of the record's process, in which process attributes
+// refer to the ancestor. All other attributes are those of the original record.
+func (r Record) ancestors() []*Record {
+	idx := -1
+	for i, s := range r.Fr.Sources {
+		if s == sfgo.SYSFLOW_SRC && i < len(r.Fr.Ints) && i < len(r.Fr.Strs) {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 || r.Cr == nil {
+		return nil
+	}
+	oid := sfgo.OID{CreateTS: r.GetInt(sfgo.PROC_OID_CREATETS_INT, sfgo.SYSFLOW_SRC), Hpid: r.GetInt(sfgo.PROC_OID_HPID_INT, sfgo.SYSFLOW_SRC)}
+	ptree := r.memoizePtree(oid)
+	if len(ptree) < 2 {
+		return nil
+	}
+	var recs = make([]*Record, 0, len(ptree)-1)
+	for _, p := range ptree[1:] {
+		ints := make([]int64, sfgo.INT_ARRAY_SIZE)
+		strs := make([]string, sfgo.STR_ARRAY_SIZE)
+		copy(ints, r.Fr.Ints[idx])
+		copy(strs, r.Fr.Strs[idx])
+		setProcAttributes(ints, strs, p)
+		fr := sfgo.FlatRecord{Sources: r.Fr.Sources, Ints: make([][]int64, len(r.Fr.Ints)), Strs: make([][]string, len(r.Fr.Strs))}
+		copy(fr.Ints, r.Fr.Ints)
+		copy(fr.Strs, r.Fr.Strs)
+		fr.Ints[idx], fr.Strs[idx] = ints, strs
+		recs = append(recs, &Record{Fr: fr, Cr: r.Cr, Ptree: r.Ptree, Ctx: r.Ctx, ptmu: r.ptmu})
+	}
+	return recs
+}
+
+// setProcAttributes overwrites the process attributes of flat arrays ints and strs with those of process p.
+func setProcAttributes(ints []int64, strs []string, p *sfgo.Process) {
+	ints[sfgo.PROC_OID_CREATETS_INT], ints[sfgo.PROC_OID_HPID_INT] = sfgo.Zeros.Int64, sfgo.Zeros.Int64
+	if p.Oid != nil {
+		ints[sfgo.PROC_OID_CREATETS_INT], ints[sfgo.PROC_OID_HPID_INT] = p.Oid.CreateTS, p.Oid.Hpid
+	}
+	ints[sfgo.PROC_POID_CREATETS_INT], ints[sfgo.PROC_POID_HPID_INT] = sfgo.Zeros.Int64, sfgo.Zeros.Int64
+	if p.Poid != nil && p.Poid.UnionType == sfgo.UnionNullOIDTypeEnumOID {
+		ints[sfgo.PROC_POID_CREATETS_INT], ints[sfgo.PROC_POID_HPID_INT] = p.Poid.OID.CreateTS, p.Poid.OID.Hpid
+	}
+	ints[sfgo.PROC_TS_INT] = p.Ts
+	ints[sfgo.PROC_UID_INT] = int64(p.Uid)
+	ints[sfgo.PROC_GID_INT] = int64(p.Gid)
+	ints[sfgo.PROC_TTY_INT] = boolToInt(p.Tty)
+	ints[sfgo.PROC_ENTRY_INT] = boolToInt(p.Entry)
+	strs[sfgo.PROC_EXE_STR] = p.Exe
+	strs[sfgo.PROC_EXEARGS_STR] = p.ExeArgs
+	strs[sfgo.PROC_USERNAME_STR] = p.UserName
+	strs[sfgo.PROC_GROUPNAME_STR] = p.GroupName
+	strs[sfgo.PROC_CONTAINERID_STRING_STR] = sfgo.Zeros.String
+	if p.ContainerId != nil && p.ContainerId.UnionType == sfgo.UnionNullStringTypeEnumString {
+		strs[sfgo.PROC_CONTAINERID_STRING_STR] = p.ContainerId.String
+	}
+}
+
+// HasCachedValue returns true if attr can be resolved from cache for process ID.
+func (r Record) HasCachedValue(ID sfgo.OID, attr RecAttribute) bool {
+	ptree := r.memoizePtree(ID)
+	switch attr {
+	case ProcAExe, ProcAName, ProcACmdLine, ProcAPID, ProcADepth:
+		return len(ptree) > 0
+	}
+	return len(ptree) > 1
+}
+
 // Context denotes the type for contextual information obtained during rule processing.
 type Context []interface{}
 
@@ -272,6 +514,8 @@ const (
 	ruleCtxKey contextKey = iota
 	tagCtxKey
 	hashCtxKey
+	enrichCtxKey
+	numCtxKeys
 )
 
 // AddRule stores add a rule instance to the set of rules matching a record.
@@ -316,6 +560,22 @@ func (s Context) GetHashes() HashSet {
 	return HashSet{}
 }
 
+// AddEnrichment stores an enrichment key/value pair into context object.
+func (s Context) AddEnrichment(key string, value interface{}) {
+	if s[enrichCtxKey] == nil {
+		s[enrichCtxKey] = make(map[string]interface{})
+	}
+	s[enrichCtxKey].(map[string]interface{})[key] = value
+}
+
+// GetEnrichment retrieves enrichment key/value pairs from context object.
+func (s Context) GetEnrichment() map[string]interface{} {
+	if s[enrichCtxKey] != nil {
+		return s[enrichCtxKey].(map[string]interface{})
+	}
+	return nil
+}
+
 // HashSet type
 type HashSet struct {
 	MD5      string
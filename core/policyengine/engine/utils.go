@@ -21,18 +21,63 @@ package engine
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
 )
 
-func trimBoundingQuotes(s string) string {
-	if len(s) > 0 && (s[0] == '"' || s[0] == '\'') {
-		s = s[1:]
+// isQuoted returns true if s is a quoted string literal.
+func isQuoted(s string) bool {
+	for _, q := range []string{`\"`, `''`, `"`, `'`} {
+		if len(s) >= 2*len(q) && strings.HasPrefix(s, q) && strings.HasSuffix(s, q) {
+			return true
+		}
 	}
-	if len(s) > 0 && (s[len(s)-1] == '"' || s[len(s)-1] == '\'') {
-		s = s[:len(s)-1]
+	return false
+}
+
+// unquote returns the value of string literal s. Escape sequences are resolved, and unknown
+// sequences are kept verbatim so that paths such as "C:\Windows" need not be escaped. In single
+// quoted literals, '' denotes a quote, and a quoted double quoted literal is unquoted in turn,
+// as in YAML-quoted Falco strings. Strings other than literals are returned as is.
+func unquote(s string) string {
+	for _, q := range []string{`\"`, `''`} {
+		if len(s) >= 2*len(q) && strings.HasPrefix(s, q) && strings.HasSuffix(s, q) {
+			return s[len(q) : len(s)-len(q)]
+		}
+	}
+	if !isQuoted(s) {
+		return s
+	}
+	v := s[1 : len(s)-1]
+	if s[0] == '\'' {
+		v = strings.ReplaceAll(v, "''", "'")
+		if strings.HasPrefix(v, `"`) && isQuoted(v) {
+			return unquote(v)
+		}
 	}
-	return s
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		if v[i] != '\\' || i == len(v)-1 {
+			b.WriteByte(v[i])
+			continue
+		}
+		i++
+		switch v[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '\\', '"', '\'':
+			b.WriteByte(v[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(v[i])
+		}
+	}
+	return b.String()
 }
 
 func parseSymPath(idx sfgo.Source, attr sfgo.Attribute, r *Record) (string, string) {
@@ -49,3 +94,11 @@ func parseSymPath(idx sfgo.Source, attr sfgo.Attribute, r *Record) (string, stri
 	}
 	return orig, ""
 }
+
+// boolToInt returns the flat record representation of boolean b.
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
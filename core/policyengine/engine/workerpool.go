@@ -0,0 +1,158 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"hash/fnv"
+	"sync"
+
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+)
+
+// evalJob wraps a record submitted to the worker pool.
+type evalJob struct {
+	r    *Record
+	res  []*Record
+	done chan struct{}
+}
+
+// WorkerPool evaluates compiled policies on records concurrently.
+//
+// With global ordering, records are emitted in submission order using a bounded
+// reorder buffer. With process or container ordering, records sharing the same key
+// are always evaluated by the same worker, preserving their relative order.
+// Each record is evaluated by a single worker, so its process tree memo is never
+// accessed by more than one goroutine at a time.
+type WorkerPool struct {
+	pi           PolicyInterpreter
+	applyFilters bool
+	filterOnly   bool
+	ordering     Ordering
+	out          func(r *Record)
+	queues       []chan *evalJob
+	pending      chan *evalJob
+	wg           sync.WaitGroup
+	cwg          sync.WaitGroup
+}
+
+// NewWorkerPool creates and starts a worker pool based on the engine configuration.
+func NewWorkerPool(pi PolicyInterpreter, conf Config, applyFilters bool, filterOnly bool, out func(r *Record)) *WorkerPool {
+	n := conf.Concurrency
+	if n < 1 {
+		n = DefaultConcurrency
+	}
+	bufSize := conf.ReorderBuffer
+	if bufSize < 1 {
+		bufSize = DefaultReorderBuffer
+	}
+	wp := &WorkerPool{pi: pi, applyFilters: applyFilters, filterOnly: filterOnly, ordering: conf.Ordering, out: out}
+	if wp.keyed() {
+		wp.queues = make([]chan *evalJob, n)
+		for i := range wp.queues {
+			wp.queues[i] = make(chan *evalJob, bufSize/n+1)
+		}
+	} else {
+		q := make(chan *evalJob, bufSize)
+		wp.queues = []chan *evalJob{q}
+		for i := 1; i < n; i++ {
+			wp.queues = append(wp.queues, q)
+		}
+	}
+	if wp.ordering == GlobalOrdering {
+		wp.pending = make(chan *evalJob, bufSize)
+		wp.cwg.Add(1)
+		go wp.collect()
+	}
+	for _, q := range wp.queues {
+		wp.wg.Add(1)
+		go wp.work(q)
+	}
+	return wp
+}
+
+// Submit schedules record r for evaluation. It blocks when the pool is saturated.
+func (wp *WorkerPool) Submit(r *Record) {
+	j := &evalJob{r: r}
+	if wp.ordering == GlobalOrdering {
+		j.done = make(chan struct{})
+		wp.pending <- j
+	}
+	wp.queues[wp.index(r)] <- j
+}
+
+// Close stops accepting records and waits until all submitted records have been emitted.
+func (wp *WorkerPool) Close() {
+	if wp.keyed() {
+		for _, q := range wp.queues {
+			close(q)
+		}
+	} else {
+		close(wp.queues[0])
+	}
+	wp.wg.Wait()
+	if wp.pending != nil {
+		close(wp.pending)
+		wp.cwg.Wait()
+	}
+}
+
+func (wp *WorkerPool) keyed() bool {
+	return wp.ordering == ProcessOrdering || wp.ordering == ContainerOrdering
+}
+
+// index returns the worker queue index for record r.
+func (wp *WorkerPool) index(r *Record) int {
+	if !wp.keyed() || len(wp.queues) == 1 {
+		return 0
+	}
+	var key uint64
+	if wp.ordering == ProcessOrdering {
+		key = uint64(r.GetInt(sfgo.PROC_OID_HPID_INT, sfgo.SYSFLOW_SRC))*31 + uint64(r.GetInt(sfgo.PROC_OID_CREATETS_INT, sfgo.SYSFLOW_SRC))
+	} else {
+		h := fnv.New64a()
+		h.Write([]byte(r.GetStr(sfgo.CONT_ID_STR, sfgo.SYSFLOW_SRC)))
+		key = h.Sum64()
+	}
+	return int(key % uint64(len(wp.queues)))
+}
+
+// work evaluates jobs from queue q.
+func (wp *WorkerPool) work(q chan *evalJob) {
+	defer wp.wg.Done()
+	for j := range q {
+		if j.done == nil {
+			wp.pi.ProcessAsync(wp.applyFilters, wp.filterOnly, j.r, wp.out)
+			continue
+		}
+		wp.pi.ProcessAsync(wp.applyFilters, wp.filterOnly, j.r, func(r *Record) { j.res = append(j.res, r) })
+		close(j.done)
+	}
+}
+
+// collect emits evaluated records in submission order.
+func (wp *WorkerPool) collect() {
+	defer wp.cwg.Done()
+	for j := range wp.pending {
+		<-j.done
+		for _, r := range j.res {
+			wp.out(r)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine_test
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/ioutils"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/cache"
+	. "github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+var compileOnce sync.Once
+
+func compileTestPolicies(tb testing.TB) PolicyInterpreter {
+	compileOnce.Do(func() {
+		paths, err := ioutils.ListFilePaths("../../../resources/policies/tests", ".yaml")
+		assert.NoError(tb, err)
+		assert.NoError(tb, pi.Compile(paths...))
+	})
+	return pi
+}
+
+func newTestRecord(seq int64) *Record {
+	ints := make([]int64, sfgo.INT_ARRAY_SIZE)
+	strs := make([]string, sfgo.STR_ARRAY_SIZE)
+	ints[sfgo.SF_REC_TYPE] = sfgo.PROC_EVT
+	ints[sfgo.TS_INT] = seq
+	ints[sfgo.PROC_OID_HPID_INT] = seq % 64
+	strs[sfgo.PROC_EXE_STR] = "/usr/bin/bash"
+	strs[sfgo.PROC_EXEARGS_STR] = "-c ls"
+	strs[sfgo.CONT_ID_STR] = "cont" + strconv.FormatInt(seq%8, 10)
+	fr := sfgo.FlatRecord{Sources: []sfgo.Source{sfgo.SYSFLOW_SRC}, Ints: [][]int64{ints}, Strs: [][]string{strs}}
+	return NewRecord(fr, cache.GetInstance())
+}
+
+func TestWorkerPoolGlobalOrdering(t *testing.T) {
+	p := compileTestPolicies(t)
+	var seqs []int64
+	out := func(r *Record) { seqs = append(seqs, r.GetInt(sfgo.TS_INT, sfgo.SYSFLOW_SRC)) }
+	wp := NewWorkerPool(p, Config{Concurrency: 8, Ordering: GlobalOrdering, ReorderBuffer: 16}, false, true, out)
+	for i := int64(0); i < 1000; i++ {
+		wp.Submit(newTestRecord(i))
+	}
+	wp.Close()
+	assert.GreaterOrEqual(t, len(seqs), 1000)
+	for i := 1; i < len(seqs); i++ {
+		assert.LessOrEqual(t, seqs[i-1], seqs[i])
+	}
+}
+
+func TestWorkerPoolKeyOrdering(t *testing.T) {
+	p := compileTestPolicies(t)
+	var mu sync.Mutex
+	last := make(map[string]int64)
+	out := func(r *Record) {
+		mu.Lock()
+		defer mu.Unlock()
+		key := r.GetStr(sfgo.CONT_ID_STR, sfgo.SYSFLOW_SRC)
+		seq := r.GetInt(sfgo.TS_INT, sfgo.SYSFLOW_SRC)
+		assert.LessOrEqual(t, last[key], seq)
+		last[key] = seq
+	}
+	wp := NewWorkerPool(p, Config{Concurrency: 4, Ordering: ContainerOrdering}, false, true, out)
+	for i := int64(0); i < 1000; i++ {
+		wp.Submit(newTestRecord(i))
+	}
+	wp.Close()
+	assert.Equal(t, 8, len(last))
+}
+
+func BenchmarkProcessSerial(b *testing.B) {
+	p := compileTestPolicies(b)
+	out := func(r *Record) {}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.ProcessAsync(true, false, newTestRecord(int64(i)), out)
+	}
+}
+
+func benchmarkWorkerPool(b *testing.B, ordering Ordering) {
+	p := compileTestPolicies(b)
+	out := func(r *Record) {}
+	conf := Config{Concurrency: runtime.GOMAXPROCS(0), Ordering: ordering, ReorderBuffer: DefaultReorderBuffer}
+	b.ResetTimer()
+	wp := NewWorkerPool(p, conf, true, false, out)
+	for i := 0; i < b.N; i++ {
+		wp.Submit(newTestRecord(int64(i)))
+	}
+	wp.Close()
+}
+
+func BenchmarkWorkerPoolGlobalOrdering(b *testing.B) {
+	benchmarkWorkerPool(b, GlobalOrdering)
+}
+
+func BenchmarkWorkerPoolProcessOrdering(b *testing.B) {
+	benchmarkWorkerPool(b, ProcessOrdering)
+}
+
+func BenchmarkWorkerPoolContainerOrdering(b *testing.B) {
+	benchmarkWorkerPool(b, ContainerOrdering)
+}
+
+func BenchmarkWorkerPoolNoOrdering(b *testing.B) {
+	benchmarkWorkerPool(b, NoOrdering)
+}
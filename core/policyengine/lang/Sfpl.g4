@@ -18,9 +18,17 @@ WARNEVTTYPE: 'warn_evttypes';
 SKIPUNKNOWN: 'skip-if-unknown-filter';
 FAPPEND: 'append';
 REQ: 'required_engine_version';
+EXCEPTIONS: 'exceptions';
+FIELDS: 'fields';
+COMPS: 'comps';
+VALUES: 'values';
+SOURCE: 'source';
+FORMAT: 'format';
+COLUMN: 'column';
+SCORE: 'score';
 
 policy
-	: (prule | pfilter | pmacro | plist | preq)+ EOF
+	: (prule | pfilter | smacro | slist | preq)+ EOF
 	;
 
 defs
@@ -28,11 +36,11 @@ defs
 	;
 
 prule
-	: DECL RULE DEF text DESC DEF text COND DEF expression ((ACTION|OUTPUT) DEF text | PRIORITY DEF severity | TAGS DEF tags | PREFILTER DEF prefilter | ENABLED DEF enabled | WARNEVTTYPE DEF warnevttype | SKIPUNKNOWN DEF skipunknown)*
+	: DECL RULE DEF text (DESC DEF text | COND DEF condition | (ACTION|OUTPUT) DEF text | PRIORITY DEF severity | TAGS DEF tags | PREFILTER DEF prefilter | ENABLED DEF enabled | WARNEVTTYPE DEF warnevttype | SKIPUNKNOWN DEF skipunknown | EXCEPTIONS DEF exceptions | SCORE DEF score | FAPPEND DEF fappend)*
 	;
 
 srule
-	: DECL RULE DEF text DESC DEF text COND DEF expression ((ACTION|OUTPUT) DEF text | PRIORITY DEF severity | TAGS DEF tags | PREFILTER DEF prefilter | ENABLED DEF enabled | WARNEVTTYPE DEF warnevttype | SKIPUNKNOWN DEF skipunknown)*
+	: DECL RULE DEF text (DESC DEF text | COND DEF condition | (ACTION|OUTPUT) DEF text | PRIORITY DEF severity | TAGS DEF tags | PREFILTER DEF prefilter | ENABLED DEF enabled | WARNEVTTYPE DEF warnevttype | SKIPUNKNOWN DEF skipunknown | EXCEPTIONS DEF exceptions | SCORE DEF score | FAPPEND DEF fappend)*
 	;
 
 pfilter
@@ -44,17 +52,33 @@ sfilter
 	;
 
 pmacro
-	: DECL MACRO DEF ID COND DEF expression (FAPPEND DEF fappend)?
+	: DECL MACRO DEF ID (FAPPEND DEF fappend)? COND DEF condition (FAPPEND DEF fappend)?
+	;
+
+smacro
+	: DECL MACRO DEF ID (FAPPEND DEF fappend)? COND DEF condition (FAPPEND DEF fappend)?
 	;
 
 plist
-	: DECL LIST DEF ID ITEMS DEF items 
+	: DECL LIST DEF ID (FAPPEND DEF fappend)? (ITEMS DEF items | listsource) (FAPPEND DEF fappend)?
+	;
+
+slist
+	: DECL LIST DEF ID (FAPPEND DEF fappend)? (ITEMS DEF items | listsource) (FAPPEND DEF fappend)?
 	;
 
 preq
 	: DECL REQ DEF atom
 	;
+
+listsource
+	: (SOURCE DEF atom | FORMAT DEF atom | COLUMN DEF atom)+
+	;
 	
+condition
+	: (AND | OR)? expression
+	;
+
 expression 
 	: or_expression 
 	;
@@ -72,7 +96,8 @@ term
 	| NOT term
 	| atom unary_operator 
 	| atom binary_operator atom 
-	| atom (IN|PMATCH) LPAREN (atom|items) (LISTSEP (atom|items))* RPAREN 
+	| atom (IN|PMATCH|INCIDR) LPAREN (atom|items) (LISTSEP (atom|items))* RPAREN 
+	| ANCESTOR LPAREN expression RPAREN
 	| LPAREN expression RPAREN
 	;
 
@@ -88,6 +113,42 @@ prefilter
 	: items
 	;
 
+exceptions
+	: exception*
+	| LBRACK RBRACK
+	;
+
+exception
+	: DECL NAME DEF ID (FIELDS DEF fields | COMPS DEF comps | VALUES DEF values)*
+	;
+
+fields
+	: items
+	| atom
+	;
+
+comps
+	: LBRACK (comp (LISTSEP comp)*)? (LISTSEP)? RBRACK
+	| comp
+	;
+
+comp
+	: binary_operator
+	| IN
+	| PMATCH
+	| INCIDR
+	;
+
+values
+	: LBRACK (value (LISTSEP value)*)? (LISTSEP)? RBRACK
+	| (DECL value)+
+	;
+
+value
+	: LBRACK (value (LISTSEP value)*)? (LISTSEP)? RBRACK
+	| atom
+	;
+
 severity
 	: SEVERITY
 	;
@@ -104,6 +165,10 @@ skipunknown
 	: atom
 	;
 
+score
+	: atom
+	;
+
 fappend
 	: atom
 	;
@@ -117,13 +182,27 @@ atom
 	| PATH
 	| NUMBER
 	| TAG
+	| CIDR
 	| STRING	
+	| SEVERITY
+	| keyword
 	| '<' /* event direction */
 	| '>' /* event direction */
 	;
 
+keyword /* contextual keywords */
+	: EXCEPTIONS
+	| FIELDS
+	| COMPS
+	| VALUES
+	| SOURCE
+	| FORMAT
+	| COLUMN
+	| SCORE
+	;
+
 text
-	: ({!(p.GetCurrentToken().GetText() == "desc" ||
+	: ({!((p.GetCurrentToken().GetText() == "desc" ||
 	      p.GetCurrentToken().GetText() == "condition" ||
 	      p.GetCurrentToken().GetText() == "action" ||
 	      p.GetCurrentToken().GetText() == "output" ||
@@ -133,7 +212,10 @@ text
 		  p.GetCurrentToken().GetText() == "enabled" ||
 		  p.GetCurrentToken().GetText() == "warn_evttypes" ||
 		  p.GetCurrentToken().GetText() == "skip-if-unknown-filter" ||
-		  p.GetCurrentToken().GetText() == "append")}? .)+
+		  p.GetCurrentToken().GetText() == "exceptions" ||
+		  p.GetCurrentToken().GetText() == "score" ||
+		  p.GetCurrentToken().GetText() == "append") &&
+		  p.GetTokenStream().LT(2).GetTokenType() == SfplParserDEF)}? .)+
 	;
 	
 binary_operator 
@@ -213,10 +295,18 @@ PMATCH
 	: 'pmatch'
 	;
 
+INCIDR
+	: 'in_cidr'
+	;
+
 EXISTS 
 	: 'exists'
 	;
 
+ANCESTOR
+	: 'any' [ \t\r\n]+ 'ancestor' [ \t\r\n]+ 'matches'
+	;
+
 LBRACK 
 	: '['
 	;
@@ -268,6 +358,10 @@ FSEVERITY
 	| D E B U G
 	;
 
+CIDR
+	: [0-9]+ '.' [0-9]+ '.' [0-9]+ '.' [0-9]+ '/' [0-9]+
+	;
+
 ID
 	:  ('a'..'z' | 'A'..'Z' | '0'..'9' | '_') ('a'..'z' | 'A'..'Z' | '0'..'9' | '_' | '-' | '.' | ':'? '[' (NUMBER|PATH) (':' PATH)* ']' | '*' )*	
 	;
@@ -281,10 +375,10 @@ PATH
 	;
 
 STRING 
-    : '"' (STRING|STRLIT) '"' 
-    | '\'' (STRING|STRLIT) '\''
-    | '\\"' (STRING|STRLIT) '\\"'
-    | '\'\'' (STRING|STRLIT) '\'\''
+    : '\\"' STRLIT '\\"'
+    | '\'\'' STRLIT '\'\''
+    | '"' (ESC | ~["\\\r\n])* '"'
+    | '\'' (ESC | '\'\'' | ~['\\\r\n])* '\''
     ;
 
 TAG
@@ -296,7 +390,9 @@ fragment STRLIT
     : ~[\r\n]*?
 	;
 	
-fragment ESC : '\\"' | '\'\'' ;
+fragment ESC
+    : '\\' ~[\r\n]
+    ;
 	
 WS
 	: [ \t\r\n\u000C]+ -> channel(HIDDEN)
@@ -62,18 +62,42 @@ func (s *BaseSfplListener) EnterPmacro(ctx *PmacroContext) {}
 // ExitPmacro is called when production pmacro is exited.
 func (s *BaseSfplListener) ExitPmacro(ctx *PmacroContext) {}
 
+// EnterSmacro is called when production smacro is entered.
+func (s *BaseSfplListener) EnterSmacro(ctx *SmacroContext) {}
+
+// ExitSmacro is called when production smacro is exited.
+func (s *BaseSfplListener) ExitSmacro(ctx *SmacroContext) {}
+
 // EnterPlist is called when production plist is entered.
 func (s *BaseSfplListener) EnterPlist(ctx *PlistContext) {}
 
 // ExitPlist is called when production plist is exited.
 func (s *BaseSfplListener) ExitPlist(ctx *PlistContext) {}
 
+// EnterSlist is called when production slist is entered.
+func (s *BaseSfplListener) EnterSlist(ctx *SlistContext) {}
+
+// ExitSlist is called when production slist is exited.
+func (s *BaseSfplListener) ExitSlist(ctx *SlistContext) {}
+
 // EnterPreq is called when production preq is entered.
 func (s *BaseSfplListener) EnterPreq(ctx *PreqContext) {}
 
 // ExitPreq is called when production preq is exited.
 func (s *BaseSfplListener) ExitPreq(ctx *PreqContext) {}
 
+// EnterListsource is called when production listsource is entered.
+func (s *BaseSfplListener) EnterListsource(ctx *ListsourceContext) {}
+
+// ExitListsource is called when production listsource is exited.
+func (s *BaseSfplListener) ExitListsource(ctx *ListsourceContext) {}
+
+// EnterCondition is called when production condition is entered.
+func (s *BaseSfplListener) EnterCondition(ctx *ConditionContext) {}
+
+// ExitCondition is called when production condition is exited.
+func (s *BaseSfplListener) ExitCondition(ctx *ConditionContext) {}
+
 // EnterExpression is called when production expression is entered.
 func (s *BaseSfplListener) EnterExpression(ctx *ExpressionContext) {}
 
@@ -116,6 +140,48 @@ func (s *BaseSfplListener) EnterPrefilter(ctx *PrefilterContext) {}
 // ExitPrefilter is called when production prefilter is exited.
 func (s *BaseSfplListener) ExitPrefilter(ctx *PrefilterContext) {}
 
+// EnterExceptions is called when production exceptions is entered.
+func (s *BaseSfplListener) EnterExceptions(ctx *ExceptionsContext) {}
+
+// ExitExceptions is called when production exceptions is exited.
+func (s *BaseSfplListener) ExitExceptions(ctx *ExceptionsContext) {}
+
+// EnterException is called when production exception is entered.
+func (s *BaseSfplListener) EnterException(ctx *ExceptionContext) {}
+
+// ExitException is called when production exception is exited.
+func (s *BaseSfplListener) ExitException(ctx *ExceptionContext) {}
+
+// EnterFields is called when production fields is entered.
+func (s *BaseSfplListener) EnterFields(ctx *FieldsContext) {}
+
+// ExitFields is called when production fields is exited.
+func (s *BaseSfplListener) ExitFields(ctx *FieldsContext) {}
+
+// EnterComps is called when production comps is entered.
+func (s *BaseSfplListener) EnterComps(ctx *CompsContext) {}
+
+// ExitComps is called when production comps is exited.
+func (s *BaseSfplListener) ExitComps(ctx *CompsContext) {}
+
+// EnterComp is called when production comp is entered.
+func (s *BaseSfplListener) EnterComp(ctx *CompContext) {}
+
+// ExitComp is called when production comp is exited.
+func (s *BaseSfplListener) ExitComp(ctx *CompContext) {}
+
+// EnterValues is called when production values is entered.
+func (s *BaseSfplListener) EnterValues(ctx *ValuesContext) {}
+
+// ExitValues is called when production values is exited.
+func (s *BaseSfplListener) ExitValues(ctx *ValuesContext) {}
+
+// EnterValue is called when production value is entered.
+func (s *BaseSfplListener) EnterValue(ctx *ValueContext) {}
+
+// ExitValue is called when production value is exited.
+func (s *BaseSfplListener) ExitValue(ctx *ValueContext) {}
+
 // EnterSeverity is called when production severity is entered.
 func (s *BaseSfplListener) EnterSeverity(ctx *SeverityContext) {}
 
@@ -140,6 +206,12 @@ func (s *BaseSfplListener) EnterSkipunknown(ctx *SkipunknownContext) {}
 // ExitSkipunknown is called when production skipunknown is exited.
 func (s *BaseSfplListener) ExitSkipunknown(ctx *SkipunknownContext) {}
 
+// EnterScore is called when production score is entered.
+func (s *BaseSfplListener) EnterScore(ctx *ScoreContext) {}
+
+// ExitScore is called when production score is exited.
+func (s *BaseSfplListener) ExitScore(ctx *ScoreContext) {}
+
 // EnterFappend is called when production fappend is entered.
 func (s *BaseSfplListener) EnterFappend(ctx *FappendContext) {}
 
@@ -158,6 +230,12 @@ func (s *BaseSfplListener) EnterAtom(ctx *AtomContext) {}
 // ExitAtom is called when production atom is exited.
 func (s *BaseSfplListener) ExitAtom(ctx *AtomContext) {}
 
+// EnterKeyword is called when production keyword is entered.
+func (s *BaseSfplListener) EnterKeyword(ctx *KeywordContext) {}
+
+// ExitKeyword is called when production keyword is exited.
+func (s *BaseSfplListener) ExitKeyword(ctx *KeywordContext) {}
+
 // EnterText is called when production text is entered.
 func (s *BaseSfplListener) EnterText(ctx *TextContext) {}
 
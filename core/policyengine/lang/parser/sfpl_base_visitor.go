@@ -35,14 +35,30 @@ func (v *BaseSfplVisitor) VisitPmacro(ctx *PmacroContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSfplVisitor) VisitSmacro(ctx *SmacroContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSfplVisitor) VisitPlist(ctx *PlistContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSfplVisitor) VisitSlist(ctx *SlistContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSfplVisitor) VisitPreq(ctx *PreqContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSfplVisitor) VisitListsource(ctx *ListsourceContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitCondition(ctx *ConditionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSfplVisitor) VisitExpression(ctx *ExpressionContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -71,6 +87,34 @@ func (v *BaseSfplVisitor) VisitPrefilter(ctx *PrefilterContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSfplVisitor) VisitExceptions(ctx *ExceptionsContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitException(ctx *ExceptionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitFields(ctx *FieldsContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitComps(ctx *CompsContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitComp(ctx *CompContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitValues(ctx *ValuesContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitValue(ctx *ValueContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSfplVisitor) VisitSeverity(ctx *SeverityContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -87,6 +131,10 @@ func (v *BaseSfplVisitor) VisitSkipunknown(ctx *SkipunknownContext) interface{}
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSfplVisitor) VisitScore(ctx *ScoreContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSfplVisitor) VisitFappend(ctx *FappendContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -99,6 +147,10 @@ func (v *BaseSfplVisitor) VisitAtom(ctx *AtomContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSfplVisitor) VisitKeyword(ctx *KeywordContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSfplVisitor) VisitText(ctx *TextContext) interface{} {
 	return v.VisitChildren(ctx)
 }
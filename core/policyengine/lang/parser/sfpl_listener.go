@@ -28,12 +28,24 @@ type SfplListener interface {
 	// EnterPmacro is called when entering the pmacro production.
 	EnterPmacro(c *PmacroContext)
 
+	// EnterSmacro is called when entering the smacro production.
+	EnterSmacro(c *SmacroContext)
+
 	// EnterPlist is called when entering the plist production.
 	EnterPlist(c *PlistContext)
 
+	// EnterSlist is called when entering the slist production.
+	EnterSlist(c *SlistContext)
+
 	// EnterPreq is called when entering the preq production.
 	EnterPreq(c *PreqContext)
 
+	// EnterListsource is called when entering the listsource production.
+	EnterListsource(c *ListsourceContext)
+
+	// EnterCondition is called when entering the condition production.
+	EnterCondition(c *ConditionContext)
+
 	// EnterExpression is called when entering the expression production.
 	EnterExpression(c *ExpressionContext)
 
@@ -55,6 +67,27 @@ type SfplListener interface {
 	// EnterPrefilter is called when entering the prefilter production.
 	EnterPrefilter(c *PrefilterContext)
 
+	// EnterExceptions is called when entering the exceptions production.
+	EnterExceptions(c *ExceptionsContext)
+
+	// EnterException is called when entering the exception production.
+	EnterException(c *ExceptionContext)
+
+	// EnterFields is called when entering the fields production.
+	EnterFields(c *FieldsContext)
+
+	// EnterComps is called when entering the comps production.
+	EnterComps(c *CompsContext)
+
+	// EnterComp is called when entering the comp production.
+	EnterComp(c *CompContext)
+
+	// EnterValues is called when entering the values production.
+	EnterValues(c *ValuesContext)
+
+	// EnterValue is called when entering the value production.
+	EnterValue(c *ValueContext)
+
 	// EnterSeverity is called when entering the severity production.
 	EnterSeverity(c *SeverityContext)
 
@@ -67,6 +100,9 @@ type SfplListener interface {
 	// EnterSkipunknown is called when entering the skipunknown production.
 	EnterSkipunknown(c *SkipunknownContext)
 
+	// EnterScore is called when entering the score production.
+	EnterScore(c *ScoreContext)
+
 	// EnterFappend is called when entering the fappend production.
 	EnterFappend(c *FappendContext)
 
@@ -76,6 +112,9 @@ type SfplListener interface {
 	// EnterAtom is called when entering the atom production.
 	EnterAtom(c *AtomContext)
 
+	// EnterKeyword is called when entering the keyword production.
+	EnterKeyword(c *KeywordContext)
+
 	// EnterText is called when entering the text production.
 	EnterText(c *TextContext)
 
@@ -106,12 +145,24 @@ type SfplListener interface {
 	// ExitPmacro is called when exiting the pmacro production.
 	ExitPmacro(c *PmacroContext)
 
+	// ExitSmacro is called when exiting the smacro production.
+	ExitSmacro(c *SmacroContext)
+
 	// ExitPlist is called when exiting the plist production.
 	ExitPlist(c *PlistContext)
 
+	// ExitSlist is called when exiting the slist production.
+	ExitSlist(c *SlistContext)
+
 	// ExitPreq is called when exiting the preq production.
 	ExitPreq(c *PreqContext)
 
+	// ExitListsource is called when exiting the listsource production.
+	ExitListsource(c *ListsourceContext)
+
+	// ExitCondition is called when exiting the condition production.
+	ExitCondition(c *ConditionContext)
+
 	// ExitExpression is called when exiting the expression production.
 	ExitExpression(c *ExpressionContext)
 
@@ -133,6 +184,27 @@ type SfplListener interface {
 	// ExitPrefilter is called when exiting the prefilter production.
 	ExitPrefilter(c *PrefilterContext)
 
+	// ExitExceptions is called when exiting the exceptions production.
+	ExitExceptions(c *ExceptionsContext)
+
+	// ExitException is called when exiting the exception production.
+	ExitException(c *ExceptionContext)
+
+	// ExitFields is called when exiting the fields production.
+	ExitFields(c *FieldsContext)
+
+	// ExitComps is called when exiting the comps production.
+	ExitComps(c *CompsContext)
+
+	// ExitComp is called when exiting the comp production.
+	ExitComp(c *CompContext)
+
+	// ExitValues is called when exiting the values production.
+	ExitValues(c *ValuesContext)
+
+	// ExitValue is called when exiting the value production.
+	ExitValue(c *ValueContext)
+
 	// ExitSeverity is called when exiting the severity production.
 	ExitSeverity(c *SeverityContext)
 
@@ -145,6 +217,9 @@ type SfplListener interface {
 	// ExitSkipunknown is called when exiting the skipunknown production.
 	ExitSkipunknown(c *SkipunknownContext)
 
+	// ExitScore is called when exiting the score production.
+	ExitScore(c *ScoreContext)
+
 	// ExitFappend is called when exiting the fappend production.
 	ExitFappend(c *FappendContext)
 
@@ -154,6 +229,9 @@ type SfplListener interface {
 	// ExitAtom is called when exiting the atom production.
 	ExitAtom(c *AtomContext)
 
+	// ExitKeyword is called when exiting the keyword production.
+	ExitKeyword(c *KeywordContext)
+
 	// ExitText is called when exiting the text production.
 	ExitText(c *TextContext)
 
@@ -15,141 +15,255 @@ var _ = reflect.Copy
 var _ = strconv.Itoa
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 55, 310,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 66, 542,
 	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7,
 	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13,
 	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9,
 	18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 4, 22, 9, 22, 4, 23, 9, 23,
-	4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 4, 27, 9, 27, 3, 2, 3, 2, 3,
-	2, 3, 2, 3, 2, 6, 2, 60, 10, 2, 13, 2, 14, 2, 61, 3, 2, 3, 2, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 7, 3, 71, 10, 3, 12, 3, 14, 3, 74, 11, 3, 3, 3, 3,
-	3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3,
+	4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 4, 27, 9, 27, 4, 28, 9, 28, 4,
+	29, 9, 29, 4, 30, 9, 30, 4, 31, 9, 31, 4, 32, 9, 32, 4, 33, 9, 33, 4, 34,
+	9, 34, 4, 35, 9, 35, 4, 36, 9, 36, 4, 37, 9, 37, 4, 38, 9, 38, 4, 39, 9,
+	39, 4, 40, 9, 40, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 6, 2, 86, 10, 2, 13, 2,
+	14, 2, 87, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 7, 3, 97, 10, 3, 12,
+	3, 14, 3, 100, 11, 3, 3, 3, 3, 3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3,
 	4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3,
-	4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 7, 4, 109, 10, 4, 12, 4, 14,
-	4, 112, 11, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3,
-	5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3,
-	5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 7, 5, 145, 10,
-	5, 12, 5, 14, 5, 148, 11, 5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6,
-	3, 6, 3, 6, 3, 6, 5, 6, 160, 10, 6, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7,
-	3, 7, 3, 7, 3, 7, 3, 7, 5, 7, 172, 10, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8,
-	3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 5, 8, 184, 10, 8, 3, 9, 3, 9, 3, 9, 3, 9,
-	3, 9, 3, 9, 3, 9, 3, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 11, 3, 11,
-	3, 12, 3, 12, 3, 12, 7, 12, 204, 10, 12, 12, 12, 14, 12, 207, 11, 12, 3,
-	13, 3, 13, 3, 13, 7, 13, 212, 10, 13, 12, 13, 14, 13, 215, 11, 13, 3, 14,
-	3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3,
-	14, 3, 14, 3, 14, 3, 14, 5, 14, 232, 10, 14, 3, 14, 3, 14, 3, 14, 5, 14,
-	237, 10, 14, 7, 14, 239, 10, 14, 12, 14, 14, 14, 242, 11, 14, 3, 14, 3,
-	14, 3, 14, 3, 14, 3, 14, 3, 14, 5, 14, 250, 10, 14, 3, 15, 3, 15, 3, 15,
-	3, 15, 7, 15, 256, 10, 15, 12, 15, 14, 15, 259, 11, 15, 5, 15, 261, 10,
-	15, 3, 15, 5, 15, 264, 10, 15, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16, 3, 16,
-	7, 16, 272, 10, 16, 12, 16, 14, 16, 275, 11, 16, 5, 16, 277, 10, 16, 3,
-	16, 5, 16, 280, 10, 16, 3, 16, 3, 16, 3, 17, 3, 17, 3, 18, 3, 18, 3, 19,
-	3, 19, 3, 20, 3, 20, 3, 21, 3, 21, 3, 22, 3, 22, 3, 23, 3, 23, 3, 24, 3,
-	24, 3, 25, 3, 25, 6, 25, 302, 10, 25, 13, 25, 14, 25, 303, 3, 26, 3, 26,
-	3, 27, 3, 27, 3, 27, 2, 2, 28, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22,
-	24, 26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 2, 6, 3, 2,
-	11, 12, 4, 2, 30, 30, 35, 35, 5, 2, 24, 24, 26, 26, 47, 51, 4, 2, 24, 29,
-	31, 34, 2, 327, 2, 59, 3, 2, 2, 2, 4, 72, 3, 2, 2, 2, 6, 77, 3, 2, 2, 2,
-	8, 113, 3, 2, 2, 2, 10, 149, 3, 2, 2, 2, 12, 161, 3, 2, 2, 2, 14, 173,
-	3, 2, 2, 2, 16, 185, 3, 2, 2, 2, 18, 193, 3, 2, 2, 2, 20, 198, 3, 2, 2,
-	2, 22, 200, 3, 2, 2, 2, 24, 208, 3, 2, 2, 2, 26, 249, 3, 2, 2, 2, 28, 251,
-	3, 2, 2, 2, 30, 267, 3, 2, 2, 2, 32, 283, 3, 2, 2, 2, 34, 285, 3, 2, 2,
-	2, 36, 287, 3, 2, 2, 2, 38, 289, 3, 2, 2, 2, 40, 291, 3, 2, 2, 2, 42, 293,
-	3, 2, 2, 2, 44, 295, 3, 2, 2, 2, 46, 297, 3, 2, 2, 2, 48, 301, 3, 2, 2,
-	2, 50, 305, 3, 2, 2, 2, 52, 307, 3, 2, 2, 2, 54, 60, 5, 6, 4, 2, 55, 60,
-	5, 10, 6, 2, 56, 60, 5, 14, 8, 2, 57, 60, 5, 16, 9, 2, 58, 60, 5, 18, 10,
-	2, 59, 54, 3, 2, 2, 2, 59, 55, 3, 2, 2, 2, 59, 56, 3, 2, 2, 2, 59, 57,
-	3, 2, 2, 2, 59, 58, 3, 2, 2, 2, 60, 61, 3, 2, 2, 2, 61, 59, 3, 2, 2, 2,
-	61, 62, 3, 2, 2, 2, 62, 63, 3, 2, 2, 2, 63, 64, 7, 2, 2, 3, 64, 3, 3, 2,
-	2, 2, 65, 71, 5, 8, 5, 2, 66, 71, 5, 12, 7, 2, 67, 71, 5, 14, 8, 2, 68,
-	71, 5, 16, 9, 2, 69, 71, 5, 18, 10, 2, 70, 65, 3, 2, 2, 2, 70, 66, 3, 2,
-	2, 2, 70, 67, 3, 2, 2, 2, 70, 68, 3, 2, 2, 2, 70, 69, 3, 2, 2, 2, 71, 74,
-	3, 2, 2, 2, 72, 70, 3, 2, 2, 2, 72, 73, 3, 2, 2, 2, 73, 75, 3, 2, 2, 2,
-	74, 72, 3, 2, 2, 2, 75, 76, 7, 2, 2, 3, 76, 5, 3, 2, 2, 2, 77, 78, 7, 42,
-	2, 2, 78, 79, 7, 3, 2, 2, 79, 80, 7, 43, 2, 2, 80, 81, 5, 48, 25, 2, 81,
-	82, 7, 10, 2, 2, 82, 83, 7, 43, 2, 2, 83, 84, 5, 48, 25, 2, 84, 85, 7,
-	9, 2, 2, 85, 86, 7, 43, 2, 2, 86, 110, 5, 20, 11, 2, 87, 88, 9, 2, 2, 2,
-	88, 89, 7, 43, 2, 2, 89, 109, 5, 48, 25, 2, 90, 91, 7, 13, 2, 2, 91, 92,
-	7, 43, 2, 2, 92, 109, 5, 34, 18, 2, 93, 94, 7, 14, 2, 2, 94, 95, 7, 43,
-	2, 2, 95, 109, 5, 30, 16, 2, 96, 97, 7, 15, 2, 2, 97, 98, 7, 43, 2, 2,
-	98, 109, 5, 32, 17, 2, 99, 100, 7, 16, 2, 2, 100, 101, 7, 43, 2, 2, 101,
-	109, 5, 36, 19, 2, 102, 103, 7, 17, 2, 2, 103, 104, 7, 43, 2, 2, 104, 109,
-	5, 38, 20, 2, 105, 106, 7, 18, 2, 2, 106, 107, 7, 43, 2, 2, 107, 109, 5,
-	40, 21, 2, 108, 87, 3, 2, 2, 2, 108, 90, 3, 2, 2, 2, 108, 93, 3, 2, 2,
-	2, 108, 96, 3, 2, 2, 2, 108, 99, 3, 2, 2, 2, 108, 102, 3, 2, 2, 2, 108,
-	105, 3, 2, 2, 2, 109, 112, 3, 2, 2, 2, 110, 108, 3, 2, 2, 2, 110, 111,
-	3, 2, 2, 2, 111, 7, 3, 2, 2, 2, 112, 110, 3, 2, 2, 2, 113, 114, 7, 42,
-	2, 2, 114, 115, 7, 3, 2, 2, 115, 116, 7, 43, 2, 2, 116, 117, 5, 48, 25,
-	2, 117, 118, 7, 10, 2, 2, 118, 119, 7, 43, 2, 2, 119, 120, 5, 48, 25, 2,
-	120, 121, 7, 9, 2, 2, 121, 122, 7, 43, 2, 2, 122, 146, 5, 20, 11, 2, 123,
-	124, 9, 2, 2, 2, 124, 125, 7, 43, 2, 2, 125, 145, 5, 48, 25, 2, 126, 127,
-	7, 13, 2, 2, 127, 128, 7, 43, 2, 2, 128, 145, 5, 34, 18, 2, 129, 130, 7,
-	14, 2, 2, 130, 131, 7, 43, 2, 2, 131, 145, 5, 30, 16, 2, 132, 133, 7, 15,
-	2, 2, 133, 134, 7, 43, 2, 2, 134, 145, 5, 32, 17, 2, 135, 136, 7, 16, 2,
-	2, 136, 137, 7, 43, 2, 2, 137, 145, 5, 36, 19, 2, 138, 139, 7, 17, 2, 2,
-	139, 140, 7, 43, 2, 2, 140, 145, 5, 38, 20, 2, 141, 142, 7, 18, 2, 2, 142,
-	143, 7, 43, 2, 2, 143, 145, 5, 40, 21, 2, 144, 123, 3, 2, 2, 2, 144, 126,
-	3, 2, 2, 2, 144, 129, 3, 2, 2, 2, 144, 132, 3, 2, 2, 2, 144, 135, 3, 2,
-	2, 2, 144, 138, 3, 2, 2, 2, 144, 141, 3, 2, 2, 2, 145, 148, 3, 2, 2, 2,
-	146, 144, 3, 2, 2, 2, 146, 147, 3, 2, 2, 2, 147, 9, 3, 2, 2, 2, 148, 146,
-	3, 2, 2, 2, 149, 150, 7, 42, 2, 2, 150, 151, 7, 4, 2, 2, 151, 152, 7, 43,
-	2, 2, 152, 153, 7, 47, 2, 2, 153, 154, 7, 9, 2, 2, 154, 155, 7, 43, 2,
-	2, 155, 159, 5, 20, 11, 2, 156, 157, 7, 16, 2, 2, 157, 158, 7, 43, 2, 2,
-	158, 160, 5, 36, 19, 2, 159, 156, 3, 2, 2, 2, 159, 160, 3, 2, 2, 2, 160,
-	11, 3, 2, 2, 2, 161, 162, 7, 42, 2, 2, 162, 163, 7, 4, 2, 2, 163, 164,
-	7, 43, 2, 2, 164, 165, 7, 47, 2, 2, 165, 166, 7, 9, 2, 2, 166, 167, 7,
-	43, 2, 2, 167, 171, 5, 20, 11, 2, 168, 169, 7, 16, 2, 2, 169, 170, 7, 43,
-	2, 2, 170, 172, 5, 36, 19, 2, 171, 168, 3, 2, 2, 2, 171, 172, 3, 2, 2,
-	2, 172, 13, 3, 2, 2, 2, 173, 174, 7, 42, 2, 2, 174, 175, 7, 5, 2, 2, 175,
-	176, 7, 43, 2, 2, 176, 177, 7, 47, 2, 2, 177, 178, 7, 9, 2, 2, 178, 179,
-	7, 43, 2, 2, 179, 183, 5, 20, 11, 2, 180, 181, 7, 19, 2, 2, 181, 182, 7,
-	43, 2, 2, 182, 184, 5, 42, 22, 2, 183, 180, 3, 2, 2, 2, 183, 184, 3, 2,
-	2, 2, 184, 15, 3, 2, 2, 2, 185, 186, 7, 42, 2, 2, 186, 187, 7, 6, 2, 2,
-	187, 188, 7, 43, 2, 2, 188, 189, 7, 47, 2, 2, 189, 190, 7, 8, 2, 2, 190,
-	191, 7, 43, 2, 2, 191, 192, 5, 28, 15, 2, 192, 17, 3, 2, 2, 2, 193, 194,
-	7, 42, 2, 2, 194, 195, 7, 20, 2, 2, 195, 196, 7, 43, 2, 2, 196, 197, 5,
-	46, 24, 2, 197, 19, 3, 2, 2, 2, 198, 199, 5, 22, 12, 2, 199, 21, 3, 2,
-	2, 2, 200, 205, 5, 24, 13, 2, 201, 202, 7, 22, 2, 2, 202, 204, 5, 24, 13,
-	2, 203, 201, 3, 2, 2, 2, 204, 207, 3, 2, 2, 2, 205, 203, 3, 2, 2, 2, 205,
-	206, 3, 2, 2, 2, 206, 23, 3, 2, 2, 2, 207, 205, 3, 2, 2, 2, 208, 213, 5,
-	26, 14, 2, 209, 210, 7, 21, 2, 2, 210, 212, 5, 26, 14, 2, 211, 209, 3,
-	2, 2, 2, 212, 215, 3, 2, 2, 2, 213, 211, 3, 2, 2, 2, 213, 214, 3, 2, 2,
-	2, 214, 25, 3, 2, 2, 2, 215, 213, 3, 2, 2, 2, 216, 250, 5, 44, 23, 2, 217,
-	218, 7, 23, 2, 2, 218, 250, 5, 26, 14, 2, 219, 220, 5, 46, 24, 2, 220,
-	221, 5, 52, 27, 2, 221, 250, 3, 2, 2, 2, 222, 223, 5, 46, 24, 2, 223, 224,
-	5, 50, 26, 2, 224, 225, 5, 46, 24, 2, 225, 250, 3, 2, 2, 2, 226, 227, 5,
-	46, 24, 2, 227, 228, 9, 3, 2, 2, 228, 231, 7, 39, 2, 2, 229, 232, 5, 46,
-	24, 2, 230, 232, 5, 28, 15, 2, 231, 229, 3, 2, 2, 2, 231, 230, 3, 2, 2,
-	2, 232, 240, 3, 2, 2, 2, 233, 236, 7, 41, 2, 2, 234, 237, 5, 46, 24, 2,
-	235, 237, 5, 28, 15, 2, 236, 234, 3, 2, 2, 2, 236, 235, 3, 2, 2, 2, 237,
-	239, 3, 2, 2, 2, 238, 233, 3, 2, 2, 2, 239, 242, 3, 2, 2, 2, 240, 238,
-	3, 2, 2, 2, 240, 241, 3, 2, 2, 2, 241, 243, 3, 2, 2, 2, 242, 240, 3, 2,
-	2, 2, 243, 244, 7, 40, 2, 2, 244, 250, 3, 2, 2, 2, 245, 246, 7, 39, 2,
-	2, 246, 247, 5, 20, 11, 2, 247, 248, 7, 40, 2, 2, 248, 250, 3, 2, 2, 2,
-	249, 216, 3, 2, 2, 2, 249, 217, 3, 2, 2, 2, 249, 219, 3, 2, 2, 2, 249,
-	222, 3, 2, 2, 2, 249, 226, 3, 2, 2, 2, 249, 245, 3, 2, 2, 2, 250, 27, 3,
-	2, 2, 2, 251, 260, 7, 37, 2, 2, 252, 257, 5, 46, 24, 2, 253, 254, 7, 41,
-	2, 2, 254, 256, 5, 46, 24, 2, 255, 253, 3, 2, 2, 2, 256, 259, 3, 2, 2,
-	2, 257, 255, 3, 2, 2, 2, 257, 258, 3, 2, 2, 2, 258, 261, 3, 2, 2, 2, 259,
-	257, 3, 2, 2, 2, 260, 252, 3, 2, 2, 2, 260, 261, 3, 2, 2, 2, 261, 263,
-	3, 2, 2, 2, 262, 264, 7, 41, 2, 2, 263, 262, 3, 2, 2, 2, 263, 264, 3, 2,
-	2, 2, 264, 265, 3, 2, 2, 2, 265, 266, 7, 38, 2, 2, 266, 29, 3, 2, 2, 2,
-	267, 276, 7, 37, 2, 2, 268, 273, 5, 46, 24, 2, 269, 270, 7, 41, 2, 2, 270,
-	272, 5, 46, 24, 2, 271, 269, 3, 2, 2, 2, 272, 275, 3, 2, 2, 2, 273, 271,
-	3, 2, 2, 2, 273, 274, 3, 2, 2, 2, 274, 277, 3, 2, 2, 2, 275, 273, 3, 2,
-	2, 2, 276, 268, 3, 2, 2, 2, 276, 277, 3, 2, 2, 2, 277, 279, 3, 2, 2, 2,
-	278, 280, 7, 41, 2, 2, 279, 278, 3, 2, 2, 2, 279, 280, 3, 2, 2, 2, 280,
-	281, 3, 2, 2, 2, 281, 282, 7, 38, 2, 2, 282, 31, 3, 2, 2, 2, 283, 284,
-	5, 28, 15, 2, 284, 33, 3, 2, 2, 2, 285, 286, 7, 44, 2, 2, 286, 35, 3, 2,
-	2, 2, 287, 288, 5, 46, 24, 2, 288, 37, 3, 2, 2, 2, 289, 290, 5, 46, 24,
-	2, 290, 39, 3, 2, 2, 2, 291, 292, 5, 46, 24, 2, 292, 41, 3, 2, 2, 2, 293,
-	294, 5, 46, 24, 2, 294, 43, 3, 2, 2, 2, 295, 296, 7, 47, 2, 2, 296, 45,
-	3, 2, 2, 2, 297, 298, 9, 4, 2, 2, 298, 47, 3, 2, 2, 2, 299, 300, 6, 25,
-	2, 2, 300, 302, 11, 2, 2, 2, 301, 299, 3, 2, 2, 2, 302, 303, 3, 2, 2, 2,
-	303, 301, 3, 2, 2, 2, 303, 304, 3, 2, 2, 2, 304, 49, 3, 2, 2, 2, 305, 306,
-	9, 5, 2, 2, 306, 51, 3, 2, 2, 2, 307, 308, 7, 36, 2, 2, 308, 53, 3, 2,
-	2, 2, 26, 59, 61, 70, 72, 108, 110, 144, 146, 159, 171, 183, 205, 213,
-	231, 236, 240, 249, 257, 260, 263, 273, 276, 279, 303,
+	4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3,
+	4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 7, 4, 144, 10,
+	4, 12, 4, 14, 4, 147, 11, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5,
+	3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5,
+	3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5,
+	3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 7, 5, 189, 10, 5,
+	12, 5, 14, 5, 192, 11, 5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3,
+	6, 3, 6, 3, 6, 5, 6, 204, 10, 6, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3,
+	7, 3, 7, 3, 7, 3, 7, 5, 7, 216, 10, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3,
+	8, 3, 8, 5, 8, 225, 10, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 5, 8, 233,
+	10, 8, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 5, 9, 242, 10, 9, 3, 9,
+	3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 5, 9, 250, 10, 9, 3, 10, 3, 10, 3, 10, 3,
+	10, 3, 10, 3, 10, 3, 10, 5, 10, 259, 10, 10, 3, 10, 3, 10, 3, 10, 3, 10,
+	5, 10, 265, 10, 10, 3, 10, 3, 10, 3, 10, 5, 10, 270, 10, 10, 3, 11, 3,
+	11, 3, 11, 3, 11, 3, 11, 3, 11, 3, 11, 5, 11, 279, 10, 11, 3, 11, 3, 11,
+	3, 11, 3, 11, 5, 11, 285, 10, 11, 3, 11, 3, 11, 3, 11, 5, 11, 290, 10,
+	11, 3, 12, 3, 12, 3, 12, 3, 12, 3, 12, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13,
+	3, 13, 3, 13, 3, 13, 3, 13, 6, 13, 306, 10, 13, 13, 13, 14, 13, 307, 3,
+	14, 5, 14, 311, 10, 14, 3, 14, 3, 14, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16,
+	7, 16, 320, 10, 16, 12, 16, 14, 16, 323, 11, 16, 3, 17, 3, 17, 3, 17, 7,
+	17, 328, 10, 17, 12, 17, 14, 17, 331, 11, 17, 3, 18, 3, 18, 3, 18, 3, 18,
+	3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3,
+	18, 5, 18, 348, 10, 18, 3, 18, 3, 18, 3, 18, 5, 18, 353, 10, 18, 7, 18,
+	355, 10, 18, 12, 18, 14, 18, 358, 11, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3,
+	18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 5, 18, 371, 10, 18, 3, 19,
+	3, 19, 3, 19, 3, 19, 7, 19, 377, 10, 19, 12, 19, 14, 19, 380, 11, 19, 5,
+	19, 382, 10, 19, 3, 19, 5, 19, 385, 10, 19, 3, 19, 3, 19, 3, 20, 3, 20,
+	3, 20, 3, 20, 7, 20, 393, 10, 20, 12, 20, 14, 20, 396, 11, 20, 5, 20, 398,
+	10, 20, 3, 20, 5, 20, 401, 10, 20, 3, 20, 3, 20, 3, 21, 3, 21, 3, 22, 7,
+	22, 408, 10, 22, 12, 22, 14, 22, 411, 11, 22, 3, 22, 3, 22, 5, 22, 415,
+	10, 22, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23,
+	3, 23, 3, 23, 3, 23, 3, 23, 7, 23, 430, 10, 23, 12, 23, 14, 23, 433, 11,
+	23, 3, 24, 3, 24, 5, 24, 437, 10, 24, 3, 25, 3, 25, 3, 25, 3, 25, 7, 25,
+	443, 10, 25, 12, 25, 14, 25, 446, 11, 25, 5, 25, 448, 10, 25, 3, 25, 5,
+	25, 451, 10, 25, 3, 25, 3, 25, 5, 25, 455, 10, 25, 3, 26, 3, 26, 3, 26,
+	3, 26, 5, 26, 461, 10, 26, 3, 27, 3, 27, 3, 27, 3, 27, 7, 27, 467, 10,
+	27, 12, 27, 14, 27, 470, 11, 27, 5, 27, 472, 10, 27, 3, 27, 5, 27, 475,
+	10, 27, 3, 27, 3, 27, 3, 27, 6, 27, 480, 10, 27, 13, 27, 14, 27, 481, 5,
+	27, 484, 10, 27, 3, 28, 3, 28, 3, 28, 3, 28, 7, 28, 490, 10, 28, 12, 28,
+	14, 28, 493, 11, 28, 5, 28, 495, 10, 28, 3, 28, 5, 28, 498, 10, 28, 3,
+	28, 3, 28, 5, 28, 502, 10, 28, 3, 29, 3, 29, 3, 30, 3, 30, 3, 31, 3, 31,
+	3, 32, 3, 32, 3, 33, 3, 33, 3, 34, 3, 34, 3, 35, 3, 35, 3, 36, 3, 36, 3,
+	36, 3, 36, 3, 36, 3, 36, 3, 36, 3, 36, 3, 36, 3, 36, 5, 36, 528, 10, 36,
+	3, 37, 3, 37, 3, 38, 3, 38, 6, 38, 534, 10, 38, 13, 38, 14, 38, 535, 3,
+	39, 3, 39, 3, 40, 3, 40, 3, 40, 2, 2, 41, 2, 4, 6, 8, 10, 12, 14, 16, 18,
+	20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54,
+	56, 58, 60, 62, 64, 66, 68, 70, 72, 74, 76, 78, 2, 7, 3, 2, 11, 12, 3,
+	2, 29, 30, 4, 2, 38, 38, 43, 44, 3, 2, 21, 28, 4, 2, 32, 37, 39, 42, 2,
+	601, 2, 85, 3, 2, 2, 2, 4, 98, 3, 2, 2, 2, 6, 103, 3, 2, 2, 2, 8, 148,
+	3, 2, 2, 2, 10, 193, 3, 2, 2, 2, 12, 205, 3, 2, 2, 2, 14, 217, 3, 2, 2,
+	2, 16, 234, 3, 2, 2, 2, 18, 251, 3, 2, 2, 2, 20, 271, 3, 2, 2, 2, 22, 291,
+	3, 2, 2, 2, 24, 305, 3, 2, 2, 2, 26, 310, 3, 2, 2, 2, 28, 314, 3, 2, 2,
+	2, 30, 316, 3, 2, 2, 2, 32, 324, 3, 2, 2, 2, 34, 370, 3, 2, 2, 2, 36, 372,
+	3, 2, 2, 2, 38, 388, 3, 2, 2, 2, 40, 404, 3, 2, 2, 2, 42, 414, 3, 2, 2,
+	2, 44, 416, 3, 2, 2, 2, 46, 436, 3, 2, 2, 2, 48, 454, 3, 2, 2, 2, 50, 460,
+	3, 2, 2, 2, 52, 483, 3, 2, 2, 2, 54, 501, 3, 2, 2, 2, 56, 503, 3, 2, 2,
+	2, 58, 505, 3, 2, 2, 2, 60, 507, 3, 2, 2, 2, 62, 509, 3, 2, 2, 2, 64, 511,
+	3, 2, 2, 2, 66, 513, 3, 2, 2, 2, 68, 515, 3, 2, 2, 2, 70, 527, 3, 2, 2,
+	2, 72, 529, 3, 2, 2, 2, 74, 533, 3, 2, 2, 2, 76, 537, 3, 2, 2, 2, 78, 539,
+	3, 2, 2, 2, 80, 86, 5, 6, 4, 2, 81, 86, 5, 10, 6, 2, 82, 86, 5, 16, 9,
+	2, 83, 86, 5, 20, 11, 2, 84, 86, 5, 22, 12, 2, 85, 80, 3, 2, 2, 2, 85,
+	81, 3, 2, 2, 2, 85, 82, 3, 2, 2, 2, 85, 83, 3, 2, 2, 2, 85, 84, 3, 2, 2,
+	2, 86, 87, 3, 2, 2, 2, 87, 85, 3, 2, 2, 2, 87, 88, 3, 2, 2, 2, 88, 89,
+	3, 2, 2, 2, 89, 90, 7, 2, 2, 3, 90, 3, 3, 2, 2, 2, 91, 97, 5, 8, 5, 2,
+	92, 97, 5, 12, 7, 2, 93, 97, 5, 14, 8, 2, 94, 97, 5, 18, 10, 2, 95, 97,
+	5, 22, 12, 2, 96, 91, 3, 2, 2, 2, 96, 92, 3, 2, 2, 2, 96, 93, 3, 2, 2,
+	2, 96, 94, 3, 2, 2, 2, 96, 95, 3, 2, 2, 2, 97, 100, 3, 2, 2, 2, 98, 96,
+	3, 2, 2, 2, 98, 99, 3, 2, 2, 2, 99, 101, 3, 2, 2, 2, 100, 98, 3, 2, 2,
+	2, 101, 102, 7, 2, 2, 3, 102, 5, 3, 2, 2, 2, 103, 104, 7, 52, 2, 2, 104,
+	105, 7, 3, 2, 2, 105, 106, 7, 53, 2, 2, 106, 145, 5, 74, 38, 2, 107, 108,
+	7, 10, 2, 2, 108, 109, 7, 53, 2, 2, 109, 144, 5, 74, 38, 2, 110, 111, 7,
+	9, 2, 2, 111, 112, 7, 53, 2, 2, 112, 144, 5, 26, 14, 2, 113, 114, 9, 2,
+	2, 2, 114, 115, 7, 53, 2, 2, 115, 144, 5, 74, 38, 2, 116, 117, 7, 13, 2,
+	2, 117, 118, 7, 53, 2, 2, 118, 144, 5, 56, 29, 2, 119, 120, 7, 14, 2, 2,
+	120, 121, 7, 53, 2, 2, 121, 144, 5, 38, 20, 2, 122, 123, 7, 15, 2, 2, 123,
+	124, 7, 53, 2, 2, 124, 144, 5, 40, 21, 2, 125, 126, 7, 16, 2, 2, 126, 127,
+	7, 53, 2, 2, 127, 144, 5, 58, 30, 2, 128, 129, 7, 17, 2, 2, 129, 130, 7,
+	53, 2, 2, 130, 144, 5, 60, 31, 2, 131, 132, 7, 18, 2, 2, 132, 133, 7, 53,
+	2, 2, 133, 144, 5, 62, 32, 2, 134, 135, 7, 21, 2, 2, 135, 136, 7, 53, 2,
+	2, 136, 144, 5, 42, 22, 2, 137, 138, 7, 28, 2, 2, 138, 139, 7, 53, 2, 2,
+	139, 144, 5, 64, 33, 2, 140, 141, 7, 19, 2, 2, 141, 142, 7, 53, 2, 2, 142,
+	144, 5, 66, 34, 2, 143, 107, 3, 2, 2, 2, 143, 110, 3, 2, 2, 2, 143, 113,
+	3, 2, 2, 2, 143, 116, 3, 2, 2, 2, 143, 119, 3, 2, 2, 2, 143, 122, 3, 2,
+	2, 2, 143, 125, 3, 2, 2, 2, 143, 128, 3, 2, 2, 2, 143, 131, 3, 2, 2, 2,
+	143, 134, 3, 2, 2, 2, 143, 137, 3, 2, 2, 2, 143, 140, 3, 2, 2, 2, 144,
+	147, 3, 2, 2, 2, 145, 143, 3, 2, 2, 2, 145, 146, 3, 2, 2, 2, 146, 7, 3,
+	2, 2, 2, 147, 145, 3, 2, 2, 2, 148, 149, 7, 52, 2, 2, 149, 150, 7, 3, 2,
+	2, 150, 151, 7, 53, 2, 2, 151, 190, 5, 74, 38, 2, 152, 153, 7, 10, 2, 2,
+	153, 154, 7, 53, 2, 2, 154, 189, 5, 74, 38, 2, 155, 156, 7, 9, 2, 2, 156,
+	157, 7, 53, 2, 2, 157, 189, 5, 26, 14, 2, 158, 159, 9, 2, 2, 2, 159, 160,
+	7, 53, 2, 2, 160, 189, 5, 74, 38, 2, 161, 162, 7, 13, 2, 2, 162, 163, 7,
+	53, 2, 2, 163, 189, 5, 56, 29, 2, 164, 165, 7, 14, 2, 2, 165, 166, 7, 53,
+	2, 2, 166, 189, 5, 38, 20, 2, 167, 168, 7, 15, 2, 2, 168, 169, 7, 53, 2,
+	2, 169, 189, 5, 40, 21, 2, 170, 171, 7, 16, 2, 2, 171, 172, 7, 53, 2, 2,
+	172, 189, 5, 58, 30, 2, 173, 174, 7, 17, 2, 2, 174, 175, 7, 53, 2, 2, 175,
+	189, 5, 60, 31, 2, 176, 177, 7, 18, 2, 2, 177, 178, 7, 53, 2, 2, 178, 189,
+	5, 62, 32, 2, 179, 180, 7, 21, 2, 2, 180, 181, 7, 53, 2, 2, 181, 189, 5,
+	42, 22, 2, 182, 183, 7, 28, 2, 2, 183, 184, 7, 53, 2, 2, 184, 189, 5, 64,
+	33, 2, 185, 186, 7, 19, 2, 2, 186, 187, 7, 53, 2, 2, 187, 189, 5, 66, 34,
+	2, 188, 152, 3, 2, 2, 2, 188, 155, 3, 2, 2, 2, 188, 158, 3, 2, 2, 2, 188,
+	161, 3, 2, 2, 2, 188, 164, 3, 2, 2, 2, 188, 167, 3, 2, 2, 2, 188, 170,
+	3, 2, 2, 2, 188, 173, 3, 2, 2, 2, 188, 176, 3, 2, 2, 2, 188, 179, 3, 2,
+	2, 2, 188, 182, 3, 2, 2, 2, 188, 185, 3, 2, 2, 2, 189, 192, 3, 2, 2, 2,
+	190, 188, 3, 2, 2, 2, 190, 191, 3, 2, 2, 2, 191, 9, 3, 2, 2, 2, 192, 190,
+	3, 2, 2, 2, 193, 194, 7, 52, 2, 2, 194, 195, 7, 4, 2, 2, 195, 196, 7, 53,
+	2, 2, 196, 197, 7, 58, 2, 2, 197, 198, 7, 9, 2, 2, 198, 199, 7, 53, 2,
+	2, 199, 203, 5, 28, 15, 2, 200, 201, 7, 16, 2, 2, 201, 202, 7, 53, 2, 2,
+	202, 204, 5, 58, 30, 2, 203, 200, 3, 2, 2, 2, 203, 204, 3, 2, 2, 2, 204,
+	11, 3, 2, 2, 2, 205, 206, 7, 52, 2, 2, 206, 207, 7, 4, 2, 2, 207, 208,
+	7, 53, 2, 2, 208, 209, 7, 58, 2, 2, 209, 210, 7, 9, 2, 2, 210, 211, 7,
+	53, 2, 2, 211, 215, 5, 28, 15, 2, 212, 213, 7, 16, 2, 2, 213, 214, 7, 53,
+	2, 2, 214, 216, 5, 58, 30, 2, 215, 212, 3, 2, 2, 2, 215, 216, 3, 2, 2,
+	2, 216, 13, 3, 2, 2, 2, 217, 218, 7, 52, 2, 2, 218, 219, 7, 5, 2, 2, 219,
+	220, 7, 53, 2, 2, 220, 224, 7, 58, 2, 2, 221, 222, 7, 19, 2, 2, 222, 223,
+	7, 53, 2, 2, 223, 225, 5, 66, 34, 2, 224, 221, 3, 2, 2, 2, 224, 225, 3,
+	2, 2, 2, 225, 226, 3, 2, 2, 2, 226, 227, 7, 9, 2, 2, 227, 228, 7, 53, 2,
+	2, 228, 232, 5, 26, 14, 2, 229, 230, 7, 19, 2, 2, 230, 231, 7, 53, 2, 2,
+	231, 233, 5, 66, 34, 2, 232, 229, 3, 2, 2, 2, 232, 233, 3, 2, 2, 2, 233,
+	15, 3, 2, 2, 2, 234, 235, 7, 52, 2, 2, 235, 236, 7, 5, 2, 2, 236, 237,
+	7, 53, 2, 2, 237, 241, 7, 58, 2, 2, 238, 239, 7, 19, 2, 2, 239, 240, 7,
+	53, 2, 2, 240, 242, 5, 66, 34, 2, 241, 238, 3, 2, 2, 2, 241, 242, 3, 2,
+	2, 2, 242, 243, 3, 2, 2, 2, 243, 244, 7, 9, 2, 2, 244, 245, 7, 53, 2, 2,
+	245, 249, 5, 26, 14, 2, 246, 247, 7, 19, 2, 2, 247, 248, 7, 53, 2, 2, 248,
+	250, 5, 66, 34, 2, 249, 246, 3, 2, 2, 2, 249, 250, 3, 2, 2, 2, 250, 17,
+	3, 2, 2, 2, 251, 252, 7, 52, 2, 2, 252, 253, 7, 6, 2, 2, 253, 254, 7, 53,
+	2, 2, 254, 258, 7, 58, 2, 2, 255, 256, 7, 19, 2, 2, 256, 257, 7, 53, 2,
+	2, 257, 259, 5, 66, 34, 2, 258, 255, 3, 2, 2, 2, 258, 259, 3, 2, 2, 2,
+	259, 264, 3, 2, 2, 2, 260, 261, 7, 8, 2, 2, 261, 262, 7, 53, 2, 2, 262,
+	265, 5, 36, 19, 2, 263, 265, 5, 24, 13, 2, 264, 260, 3, 2, 2, 2, 264, 263,
+	3, 2, 2, 2, 265, 269, 3, 2, 2, 2, 266, 267, 7, 19, 2, 2, 267, 268, 7, 53,
+	2, 2, 268, 270, 5, 66, 34, 2, 269, 266, 3, 2, 2, 2, 269, 270, 3, 2, 2,
+	2, 270, 19, 3, 2, 2, 2, 271, 272, 7, 52, 2, 2, 272, 273, 7, 6, 2, 2, 273,
+	274, 7, 53, 2, 2, 274, 278, 7, 58, 2, 2, 275, 276, 7, 19, 2, 2, 276, 277,
+	7, 53, 2, 2, 277, 279, 5, 66, 34, 2, 278, 275, 3, 2, 2, 2, 278, 279, 3,
+	2, 2, 2, 279, 284, 3, 2, 2, 2, 280, 281, 7, 8, 2, 2, 281, 282, 7, 53, 2,
+	2, 282, 285, 5, 36, 19, 2, 283, 285, 5, 24, 13, 2, 284, 280, 3, 2, 2, 2,
+	284, 283, 3, 2, 2, 2, 285, 289, 3, 2, 2, 2, 286, 287, 7, 19, 2, 2, 287,
+	288, 7, 53, 2, 2, 288, 290, 5, 66, 34, 2, 289, 286, 3, 2, 2, 2, 289, 290,
+	3, 2, 2, 2, 290, 21, 3, 2, 2, 2, 291, 292, 7, 52, 2, 2, 292, 293, 7, 20,
+	2, 2, 293, 294, 7, 53, 2, 2, 294, 295, 5, 70, 36, 2, 295, 23, 3, 2, 2,
+	2, 296, 297, 7, 25, 2, 2, 297, 298, 7, 53, 2, 2, 298, 306, 5, 70, 36, 2,
+	299, 300, 7, 26, 2, 2, 300, 301, 7, 53, 2, 2, 301, 306, 5, 70, 36, 2, 302,
+	303, 7, 27, 2, 2, 303, 304, 7, 53, 2, 2, 304, 306, 5, 70, 36, 2, 305, 296,
+	3, 2, 2, 2, 305, 299, 3, 2, 2, 2, 305, 302, 3, 2, 2, 2, 306, 307, 3, 2,
+	2, 2, 307, 305, 3, 2, 2, 2, 307, 308, 3, 2, 2, 2, 308, 25, 3, 2, 2, 2,
+	309, 311, 9, 3, 2, 2, 310, 309, 3, 2, 2, 2, 310, 311, 3, 2, 2, 2, 311,
+	312, 3, 2, 2, 2, 312, 313, 5, 28, 15, 2, 313, 27, 3, 2, 2, 2, 314, 315,
+	5, 30, 16, 2, 315, 29, 3, 2, 2, 2, 316, 321, 5, 32, 17, 2, 317, 318, 7,
+	30, 2, 2, 318, 320, 5, 32, 17, 2, 319, 317, 3, 2, 2, 2, 320, 323, 3, 2,
+	2, 2, 321, 319, 3, 2, 2, 2, 321, 322, 3, 2, 2, 2, 322, 31, 3, 2, 2, 2,
+	323, 321, 3, 2, 2, 2, 324, 329, 5, 34, 18, 2, 325, 326, 7, 29, 2, 2, 326,
+	328, 5, 34, 18, 2, 327, 325, 3, 2, 2, 2, 328, 331, 3, 2, 2, 2, 329, 327,
+	3, 2, 2, 2, 329, 330, 3, 2, 2, 2, 330, 33, 3, 2, 2, 2, 331, 329, 3, 2,
+	2, 2, 332, 371, 5, 68, 35, 2, 333, 334, 7, 31, 2, 2, 334, 371, 5, 34, 18,
+	2, 335, 336, 5, 70, 36, 2, 336, 337, 5, 78, 40, 2, 337, 371, 3, 2, 2, 2,
+	338, 339, 5, 70, 36, 2, 339, 340, 5, 76, 39, 2, 340, 341, 5, 70, 36, 2,
+	341, 371, 3, 2, 2, 2, 342, 343, 5, 70, 36, 2, 343, 344, 9, 4, 2, 2, 344,
+	347, 7, 49, 2, 2, 345, 348, 5, 70, 36, 2, 346, 348, 5, 36, 19, 2, 347,
+	345, 3, 2, 2, 2, 347, 346, 3, 2, 2, 2, 348, 356, 3, 2, 2, 2, 349, 352,
+	7, 51, 2, 2, 350, 353, 5, 70, 36, 2, 351, 353, 5, 36, 19, 2, 352, 350,
+	3, 2, 2, 2, 352, 351, 3, 2, 2, 2, 353, 355, 3, 2, 2, 2, 354, 349, 3, 2,
+	2, 2, 355, 358, 3, 2, 2, 2, 356, 354, 3, 2, 2, 2, 356, 357, 3, 2, 2, 2,
+	357, 359, 3, 2, 2, 2, 358, 356, 3, 2, 2, 2, 359, 360, 7, 50, 2, 2, 360,
+	371, 3, 2, 2, 2, 361, 362, 7, 46, 2, 2, 362, 363, 7, 49, 2, 2, 363, 364,
+	5, 28, 15, 2, 364, 365, 7, 50, 2, 2, 365, 371, 3, 2, 2, 2, 366, 367, 7,
+	49, 2, 2, 367, 368, 5, 28, 15, 2, 368, 369, 7, 50, 2, 2, 369, 371, 3, 2,
+	2, 2, 370, 332, 3, 2, 2, 2, 370, 333, 3, 2, 2, 2, 370, 335, 3, 2, 2, 2,
+	370, 338, 3, 2, 2, 2, 370, 342, 3, 2, 2, 2, 370, 361, 3, 2, 2, 2, 370,
+	366, 3, 2, 2, 2, 371, 35, 3, 2, 2, 2, 372, 381, 7, 47, 2, 2, 373, 378,
+	5, 70, 36, 2, 374, 375, 7, 51, 2, 2, 375, 377, 5, 70, 36, 2, 376, 374,
+	3, 2, 2, 2, 377, 380, 3, 2, 2, 2, 378, 376, 3, 2, 2, 2, 378, 379, 3, 2,
+	2, 2, 379, 382, 3, 2, 2, 2, 380, 378, 3, 2, 2, 2, 381, 373, 3, 2, 2, 2,
+	381, 382, 3, 2, 2, 2, 382, 384, 3, 2, 2, 2, 383, 385, 7, 51, 2, 2, 384,
+	383, 3, 2, 2, 2, 384, 385, 3, 2, 2, 2, 385, 386, 3, 2, 2, 2, 386, 387,
+	7, 48, 2, 2, 387, 37, 3, 2, 2, 2, 388, 397, 7, 47, 2, 2, 389, 394, 5, 70,
+	36, 2, 390, 391, 7, 51, 2, 2, 391, 393, 5, 70, 36, 2, 392, 390, 3, 2, 2,
+	2, 393, 396, 3, 2, 2, 2, 394, 392, 3, 2, 2, 2, 394, 395, 3, 2, 2, 2, 395,
+	398, 3, 2, 2, 2, 396, 394, 3, 2, 2, 2, 397, 389, 3, 2, 2, 2, 397, 398,
+	3, 2, 2, 2, 398, 400, 3, 2, 2, 2, 399, 401, 7, 51, 2, 2, 400, 399, 3, 2,
+	2, 2, 400, 401, 3, 2, 2, 2, 401, 402, 3, 2, 2, 2, 402, 403, 7, 48, 2, 2,
+	403, 39, 3, 2, 2, 2, 404, 405, 5, 36, 19, 2, 405, 41, 3, 2, 2, 2, 406,
+	408, 5, 44, 23, 2, 407, 406, 3, 2, 2, 2, 408, 411, 3, 2, 2, 2, 409, 407,
+	3, 2, 2, 2, 409, 410, 3, 2, 2, 2, 410, 415, 3, 2, 2, 2, 411, 409, 3, 2,
+	2, 2, 412, 413, 7, 47, 2, 2, 413, 415, 7, 48, 2, 2, 414, 409, 3, 2, 2,
+	2, 414, 412, 3, 2, 2, 2, 415, 43, 3, 2, 2, 2, 416, 417, 7, 52, 2, 2, 417,
+	418, 7, 7, 2, 2, 418, 419, 7, 53, 2, 2, 419, 431, 7, 58, 2, 2, 420, 421,
+	7, 22, 2, 2, 421, 422, 7, 53, 2, 2, 422, 430, 5, 46, 24, 2, 423, 424, 7,
+	23, 2, 2, 424, 425, 7, 53, 2, 2, 425, 430, 5, 48, 25, 2, 426, 427, 7, 24,
+	2, 2, 427, 428, 7, 53, 2, 2, 428, 430, 5, 52, 27, 2, 429, 420, 3, 2, 2,
+	2, 429, 423, 3, 2, 2, 2, 429, 426, 3, 2, 2, 2, 430, 433, 3, 2, 2, 2, 431,
+	429, 3, 2, 2, 2, 431, 432, 3, 2, 2, 2, 432, 45, 3, 2, 2, 2, 433, 431, 3,
+	2, 2, 2, 434, 437, 5, 36, 19, 2, 435, 437, 5, 70, 36, 2, 436, 434, 3, 2,
+	2, 2, 436, 435, 3, 2, 2, 2, 437, 47, 3, 2, 2, 2, 438, 447, 7, 47, 2, 2,
+	439, 444, 5, 50, 26, 2, 440, 441, 7, 51, 2, 2, 441, 443, 5, 50, 26, 2,
+	442, 440, 3, 2, 2, 2, 443, 446, 3, 2, 2, 2, 444, 442, 3, 2, 2, 2, 444,
+	445, 3, 2, 2, 2, 445, 448, 3, 2, 2, 2, 446, 444, 3, 2, 2, 2, 447, 439,
+	3, 2, 2, 2, 447, 448, 3, 2, 2, 2, 448, 450, 3, 2, 2, 2, 449, 451, 7, 51,
+	2, 2, 450, 449, 3, 2, 2, 2, 450, 451, 3, 2, 2, 2, 451, 452, 3, 2, 2, 2,
+	452, 455, 7, 48, 2, 2, 453, 455, 5, 50, 26, 2, 454, 438, 3, 2, 2, 2, 454,
+	453, 3, 2, 2, 2, 455, 49, 3, 2, 2, 2, 456, 461, 5, 76, 39, 2, 457, 461,
+	7, 38, 2, 2, 458, 461, 7, 43, 2, 2, 459, 461, 7, 44, 2, 2, 460, 456, 3,
+	2, 2, 2, 460, 457, 3, 2, 2, 2, 460, 458, 3, 2, 2, 2, 460, 459, 3, 2, 2,
+	2, 461, 51, 3, 2, 2, 2, 462, 471, 7, 47, 2, 2, 463, 468, 5, 54, 28, 2,
+	464, 465, 7, 51, 2, 2, 465, 467, 5, 54, 28, 2, 466, 464, 3, 2, 2, 2, 467,
+	470, 3, 2, 2, 2, 468, 466, 3, 2, 2, 2, 468, 469, 3, 2, 2, 2, 469, 472,
+	3, 2, 2, 2, 470, 468, 3, 2, 2, 2, 471, 463, 3, 2, 2, 2, 471, 472, 3, 2,
+	2, 2, 472, 474, 3, 2, 2, 2, 473, 475, 7, 51, 2, 2, 474, 473, 3, 2, 2, 2,
+	474, 475, 3, 2, 2, 2, 475, 476, 3, 2, 2, 2, 476, 484, 7, 48, 2, 2, 477,
+	478, 7, 52, 2, 2, 478, 480, 5, 54, 28, 2, 479, 477, 3, 2, 2, 2, 480, 481,
+	3, 2, 2, 2, 481, 479, 3, 2, 2, 2, 481, 482, 3, 2, 2, 2, 482, 484, 3, 2,
+	2, 2, 483, 462, 3, 2, 2, 2, 483, 479, 3, 2, 2, 2, 484, 53, 3, 2, 2, 2,
+	485, 494, 7, 47, 2, 2, 486, 491, 5, 54, 28, 2, 487, 488, 7, 51, 2, 2, 488,
+	490, 5, 54, 28, 2, 489, 487, 3, 2, 2, 2, 490, 493, 3, 2, 2, 2, 491, 489,
+	3, 2, 2, 2, 491, 492, 3, 2, 2, 2, 492, 495, 3, 2, 2, 2, 493, 491, 3, 2,
+	2, 2, 494, 486, 3, 2, 2, 2, 494, 495, 3, 2, 2, 2, 495, 497, 3, 2, 2, 2,
+	496, 498, 7, 51, 2, 2, 497, 496, 3, 2, 2, 2, 497, 498, 3, 2, 2, 2, 498,
+	499, 3, 2, 2, 2, 499, 502, 7, 48, 2, 2, 500, 502, 5, 70, 36, 2, 501, 485,
+	3, 2, 2, 2, 501, 500, 3, 2, 2, 2, 502, 55, 3, 2, 2, 2, 503, 504, 7, 54,
+	2, 2, 504, 57, 3, 2, 2, 2, 505, 506, 5, 70, 36, 2, 506, 59, 3, 2, 2, 2,
+	507, 508, 5, 70, 36, 2, 508, 61, 3, 2, 2, 2, 509, 510, 5, 70, 36, 2, 510,
+	63, 3, 2, 2, 2, 511, 512, 5, 70, 36, 2, 512, 65, 3, 2, 2, 2, 513, 514,
+	5, 70, 36, 2, 514, 67, 3, 2, 2, 2, 515, 516, 7, 58, 2, 2, 516, 69, 3, 2,
+	2, 2, 517, 528, 7, 58, 2, 2, 518, 528, 7, 60, 2, 2, 519, 528, 7, 59, 2,
+	2, 520, 528, 7, 62, 2, 2, 521, 528, 7, 57, 2, 2, 522, 528, 7, 61, 2, 2,
+	523, 528, 7, 54, 2, 2, 524, 528, 5, 72, 37, 2, 525, 528, 7, 32, 2, 2, 526,
+	528, 7, 34, 2, 2, 527, 517, 3, 2, 2, 2, 527, 518, 3, 2, 2, 2, 527, 519,
+	3, 2, 2, 2, 527, 520, 3, 2, 2, 2, 527, 521, 3, 2, 2, 2, 527, 522, 3, 2,
+	2, 2, 527, 523, 3, 2, 2, 2, 527, 524, 3, 2, 2, 2, 527, 525, 3, 2, 2, 2,
+	527, 526, 3, 2, 2, 2, 528, 71, 3, 2, 2, 2, 529, 530, 9, 5, 2, 2, 530, 73,
+	3, 2, 2, 2, 531, 532, 6, 38, 2, 2, 532, 534, 11, 2, 2, 2, 533, 531, 3,
+	2, 2, 2, 534, 535, 3, 2, 2, 2, 535, 533, 3, 2, 2, 2, 535, 536, 3, 2, 2,
+	2, 536, 75, 3, 2, 2, 2, 537, 538, 9, 6, 2, 2, 538, 77, 3, 2, 2, 2, 539,
+	540, 7, 45, 2, 2, 540, 79, 3, 2, 2, 2, 58, 85, 87, 96, 98, 143, 145, 188,
+	190, 203, 215, 224, 232, 241, 249, 258, 264, 269, 278, 284, 289, 305, 307,
+	310, 321, 329, 347, 352, 356, 370, 378, 381, 384, 394, 397, 400, 409, 414,
+	429, 431, 436, 444, 447, 450, 454, 460, 468, 471, 474, 481, 483, 491, 494,
+	497, 501, 527, 535,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
@@ -158,26 +272,31 @@ var literalNames = []string{
 	"", "'rule'", "'filter'", "'macro'", "'list'", "'name'", "'items'", "'condition'",
 	"'desc'", "'action'", "'output'", "'priority'", "'tags'", "'prefilter'",
 	"'enabled'", "'warn_evttypes'", "'skip-if-unknown-filter'", "'append'",
-	"'required_engine_version'", "'and'", "'or'", "'not'", "'<'", "'<='", "'>'",
-	"'>='", "'='", "'!='", "'in'", "'contains'", "'icontains'", "'startswith'",
-	"'endswith'", "'pmatch'", "'exists'", "'['", "']'", "'('", "')'", "','",
-	"'-'",
+	"'required_engine_version'", "'exceptions'", "'fields'", "'comps'", "'values'",
+	"'source'", "'format'", "'column'", "'score'", "'and'", "'or'", "'not'",
+	"'<'", "'<='", "'>'", "'>='", "'='", "'!='", "'in'", "'contains'", "'icontains'",
+	"'startswith'", "'endswith'", "'pmatch'", "'in_cidr'", "'exists'", "",
+	"'['", "']'", "'('", "')'", "','", "'-'",
 }
 var symbolicNames = []string{
 	"", "RULE", "FILTER", "MACRO", "LIST", "NAME", "ITEMS", "COND", "DESC",
 	"ACTION", "OUTPUT", "PRIORITY", "TAGS", "PREFILTER", "ENABLED", "WARNEVTTYPE",
-	"SKIPUNKNOWN", "FAPPEND", "REQ", "AND", "OR", "NOT", "LT", "LE", "GT",
-	"GE", "EQ", "NEQ", "IN", "CONTAINS", "ICONTAINS", "STARTSWITH", "ENDSWITH",
-	"PMATCH", "EXISTS", "LBRACK", "RBRACK", "LPAREN", "RPAREN", "LISTSEP",
-	"DECL", "DEF", "SEVERITY", "SFSEVERITY", "FSEVERITY", "ID", "NUMBER", "PATH",
-	"STRING", "TAG", "WS", "NL", "COMMENT", "ANY",
+	"SKIPUNKNOWN", "FAPPEND", "REQ", "EXCEPTIONS", "FIELDS", "COMPS", "VALUES",
+	"SOURCE", "FORMAT", "COLUMN", "SCORE", "AND", "OR", "NOT", "LT", "LE",
+	"GT", "GE", "EQ", "NEQ", "IN", "CONTAINS", "ICONTAINS", "STARTSWITH", "ENDSWITH",
+	"PMATCH", "INCIDR", "EXISTS", "ANCESTOR", "LBRACK", "RBRACK", "LPAREN",
+	"RPAREN", "LISTSEP", "DECL", "DEF", "SEVERITY", "SFSEVERITY", "FSEVERITY",
+	"CIDR", "ID", "NUMBER", "PATH", "STRING", "TAG", "WS", "NL", "COMMENT",
+	"ANY",
 }
 
 var ruleNames = []string{
-	"policy", "defs", "prule", "srule", "pfilter", "sfilter", "pmacro", "plist",
-	"preq", "expression", "or_expression", "and_expression", "term", "items",
-	"tags", "prefilter", "severity", "enabled", "warnevttype", "skipunknown",
-	"fappend", "variable", "atom", "text", "binary_operator", "unary_operator",
+	"policy", "defs", "prule", "srule", "pfilter", "sfilter", "pmacro", "smacro",
+	"plist", "slist", "preq", "listsource", "condition", "expression", "or_expression",
+	"and_expression", "term", "items", "tags", "prefilter", "exceptions", "exception",
+	"fields", "comps", "comp", "values", "value", "severity", "enabled", "warnevttype",
+	"skipunknown", "score", "fappend", "variable", "atom", "keyword", "text",
+	"binary_operator", "unary_operator",
 }
 var decisionToDFA = make([]*antlr.DFA, len(deserializedATN.DecisionToState))
 
@@ -226,41 +345,52 @@ const (
 	SfplParserSKIPUNKNOWN = 16
 	SfplParserFAPPEND     = 17
 	SfplParserREQ         = 18
-	SfplParserAND         = 19
-	SfplParserOR          = 20
-	SfplParserNOT         = 21
-	SfplParserLT          = 22
-	SfplParserLE          = 23
-	SfplParserGT          = 24
-	SfplParserGE          = 25
-	SfplParserEQ          = 26
-	SfplParserNEQ         = 27
-	SfplParserIN          = 28
-	SfplParserCONTAINS    = 29
-	SfplParserICONTAINS   = 30
-	SfplParserSTARTSWITH  = 31
-	SfplParserENDSWITH    = 32
-	SfplParserPMATCH      = 33
-	SfplParserEXISTS      = 34
-	SfplParserLBRACK      = 35
-	SfplParserRBRACK      = 36
-	SfplParserLPAREN      = 37
-	SfplParserRPAREN      = 38
-	SfplParserLISTSEP     = 39
-	SfplParserDECL        = 40
-	SfplParserDEF         = 41
-	SfplParserSEVERITY    = 42
-	SfplParserSFSEVERITY  = 43
-	SfplParserFSEVERITY   = 44
-	SfplParserID          = 45
-	SfplParserNUMBER      = 46
-	SfplParserPATH        = 47
-	SfplParserSTRING      = 48
-	SfplParserTAG         = 49
-	SfplParserWS          = 50
-	SfplParserNL          = 51
-	SfplParserCOMMENT     = 52
-	SfplParserANY         = 53
+	SfplParserEXCEPTIONS  = 19
+	SfplParserFIELDS      = 20
+	SfplParserCOMPS       = 21
+	SfplParserVALUES      = 22
+	SfplParserSOURCE      = 23
+	SfplParserFORMAT      = 24
+	SfplParserCOLUMN      = 25
+	SfplParserSCORE       = 26
+	SfplParserAND         = 27
+	SfplParserOR          = 28
+	SfplParserNOT         = 29
+	SfplParserLT          = 30
+	SfplParserLE          = 31
+	SfplParserGT          = 32
+	SfplParserGE          = 33
+	SfplParserEQ          = 34
+	SfplParserNEQ         = 35
+	SfplParserIN          = 36
+	SfplParserCONTAINS    = 37
+	SfplParserICONTAINS   = 38
+	SfplParserSTARTSWITH  = 39
+	SfplParserENDSWITH    = 40
+	SfplParserPMATCH      = 41
+	SfplParserINCIDR      = 42
+	SfplParserEXISTS      = 43
+	SfplParserANCESTOR    = 44
+	SfplParserLBRACK      = 45
+	SfplParserRBRACK      = 46
+	SfplParserLPAREN      = 47
+	SfplParserRPAREN      = 48
+	SfplParserLISTSEP     = 49
+	SfplParserDECL        = 50
+	SfplParserDEF         = 51
+	SfplParserSEVERITY    = 52
+	SfplParserSFSEVERITY  = 53
+	SfplParserFSEVERITY   = 54
+	SfplParserCIDR        = 55
+	SfplParserID          = 56
+	SfplParserNUMBER      = 57
+	SfplParserPATH        = 58
+	SfplParserSTRING      = 59
+	SfplParserTAG         = 60
+	SfplParserWS          = 61
+	SfplParserNL          = 62
+	SfplParserCOMMENT     = 63
+	SfplParserANY         = 64
 )
 
 // SfplParser rules.
@@ -272,25 +402,38 @@ const (
 	SfplParserRULE_pfilter         = 4
 	SfplParserRULE_sfilter         = 5
 	SfplParserRULE_pmacro          = 6
-	SfplParserRULE_plist           = 7
-	SfplParserRULE_preq            = 8
-	SfplParserRULE_expression      = 9
-	SfplParserRULE_or_expression   = 10
-	SfplParserRULE_and_expression  = 11
-	SfplParserRULE_term            = 12
-	SfplParserRULE_items           = 13
-	SfplParserRULE_tags            = 14
-	SfplParserRULE_prefilter       = 15
-	SfplParserRULE_severity        = 16
-	SfplParserRULE_enabled         = 17
-	SfplParserRULE_warnevttype     = 18
-	SfplParserRULE_skipunknown     = 19
-	SfplParserRULE_fappend         = 20
-	SfplParserRULE_variable        = 21
-	SfplParserRULE_atom            = 22
-	SfplParserRULE_text            = 23
-	SfplParserRULE_binary_operator = 24
-	SfplParserRULE_unary_operator  = 25
+	SfplParserRULE_smacro          = 7
+	SfplParserRULE_plist           = 8
+	SfplParserRULE_slist           = 9
+	SfplParserRULE_preq            = 10
+	SfplParserRULE_listsource      = 11
+	SfplParserRULE_condition       = 12
+	SfplParserRULE_expression      = 13
+	SfplParserRULE_or_expression   = 14
+	SfplParserRULE_and_expression  = 15
+	SfplParserRULE_term            = 16
+	SfplParserRULE_items           = 17
+	SfplParserRULE_tags            = 18
+	SfplParserRULE_prefilter       = 19
+	SfplParserRULE_exceptions      = 20
+	SfplParserRULE_exception       = 21
+	SfplParserRULE_fields          = 22
+	SfplParserRULE_comps           = 23
+	SfplParserRULE_comp            = 24
+	SfplParserRULE_values          = 25
+	SfplParserRULE_value           = 26
+	SfplParserRULE_severity        = 27
+	SfplParserRULE_enabled         = 28
+	SfplParserRULE_warnevttype     = 29
+	SfplParserRULE_skipunknown     = 30
+	SfplParserRULE_score           = 31
+	SfplParserRULE_fappend         = 32
+	SfplParserRULE_variable        = 33
+	SfplParserRULE_atom            = 34
+	SfplParserRULE_keyword         = 35
+	SfplParserRULE_text            = 36
+	SfplParserRULE_binary_operator = 37
+	SfplParserRULE_unary_operator  = 38
 )
 
 // IPolicyContext is an interface to support dynamic dispatch.
@@ -381,50 +524,50 @@ func (s *PolicyContext) Pfilter(i int) IPfilterContext {
 	return t.(IPfilterContext)
 }
 
-func (s *PolicyContext) AllPmacro() []IPmacroContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IPmacroContext)(nil)).Elem())
-	var tst = make([]IPmacroContext, len(ts))
+func (s *PolicyContext) AllSmacro() []ISmacroContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ISmacroContext)(nil)).Elem())
+	var tst = make([]ISmacroContext, len(ts))
 
 	for i, t := range ts {
 		if t != nil {
-			tst[i] = t.(IPmacroContext)
+			tst[i] = t.(ISmacroContext)
 		}
 	}
 
 	return tst
 }
 
-func (s *PolicyContext) Pmacro(i int) IPmacroContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IPmacroContext)(nil)).Elem(), i)
+func (s *PolicyContext) Smacro(i int) ISmacroContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ISmacroContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IPmacroContext)
+	return t.(ISmacroContext)
 }
 
-func (s *PolicyContext) AllPlist() []IPlistContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IPlistContext)(nil)).Elem())
-	var tst = make([]IPlistContext, len(ts))
+func (s *PolicyContext) AllSlist() []ISlistContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ISlistContext)(nil)).Elem())
+	var tst = make([]ISlistContext, len(ts))
 
 	for i, t := range ts {
 		if t != nil {
-			tst[i] = t.(IPlistContext)
+			tst[i] = t.(ISlistContext)
 		}
 	}
 
 	return tst
 }
 
-func (s *PolicyContext) Plist(i int) IPlistContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IPlistContext)(nil)).Elem(), i)
+func (s *PolicyContext) Slist(i int) ISlistContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ISlistContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IPlistContext)
+	return t.(ISlistContext)
 }
 
 func (s *PolicyContext) AllPreq() []IPreqContext {
@@ -502,52 +645,52 @@ func (p *SfplParser) Policy() (localctx IPolicyContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(57)
+	p.SetState(83)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for ok := true; ok; ok = _la == SfplParserDECL {
-		p.SetState(57)
+		p.SetState(83)
 		p.GetErrorHandler().Sync(p)
 		switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 0, p.GetParserRuleContext()) {
 		case 1:
 			{
-				p.SetState(52)
+				p.SetState(78)
 				p.Prule()
 			}
 
 		case 2:
 			{
-				p.SetState(53)
+				p.SetState(79)
 				p.Pfilter()
 			}
 
 		case 3:
 			{
-				p.SetState(54)
-				p.Pmacro()
+				p.SetState(80)
+				p.Smacro()
 			}
 
 		case 4:
 			{
-				p.SetState(55)
-				p.Plist()
+				p.SetState(81)
+				p.Slist()
 			}
 
 		case 5:
 			{
-				p.SetState(56)
+				p.SetState(82)
 				p.Preq()
 			}
 
 		}
 
-		p.SetState(59)
+		p.SetState(85)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(61)
+		p.SetState(87)
 		p.Match(SfplParserEOF)
 	}
 
@@ -763,52 +906,52 @@ func (p *SfplParser) Defs() (localctx IDefsContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(70)
+	p.SetState(96)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == SfplParserDECL {
-		p.SetState(68)
+		p.SetState(94)
 		p.GetErrorHandler().Sync(p)
 		switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 2, p.GetParserRuleContext()) {
 		case 1:
 			{
-				p.SetState(63)
+				p.SetState(89)
 				p.Srule()
 			}
 
 		case 2:
 			{
-				p.SetState(64)
+				p.SetState(90)
 				p.Sfilter()
 			}
 
 		case 3:
 			{
-				p.SetState(65)
+				p.SetState(91)
 				p.Pmacro()
 			}
 
 		case 4:
 			{
-				p.SetState(66)
+				p.SetState(92)
 				p.Plist()
 			}
 
 		case 5:
 			{
-				p.SetState(67)
+				p.SetState(93)
 				p.Preq()
 			}
 
 		}
 
-		p.SetState(72)
+		p.SetState(98)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(73)
+		p.SetState(99)
 		p.Match(SfplParserEOF)
 	}
 
@@ -892,22 +1035,43 @@ func (s *PruleContext) Text(i int) ITextContext {
 	return t.(ITextContext)
 }
 
-func (s *PruleContext) DESC() antlr.TerminalNode {
-	return s.GetToken(SfplParserDESC, 0)
+func (s *PruleContext) AllDESC() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserDESC)
 }
 
-func (s *PruleContext) COND() antlr.TerminalNode {
-	return s.GetToken(SfplParserCOND, 0)
+func (s *PruleContext) DESC(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserDESC, i)
 }
 
-func (s *PruleContext) Expression() IExpressionContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExpressionContext)(nil)).Elem(), 0)
+func (s *PruleContext) AllCOND() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserCOND)
+}
+
+func (s *PruleContext) COND(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserCOND, i)
+}
+
+func (s *PruleContext) AllCondition() []IConditionContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IConditionContext)(nil)).Elem())
+	var tst = make([]IConditionContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IConditionContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *PruleContext) Condition(i int) IConditionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IConditionContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IExpressionContext)
+	return t.(IConditionContext)
 }
 
 func (s *PruleContext) AllPRIORITY() []antlr.TerminalNode {
@@ -1096,6 +1260,99 @@ func (s *PruleContext) Skipunknown(i int) ISkipunknownContext {
 	return t.(ISkipunknownContext)
 }
 
+func (s *PruleContext) AllEXCEPTIONS() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserEXCEPTIONS)
+}
+
+func (s *PruleContext) EXCEPTIONS(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserEXCEPTIONS, i)
+}
+
+func (s *PruleContext) AllExceptions() []IExceptionsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IExceptionsContext)(nil)).Elem())
+	var tst = make([]IExceptionsContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IExceptionsContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *PruleContext) Exceptions(i int) IExceptionsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExceptionsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExceptionsContext)
+}
+
+func (s *PruleContext) AllSCORE() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserSCORE)
+}
+
+func (s *PruleContext) SCORE(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserSCORE, i)
+}
+
+func (s *PruleContext) AllScore() []IScoreContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IScoreContext)(nil)).Elem())
+	var tst = make([]IScoreContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IScoreContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *PruleContext) Score(i int) IScoreContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IScoreContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IScoreContext)
+}
+
+func (s *PruleContext) AllFAPPEND() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserFAPPEND)
+}
+
+func (s *PruleContext) FAPPEND(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserFAPPEND, i)
+}
+
+func (s *PruleContext) AllFappend() []IFappendContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFappendContext)(nil)).Elem())
+	var tst = make([]IFappendContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFappendContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *PruleContext) Fappend(i int) IFappendContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFappendContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFappendContext)
+}
+
 func (s *PruleContext) AllACTION() []antlr.TerminalNode {
 	return s.GetTokens(SfplParserACTION)
 }
@@ -1165,57 +1422,61 @@ func (p *SfplParser) Prule() (localctx IPruleContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(75)
+		p.SetState(101)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(76)
+		p.SetState(102)
 		p.Match(SfplParserRULE)
 	}
 	{
-		p.SetState(77)
+		p.SetState(103)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(78)
+		p.SetState(104)
 		p.Text()
 	}
-	{
-		p.SetState(79)
-		p.Match(SfplParserDESC)
-	}
-	{
-		p.SetState(80)
-		p.Match(SfplParserDEF)
-	}
-	{
-		p.SetState(81)
-		p.Text()
-	}
-	{
-		p.SetState(82)
-		p.Match(SfplParserCOND)
-	}
-	{
-		p.SetState(83)
-		p.Match(SfplParserDEF)
-	}
-	{
-		p.SetState(84)
-		p.Expression()
-	}
-	p.SetState(108)
+	p.SetState(143)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for ((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserACTION)|(1<<SfplParserOUTPUT)|(1<<SfplParserPRIORITY)|(1<<SfplParserTAGS)|(1<<SfplParserPREFILTER)|(1<<SfplParserENABLED)|(1<<SfplParserWARNEVTTYPE)|(1<<SfplParserSKIPUNKNOWN))) != 0 {
-		p.SetState(106)
+	for ((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserCOND)|(1<<SfplParserDESC)|(1<<SfplParserACTION)|(1<<SfplParserOUTPUT)|(1<<SfplParserPRIORITY)|(1<<SfplParserTAGS)|(1<<SfplParserPREFILTER)|(1<<SfplParserENABLED)|(1<<SfplParserWARNEVTTYPE)|(1<<SfplParserSKIPUNKNOWN)|(1<<SfplParserFAPPEND)|(1<<SfplParserEXCEPTIONS)|(1<<SfplParserSCORE))) != 0 {
+		p.SetState(141)
 		p.GetErrorHandler().Sync(p)
 
 		switch p.GetTokenStream().LA(1) {
+		case SfplParserDESC:
+			{
+				p.SetState(105)
+				p.Match(SfplParserDESC)
+			}
+			{
+				p.SetState(106)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(107)
+				p.Text()
+			}
+
+		case SfplParserCOND:
+			{
+				p.SetState(108)
+				p.Match(SfplParserCOND)
+			}
+			{
+				p.SetState(109)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(110)
+				p.Condition()
+			}
+
 		case SfplParserACTION, SfplParserOUTPUT:
 			{
-				p.SetState(85)
+				p.SetState(111)
 				_la = p.GetTokenStream().LA(1)
 
 				if !(_la == SfplParserACTION || _la == SfplParserOUTPUT) {
@@ -1226,103 +1487,145 @@ func (p *SfplParser) Prule() (localctx IPruleContext) {
 				}
 			}
 			{
-				p.SetState(86)
+				p.SetState(112)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(87)
+				p.SetState(113)
 				p.Text()
 			}
 
 		case SfplParserPRIORITY:
 			{
-				p.SetState(88)
+				p.SetState(114)
 				p.Match(SfplParserPRIORITY)
 			}
 			{
-				p.SetState(89)
+				p.SetState(115)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(90)
+				p.SetState(116)
 				p.Severity()
 			}
 
 		case SfplParserTAGS:
 			{
-				p.SetState(91)
+				p.SetState(117)
 				p.Match(SfplParserTAGS)
 			}
 			{
-				p.SetState(92)
+				p.SetState(118)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(93)
+				p.SetState(119)
 				p.Tags()
 			}
 
 		case SfplParserPREFILTER:
 			{
-				p.SetState(94)
+				p.SetState(120)
 				p.Match(SfplParserPREFILTER)
 			}
 			{
-				p.SetState(95)
+				p.SetState(121)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(96)
+				p.SetState(122)
 				p.Prefilter()
 			}
 
 		case SfplParserENABLED:
 			{
-				p.SetState(97)
+				p.SetState(123)
 				p.Match(SfplParserENABLED)
 			}
 			{
-				p.SetState(98)
+				p.SetState(124)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(99)
+				p.SetState(125)
 				p.Enabled()
 			}
 
 		case SfplParserWARNEVTTYPE:
 			{
-				p.SetState(100)
+				p.SetState(126)
 				p.Match(SfplParserWARNEVTTYPE)
 			}
 			{
-				p.SetState(101)
+				p.SetState(127)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(102)
+				p.SetState(128)
 				p.Warnevttype()
 			}
 
 		case SfplParserSKIPUNKNOWN:
 			{
-				p.SetState(103)
+				p.SetState(129)
 				p.Match(SfplParserSKIPUNKNOWN)
 			}
 			{
-				p.SetState(104)
+				p.SetState(130)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(105)
+				p.SetState(131)
 				p.Skipunknown()
 			}
 
+		case SfplParserEXCEPTIONS:
+			{
+				p.SetState(132)
+				p.Match(SfplParserEXCEPTIONS)
+			}
+			{
+				p.SetState(133)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(134)
+				p.Exceptions()
+			}
+
+		case SfplParserSCORE:
+			{
+				p.SetState(135)
+				p.Match(SfplParserSCORE)
+			}
+			{
+				p.SetState(136)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(137)
+				p.Score()
+			}
+
+		case SfplParserFAPPEND:
+			{
+				p.SetState(138)
+				p.Match(SfplParserFAPPEND)
+			}
+			{
+				p.SetState(139)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(140)
+				p.Fappend()
+			}
+
 		default:
 			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		}
 
-		p.SetState(110)
+		p.SetState(145)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -1407,22 +1710,43 @@ func (s *SruleContext) Text(i int) ITextContext {
 	return t.(ITextContext)
 }
 
-func (s *SruleContext) DESC() antlr.TerminalNode {
-	return s.GetToken(SfplParserDESC, 0)
+func (s *SruleContext) AllDESC() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserDESC)
 }
 
-func (s *SruleContext) COND() antlr.TerminalNode {
-	return s.GetToken(SfplParserCOND, 0)
+func (s *SruleContext) DESC(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserDESC, i)
 }
 
-func (s *SruleContext) Expression() IExpressionContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExpressionContext)(nil)).Elem(), 0)
+func (s *SruleContext) AllCOND() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserCOND)
+}
+
+func (s *SruleContext) COND(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserCOND, i)
+}
+
+func (s *SruleContext) AllCondition() []IConditionContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IConditionContext)(nil)).Elem())
+	var tst = make([]IConditionContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IConditionContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *SruleContext) Condition(i int) IConditionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IConditionContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IExpressionContext)
+	return t.(IConditionContext)
 }
 
 func (s *SruleContext) AllPRIORITY() []antlr.TerminalNode {
@@ -1611,64 +1935,157 @@ func (s *SruleContext) Skipunknown(i int) ISkipunknownContext {
 	return t.(ISkipunknownContext)
 }
 
-func (s *SruleContext) AllACTION() []antlr.TerminalNode {
-	return s.GetTokens(SfplParserACTION)
+func (s *SruleContext) AllEXCEPTIONS() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserEXCEPTIONS)
 }
 
-func (s *SruleContext) ACTION(i int) antlr.TerminalNode {
-	return s.GetToken(SfplParserACTION, i)
+func (s *SruleContext) EXCEPTIONS(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserEXCEPTIONS, i)
 }
 
-func (s *SruleContext) AllOUTPUT() []antlr.TerminalNode {
-	return s.GetTokens(SfplParserOUTPUT)
-}
+func (s *SruleContext) AllExceptions() []IExceptionsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IExceptionsContext)(nil)).Elem())
+	var tst = make([]IExceptionsContext, len(ts))
 
-func (s *SruleContext) OUTPUT(i int) antlr.TerminalNode {
-	return s.GetToken(SfplParserOUTPUT, i)
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IExceptionsContext)
+		}
+	}
+
+	return tst
 }
 
-func (s *SruleContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *SruleContext) Exceptions(i int) IExceptionsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExceptionsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExceptionsContext)
 }
 
-func (s *SruleContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
+func (s *SruleContext) AllSCORE() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserSCORE)
 }
 
-func (s *SruleContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterSrule(s)
-	}
+func (s *SruleContext) SCORE(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserSCORE, i)
 }
 
-func (s *SruleContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitSrule(s)
+func (s *SruleContext) AllScore() []IScoreContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IScoreContext)(nil)).Elem())
+	var tst = make([]IScoreContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IScoreContext)
+		}
 	}
+
+	return tst
 }
 
-func (s *SruleContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
-	switch t := visitor.(type) {
-	case SfplVisitor:
-		return t.VisitSrule(s)
+func (s *SruleContext) Score(i int) IScoreContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IScoreContext)(nil)).Elem(), i)
 
-	default:
-		return t.VisitChildren(s)
+	if t == nil {
+		return nil
 	}
+
+	return t.(IScoreContext)
 }
 
-func (p *SfplParser) Srule() (localctx ISruleContext) {
-	localctx = NewSruleContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 6, SfplParserRULE_srule)
-	var _la int
+func (s *SruleContext) AllFAPPEND() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserFAPPEND)
+}
 
-	defer func() {
-		p.ExitRule()
-	}()
+func (s *SruleContext) FAPPEND(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserFAPPEND, i)
+}
 
-	defer func() {
-		if err := recover(); err != nil {
-			if v, ok := err.(antlr.RecognitionException); ok {
+func (s *SruleContext) AllFappend() []IFappendContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFappendContext)(nil)).Elem())
+	var tst = make([]IFappendContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFappendContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *SruleContext) Fappend(i int) IFappendContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFappendContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFappendContext)
+}
+
+func (s *SruleContext) AllACTION() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserACTION)
+}
+
+func (s *SruleContext) ACTION(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserACTION, i)
+}
+
+func (s *SruleContext) AllOUTPUT() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserOUTPUT)
+}
+
+func (s *SruleContext) OUTPUT(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserOUTPUT, i)
+}
+
+func (s *SruleContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SruleContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *SruleContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterSrule(s)
+	}
+}
+
+func (s *SruleContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitSrule(s)
+	}
+}
+
+func (s *SruleContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitSrule(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Srule() (localctx ISruleContext) {
+	localctx = NewSruleContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 6, SfplParserRULE_srule)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
 				localctx.SetException(v)
 				p.GetErrorHandler().ReportError(p, v)
 				p.GetErrorHandler().Recover(p, v)
@@ -1680,57 +2097,61 @@ func (p *SfplParser) Srule() (localctx ISruleContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(111)
+		p.SetState(146)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(112)
+		p.SetState(147)
 		p.Match(SfplParserRULE)
 	}
 	{
-		p.SetState(113)
-		p.Match(SfplParserDEF)
-	}
-	{
-		p.SetState(114)
-		p.Text()
-	}
-	{
-		p.SetState(115)
-		p.Match(SfplParserDESC)
-	}
-	{
-		p.SetState(116)
+		p.SetState(148)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(117)
+		p.SetState(149)
 		p.Text()
 	}
-	{
-		p.SetState(118)
-		p.Match(SfplParserCOND)
-	}
-	{
-		p.SetState(119)
-		p.Match(SfplParserDEF)
-	}
-	{
-		p.SetState(120)
-		p.Expression()
-	}
-	p.SetState(144)
+	p.SetState(188)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for ((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserACTION)|(1<<SfplParserOUTPUT)|(1<<SfplParserPRIORITY)|(1<<SfplParserTAGS)|(1<<SfplParserPREFILTER)|(1<<SfplParserENABLED)|(1<<SfplParserWARNEVTTYPE)|(1<<SfplParserSKIPUNKNOWN))) != 0 {
-		p.SetState(142)
+	for ((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserCOND)|(1<<SfplParserDESC)|(1<<SfplParserACTION)|(1<<SfplParserOUTPUT)|(1<<SfplParserPRIORITY)|(1<<SfplParserTAGS)|(1<<SfplParserPREFILTER)|(1<<SfplParserENABLED)|(1<<SfplParserWARNEVTTYPE)|(1<<SfplParserSKIPUNKNOWN)|(1<<SfplParserFAPPEND)|(1<<SfplParserEXCEPTIONS)|(1<<SfplParserSCORE))) != 0 {
+		p.SetState(186)
 		p.GetErrorHandler().Sync(p)
 
 		switch p.GetTokenStream().LA(1) {
+		case SfplParserDESC:
+			{
+				p.SetState(150)
+				p.Match(SfplParserDESC)
+			}
+			{
+				p.SetState(151)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(152)
+				p.Text()
+			}
+
+		case SfplParserCOND:
+			{
+				p.SetState(153)
+				p.Match(SfplParserCOND)
+			}
+			{
+				p.SetState(154)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(155)
+				p.Condition()
+			}
+
 		case SfplParserACTION, SfplParserOUTPUT:
 			{
-				p.SetState(121)
+				p.SetState(156)
 				_la = p.GetTokenStream().LA(1)
 
 				if !(_la == SfplParserACTION || _la == SfplParserOUTPUT) {
@@ -1741,103 +2162,145 @@ func (p *SfplParser) Srule() (localctx ISruleContext) {
 				}
 			}
 			{
-				p.SetState(122)
+				p.SetState(157)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(123)
+				p.SetState(158)
 				p.Text()
 			}
 
 		case SfplParserPRIORITY:
 			{
-				p.SetState(124)
+				p.SetState(159)
 				p.Match(SfplParserPRIORITY)
 			}
 			{
-				p.SetState(125)
+				p.SetState(160)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(126)
+				p.SetState(161)
 				p.Severity()
 			}
 
 		case SfplParserTAGS:
 			{
-				p.SetState(127)
+				p.SetState(162)
 				p.Match(SfplParserTAGS)
 			}
 			{
-				p.SetState(128)
+				p.SetState(163)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(129)
+				p.SetState(164)
 				p.Tags()
 			}
 
 		case SfplParserPREFILTER:
 			{
-				p.SetState(130)
+				p.SetState(165)
 				p.Match(SfplParserPREFILTER)
 			}
 			{
-				p.SetState(131)
+				p.SetState(166)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(132)
+				p.SetState(167)
 				p.Prefilter()
 			}
 
 		case SfplParserENABLED:
 			{
-				p.SetState(133)
+				p.SetState(168)
 				p.Match(SfplParserENABLED)
 			}
 			{
-				p.SetState(134)
+				p.SetState(169)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(135)
+				p.SetState(170)
 				p.Enabled()
 			}
 
 		case SfplParserWARNEVTTYPE:
 			{
-				p.SetState(136)
+				p.SetState(171)
 				p.Match(SfplParserWARNEVTTYPE)
 			}
 			{
-				p.SetState(137)
+				p.SetState(172)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(138)
+				p.SetState(173)
 				p.Warnevttype()
 			}
 
 		case SfplParserSKIPUNKNOWN:
 			{
-				p.SetState(139)
+				p.SetState(174)
 				p.Match(SfplParserSKIPUNKNOWN)
 			}
 			{
-				p.SetState(140)
+				p.SetState(175)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(141)
+				p.SetState(176)
 				p.Skipunknown()
 			}
 
+		case SfplParserEXCEPTIONS:
+			{
+				p.SetState(177)
+				p.Match(SfplParserEXCEPTIONS)
+			}
+			{
+				p.SetState(178)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(179)
+				p.Exceptions()
+			}
+
+		case SfplParserSCORE:
+			{
+				p.SetState(180)
+				p.Match(SfplParserSCORE)
+			}
+			{
+				p.SetState(181)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(182)
+				p.Score()
+			}
+
+		case SfplParserFAPPEND:
+			{
+				p.SetState(183)
+				p.Match(SfplParserFAPPEND)
+			}
+			{
+				p.SetState(184)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(185)
+				p.Fappend()
+			}
+
 		default:
 			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		}
 
-		p.SetState(146)
+		p.SetState(190)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -1984,48 +2447,48 @@ func (p *SfplParser) Pfilter() (localctx IPfilterContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(147)
+		p.SetState(191)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(148)
+		p.SetState(192)
 		p.Match(SfplParserFILTER)
 	}
 	{
-		p.SetState(149)
+		p.SetState(193)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(150)
+		p.SetState(194)
 		p.Match(SfplParserID)
 	}
 	{
-		p.SetState(151)
+		p.SetState(195)
 		p.Match(SfplParserCOND)
 	}
 	{
-		p.SetState(152)
+		p.SetState(196)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(153)
+		p.SetState(197)
 		p.Expression()
 	}
-	p.SetState(157)
+	p.SetState(201)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SfplParserENABLED {
 		{
-			p.SetState(154)
+			p.SetState(198)
 			p.Match(SfplParserENABLED)
 		}
 		{
-			p.SetState(155)
+			p.SetState(199)
 			p.Match(SfplParserDEF)
 		}
 		{
-			p.SetState(156)
+			p.SetState(200)
 			p.Enabled()
 		}
 
@@ -2173,48 +2636,48 @@ func (p *SfplParser) Sfilter() (localctx ISfilterContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(159)
+		p.SetState(203)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(160)
+		p.SetState(204)
 		p.Match(SfplParserFILTER)
 	}
 	{
-		p.SetState(161)
+		p.SetState(205)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(162)
+		p.SetState(206)
 		p.Match(SfplParserID)
 	}
 	{
-		p.SetState(163)
+		p.SetState(207)
 		p.Match(SfplParserCOND)
 	}
 	{
-		p.SetState(164)
+		p.SetState(208)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(165)
+		p.SetState(209)
 		p.Expression()
 	}
-	p.SetState(169)
+	p.SetState(213)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SfplParserENABLED {
 		{
-			p.SetState(166)
+			p.SetState(210)
 			p.Match(SfplParserENABLED)
 		}
 		{
-			p.SetState(167)
+			p.SetState(211)
 			p.Match(SfplParserDEF)
 		}
 		{
-			p.SetState(168)
+			p.SetState(212)
 			p.Enabled()
 		}
 
@@ -2285,22 +2748,39 @@ func (s *PmacroContext) COND() antlr.TerminalNode {
 	return s.GetToken(SfplParserCOND, 0)
 }
 
-func (s *PmacroContext) Expression() IExpressionContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExpressionContext)(nil)).Elem(), 0)
+func (s *PmacroContext) Condition() IConditionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IConditionContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IExpressionContext)
+	return t.(IConditionContext)
+}
+
+func (s *PmacroContext) AllFAPPEND() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserFAPPEND)
+}
+
+func (s *PmacroContext) FAPPEND(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserFAPPEND, i)
 }
 
-func (s *PmacroContext) FAPPEND() antlr.TerminalNode {
-	return s.GetToken(SfplParserFAPPEND, 0)
+func (s *PmacroContext) AllFappend() []IFappendContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFappendContext)(nil)).Elem())
+	var tst = make([]IFappendContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFappendContext)
+		}
+	}
+
+	return tst
 }
 
-func (s *PmacroContext) Fappend() IFappendContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFappendContext)(nil)).Elem(), 0)
+func (s *PmacroContext) Fappend(i int) IFappendContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFappendContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
@@ -2362,48 +2842,67 @@ func (p *SfplParser) Pmacro() (localctx IPmacroContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(171)
+		p.SetState(215)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(172)
+		p.SetState(216)
 		p.Match(SfplParserMACRO)
 	}
 	{
-		p.SetState(173)
+		p.SetState(217)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(174)
+		p.SetState(218)
 		p.Match(SfplParserID)
 	}
+	p.SetState(222)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserFAPPEND {
+		{
+			p.SetState(219)
+			p.Match(SfplParserFAPPEND)
+		}
+		{
+			p.SetState(220)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(221)
+			p.Fappend()
+		}
+
+	}
 	{
-		p.SetState(175)
+		p.SetState(224)
 		p.Match(SfplParserCOND)
 	}
 	{
-		p.SetState(176)
+		p.SetState(225)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(177)
-		p.Expression()
+		p.SetState(226)
+		p.Condition()
 	}
-	p.SetState(181)
+	p.SetState(230)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SfplParserFAPPEND {
 		{
-			p.SetState(178)
+			p.SetState(227)
 			p.Match(SfplParserFAPPEND)
 		}
 		{
-			p.SetState(179)
+			p.SetState(228)
 			p.Match(SfplParserDEF)
 		}
 		{
-			p.SetState(180)
+			p.SetState(229)
 			p.Fappend()
 		}
 
@@ -2412,111 +2911,143 @@ func (p *SfplParser) Pmacro() (localctx IPmacroContext) {
 	return localctx
 }
 
-// IPlistContext is an interface to support dynamic dispatch.
-type IPlistContext interface {
+// ISmacroContext is an interface to support dynamic dispatch.
+type ISmacroContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsPlistContext differentiates from other interfaces.
-	IsPlistContext()
+	// IsSmacroContext differentiates from other interfaces.
+	IsSmacroContext()
 }
 
-type PlistContext struct {
+type SmacroContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyPlistContext() *PlistContext {
-	var p = new(PlistContext)
+func NewEmptySmacroContext() *SmacroContext {
+	var p = new(SmacroContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_plist
+	p.RuleIndex = SfplParserRULE_smacro
 	return p
 }
 
-func (*PlistContext) IsPlistContext() {}
+func (*SmacroContext) IsSmacroContext() {}
 
-func NewPlistContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PlistContext {
-	var p = new(PlistContext)
+func NewSmacroContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SmacroContext {
+	var p = new(SmacroContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_plist
+	p.RuleIndex = SfplParserRULE_smacro
 
 	return p
 }
 
-func (s *PlistContext) GetParser() antlr.Parser { return s.parser }
+func (s *SmacroContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *PlistContext) DECL() antlr.TerminalNode {
+func (s *SmacroContext) DECL() antlr.TerminalNode {
 	return s.GetToken(SfplParserDECL, 0)
 }
 
-func (s *PlistContext) LIST() antlr.TerminalNode {
-	return s.GetToken(SfplParserLIST, 0)
+func (s *SmacroContext) MACRO() antlr.TerminalNode {
+	return s.GetToken(SfplParserMACRO, 0)
 }
 
-func (s *PlistContext) AllDEF() []antlr.TerminalNode {
+func (s *SmacroContext) AllDEF() []antlr.TerminalNode {
 	return s.GetTokens(SfplParserDEF)
 }
 
-func (s *PlistContext) DEF(i int) antlr.TerminalNode {
+func (s *SmacroContext) DEF(i int) antlr.TerminalNode {
 	return s.GetToken(SfplParserDEF, i)
 }
 
-func (s *PlistContext) ID() antlr.TerminalNode {
+func (s *SmacroContext) ID() antlr.TerminalNode {
 	return s.GetToken(SfplParserID, 0)
 }
 
-func (s *PlistContext) ITEMS() antlr.TerminalNode {
-	return s.GetToken(SfplParserITEMS, 0)
+func (s *SmacroContext) COND() antlr.TerminalNode {
+	return s.GetToken(SfplParserCOND, 0)
 }
 
-func (s *PlistContext) Items() IItemsContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IItemsContext)(nil)).Elem(), 0)
+func (s *SmacroContext) Condition() IConditionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IConditionContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IItemsContext)
+	return t.(IConditionContext)
 }
 
-func (s *PlistContext) GetRuleContext() antlr.RuleContext {
+func (s *SmacroContext) AllFAPPEND() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserFAPPEND)
+}
+
+func (s *SmacroContext) FAPPEND(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserFAPPEND, i)
+}
+
+func (s *SmacroContext) AllFappend() []IFappendContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFappendContext)(nil)).Elem())
+	var tst = make([]IFappendContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFappendContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *SmacroContext) Fappend(i int) IFappendContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFappendContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFappendContext)
+}
+
+func (s *SmacroContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *PlistContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *SmacroContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *PlistContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SmacroContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterPlist(s)
+		listenerT.EnterSmacro(s)
 	}
 }
 
-func (s *PlistContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SmacroContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitPlist(s)
+		listenerT.ExitSmacro(s)
 	}
 }
 
-func (s *PlistContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *SmacroContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitPlist(s)
+		return t.VisitSmacro(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Plist() (localctx IPlistContext) {
-	localctx = NewPlistContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, SfplParserRULE_plist)
+func (p *SfplParser) Smacro() (localctx ISmacroContext) {
+	localctx = NewSmacroContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 14, SfplParserRULE_smacro)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -2536,130 +3067,222 @@ func (p *SfplParser) Plist() (localctx IPlistContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(183)
+		p.SetState(232)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(184)
-		p.Match(SfplParserLIST)
+		p.SetState(233)
+		p.Match(SfplParserMACRO)
 	}
 	{
-		p.SetState(185)
+		p.SetState(234)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(186)
+		p.SetState(235)
 		p.Match(SfplParserID)
 	}
+	p.SetState(239)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserFAPPEND {
+		{
+			p.SetState(236)
+			p.Match(SfplParserFAPPEND)
+		}
+		{
+			p.SetState(237)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(238)
+			p.Fappend()
+		}
+
+	}
 	{
-		p.SetState(187)
-		p.Match(SfplParserITEMS)
+		p.SetState(241)
+		p.Match(SfplParserCOND)
 	}
 	{
-		p.SetState(188)
+		p.SetState(242)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(189)
-		p.Items()
+		p.SetState(243)
+		p.Condition()
+	}
+	p.SetState(247)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserFAPPEND {
+		{
+			p.SetState(244)
+			p.Match(SfplParserFAPPEND)
+		}
+		{
+			p.SetState(245)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(246)
+			p.Fappend()
+		}
+
 	}
 
 	return localctx
 }
 
-// IPreqContext is an interface to support dynamic dispatch.
-type IPreqContext interface {
+// IPlistContext is an interface to support dynamic dispatch.
+type IPlistContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsPreqContext differentiates from other interfaces.
-	IsPreqContext()
+	// IsPlistContext differentiates from other interfaces.
+	IsPlistContext()
 }
 
-type PreqContext struct {
+type PlistContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyPreqContext() *PreqContext {
-	var p = new(PreqContext)
+func NewEmptyPlistContext() *PlistContext {
+	var p = new(PlistContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_preq
+	p.RuleIndex = SfplParserRULE_plist
 	return p
 }
 
-func (*PreqContext) IsPreqContext() {}
+func (*PlistContext) IsPlistContext() {}
 
-func NewPreqContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PreqContext {
-	var p = new(PreqContext)
+func NewPlistContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PlistContext {
+	var p = new(PlistContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_preq
+	p.RuleIndex = SfplParserRULE_plist
 
 	return p
 }
 
-func (s *PreqContext) GetParser() antlr.Parser { return s.parser }
+func (s *PlistContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *PreqContext) DECL() antlr.TerminalNode {
+func (s *PlistContext) DECL() antlr.TerminalNode {
 	return s.GetToken(SfplParserDECL, 0)
 }
 
-func (s *PreqContext) REQ() antlr.TerminalNode {
-	return s.GetToken(SfplParserREQ, 0)
+func (s *PlistContext) LIST() antlr.TerminalNode {
+	return s.GetToken(SfplParserLIST, 0)
 }
 
-func (s *PreqContext) DEF() antlr.TerminalNode {
-	return s.GetToken(SfplParserDEF, 0)
+func (s *PlistContext) AllDEF() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserDEF)
 }
 
-func (s *PreqContext) Atom() IAtomContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+func (s *PlistContext) DEF(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserDEF, i)
+}
+
+func (s *PlistContext) ID() antlr.TerminalNode {
+	return s.GetToken(SfplParserID, 0)
+}
+
+func (s *PlistContext) ITEMS() antlr.TerminalNode {
+	return s.GetToken(SfplParserITEMS, 0)
+}
+
+func (s *PlistContext) Items() IItemsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IItemsContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IAtomContext)
+	return t.(IItemsContext)
 }
 
-func (s *PreqContext) GetRuleContext() antlr.RuleContext {
+func (s *PlistContext) Listsource() IListsourceContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IListsourceContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IListsourceContext)
+}
+
+func (s *PlistContext) AllFAPPEND() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserFAPPEND)
+}
+
+func (s *PlistContext) FAPPEND(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserFAPPEND, i)
+}
+
+func (s *PlistContext) AllFappend() []IFappendContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFappendContext)(nil)).Elem())
+	var tst = make([]IFappendContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFappendContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *PlistContext) Fappend(i int) IFappendContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFappendContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFappendContext)
+}
+
+func (s *PlistContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *PreqContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *PlistContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *PreqContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *PlistContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterPreq(s)
+		listenerT.EnterPlist(s)
 	}
 }
 
-func (s *PreqContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *PlistContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitPreq(s)
+		listenerT.ExitPlist(s)
 	}
 }
 
-func (s *PreqContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *PlistContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitPreq(s)
+		return t.VisitPlist(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Preq() (localctx IPreqContext) {
-	localctx = NewPreqContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 16, SfplParserRULE_preq)
+func (p *SfplParser) Plist() (localctx IPlistContext) {
+	localctx = NewPlistContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 16, SfplParserRULE_plist)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -2679,234 +3302,236 @@ func (p *SfplParser) Preq() (localctx IPreqContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(191)
+		p.SetState(249)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(192)
-		p.Match(SfplParserREQ)
+		p.SetState(250)
+		p.Match(SfplParserLIST)
 	}
 	{
-		p.SetState(193)
+		p.SetState(251)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(194)
-		p.Atom()
+		p.SetState(252)
+		p.Match(SfplParserID)
+	}
+	p.SetState(256)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserFAPPEND {
+		{
+			p.SetState(253)
+			p.Match(SfplParserFAPPEND)
+		}
+		{
+			p.SetState(254)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(255)
+			p.Fappend()
+		}
+
+	}
+	p.SetState(262)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserITEMS:
+		{
+			p.SetState(258)
+			p.Match(SfplParserITEMS)
+		}
+		{
+			p.SetState(259)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(260)
+			p.Items()
+		}
+
+	case SfplParserSOURCE, SfplParserFORMAT, SfplParserCOLUMN:
+		{
+			p.SetState(261)
+			p.Listsource()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+	p.SetState(267)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserFAPPEND {
+		{
+			p.SetState(264)
+			p.Match(SfplParserFAPPEND)
+		}
+		{
+			p.SetState(265)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(266)
+			p.Fappend()
+		}
+
 	}
 
 	return localctx
 }
 
-// IExpressionContext is an interface to support dynamic dispatch.
-type IExpressionContext interface {
+// ISlistContext is an interface to support dynamic dispatch.
+type ISlistContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsExpressionContext differentiates from other interfaces.
-	IsExpressionContext()
+	// IsSlistContext differentiates from other interfaces.
+	IsSlistContext()
 }
 
-type ExpressionContext struct {
+type SlistContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyExpressionContext() *ExpressionContext {
-	var p = new(ExpressionContext)
+func NewEmptySlistContext() *SlistContext {
+	var p = new(SlistContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_expression
+	p.RuleIndex = SfplParserRULE_slist
 	return p
 }
 
-func (*ExpressionContext) IsExpressionContext() {}
+func (*SlistContext) IsSlistContext() {}
 
-func NewExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ExpressionContext {
-	var p = new(ExpressionContext)
+func NewSlistContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SlistContext {
+	var p = new(SlistContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_expression
+	p.RuleIndex = SfplParserRULE_slist
 
 	return p
 }
 
-func (s *ExpressionContext) GetParser() antlr.Parser { return s.parser }
+func (s *SlistContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ExpressionContext) Or_expression() IOr_expressionContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IOr_expressionContext)(nil)).Elem(), 0)
+func (s *SlistContext) DECL() antlr.TerminalNode {
+	return s.GetToken(SfplParserDECL, 0)
+}
 
-	if t == nil {
-		return nil
-	}
+func (s *SlistContext) LIST() antlr.TerminalNode {
+	return s.GetToken(SfplParserLIST, 0)
+}
 
-	return t.(IOr_expressionContext)
+func (s *SlistContext) AllDEF() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserDEF)
 }
 
-func (s *ExpressionContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *SlistContext) DEF(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserDEF, i)
 }
 
-func (s *ExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
+func (s *SlistContext) ID() antlr.TerminalNode {
+	return s.GetToken(SfplParserID, 0)
 }
 
-func (s *ExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterExpression(s)
-	}
+func (s *SlistContext) ITEMS() antlr.TerminalNode {
+	return s.GetToken(SfplParserITEMS, 0)
 }
 
-func (s *ExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitExpression(s)
+func (s *SlistContext) Items() IItemsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IItemsContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
 	}
+
+	return t.(IItemsContext)
 }
 
-func (s *ExpressionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
-	switch t := visitor.(type) {
-	case SfplVisitor:
-		return t.VisitExpression(s)
+func (s *SlistContext) Listsource() IListsourceContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IListsourceContext)(nil)).Elem(), 0)
 
-	default:
-		return t.VisitChildren(s)
+	if t == nil {
+		return nil
 	}
+
+	return t.(IListsourceContext)
 }
 
-func (p *SfplParser) Expression() (localctx IExpressionContext) {
-	localctx = NewExpressionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, SfplParserRULE_expression)
-
-	defer func() {
-		p.ExitRule()
-	}()
-
-	defer func() {
-		if err := recover(); err != nil {
-			if v, ok := err.(antlr.RecognitionException); ok {
-				localctx.SetException(v)
-				p.GetErrorHandler().ReportError(p, v)
-				p.GetErrorHandler().Recover(p, v)
-			} else {
-				panic(err)
-			}
-		}
-	}()
-
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(196)
-		p.Or_expression()
-	}
-
-	return localctx
-}
-
-// IOr_expressionContext is an interface to support dynamic dispatch.
-type IOr_expressionContext interface {
-	antlr.ParserRuleContext
-
-	// GetParser returns the parser.
-	GetParser() antlr.Parser
-
-	// IsOr_expressionContext differentiates from other interfaces.
-	IsOr_expressionContext()
-}
-
-type Or_expressionContext struct {
-	*antlr.BaseParserRuleContext
-	parser antlr.Parser
+func (s *SlistContext) AllFAPPEND() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserFAPPEND)
 }
 
-func NewEmptyOr_expressionContext() *Or_expressionContext {
-	var p = new(Or_expressionContext)
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_or_expression
-	return p
-}
-
-func (*Or_expressionContext) IsOr_expressionContext() {}
-
-func NewOr_expressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *Or_expressionContext {
-	var p = new(Or_expressionContext)
-
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
-
-	p.parser = parser
-	p.RuleIndex = SfplParserRULE_or_expression
-
-	return p
+func (s *SlistContext) FAPPEND(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserFAPPEND, i)
 }
 
-func (s *Or_expressionContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *Or_expressionContext) AllAnd_expression() []IAnd_expressionContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAnd_expressionContext)(nil)).Elem())
-	var tst = make([]IAnd_expressionContext, len(ts))
+func (s *SlistContext) AllFappend() []IFappendContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFappendContext)(nil)).Elem())
+	var tst = make([]IFappendContext, len(ts))
 
 	for i, t := range ts {
 		if t != nil {
-			tst[i] = t.(IAnd_expressionContext)
+			tst[i] = t.(IFappendContext)
 		}
 	}
 
 	return tst
 }
 
-func (s *Or_expressionContext) And_expression(i int) IAnd_expressionContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAnd_expressionContext)(nil)).Elem(), i)
+func (s *SlistContext) Fappend(i int) IFappendContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFappendContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IAnd_expressionContext)
-}
-
-func (s *Or_expressionContext) AllOR() []antlr.TerminalNode {
-	return s.GetTokens(SfplParserOR)
-}
-
-func (s *Or_expressionContext) OR(i int) antlr.TerminalNode {
-	return s.GetToken(SfplParserOR, i)
+	return t.(IFappendContext)
 }
 
-func (s *Or_expressionContext) GetRuleContext() antlr.RuleContext {
+func (s *SlistContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *Or_expressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *SlistContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *Or_expressionContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *SlistContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterOr_expression(s)
+		listenerT.EnterSlist(s)
 	}
 }
 
-func (s *Or_expressionContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *SlistContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitOr_expression(s)
+		listenerT.ExitSlist(s)
 	}
 }
 
-func (s *Or_expressionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *SlistContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitOr_expression(s)
+		return t.VisitSlist(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Or_expression() (localctx IOr_expressionContext) {
-	localctx = NewOr_expressionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, SfplParserRULE_or_expression)
+func (p *SfplParser) Slist() (localctx ISlistContext) {
+	localctx = NewSlistContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 18, SfplParserRULE_slist)
 	var _la int
 
 	defer func() {
@@ -2927,134 +3552,183 @@ func (p *SfplParser) Or_expression() (localctx IOr_expressionContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(198)
-		p.And_expression()
+		p.SetState(269)
+		p.Match(SfplParserDECL)
+	}
+	{
+		p.SetState(270)
+		p.Match(SfplParserLIST)
+	}
+	{
+		p.SetState(271)
+		p.Match(SfplParserDEF)
+	}
+	{
+		p.SetState(272)
+		p.Match(SfplParserID)
 	}
-	p.SetState(203)
+	p.SetState(276)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for _la == SfplParserOR {
+	if _la == SfplParserFAPPEND {
 		{
-			p.SetState(199)
-			p.Match(SfplParserOR)
+			p.SetState(273)
+			p.Match(SfplParserFAPPEND)
 		}
 		{
-			p.SetState(200)
-			p.And_expression()
+			p.SetState(274)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(275)
+			p.Fappend()
+		}
+
+	}
+	p.SetState(282)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserITEMS:
+		{
+			p.SetState(278)
+			p.Match(SfplParserITEMS)
+		}
+		{
+			p.SetState(279)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(280)
+			p.Items()
+		}
+
+	case SfplParserSOURCE, SfplParserFORMAT, SfplParserCOLUMN:
+		{
+			p.SetState(281)
+			p.Listsource()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+	p.SetState(287)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserFAPPEND {
+		{
+			p.SetState(284)
+			p.Match(SfplParserFAPPEND)
+		}
+		{
+			p.SetState(285)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(286)
+			p.Fappend()
 		}
 
-		p.SetState(205)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
 	}
 
 	return localctx
 }
 
-// IAnd_expressionContext is an interface to support dynamic dispatch.
-type IAnd_expressionContext interface {
+// IPreqContext is an interface to support dynamic dispatch.
+type IPreqContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsAnd_expressionContext differentiates from other interfaces.
-	IsAnd_expressionContext()
+	// IsPreqContext differentiates from other interfaces.
+	IsPreqContext()
 }
 
-type And_expressionContext struct {
+type PreqContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyAnd_expressionContext() *And_expressionContext {
-	var p = new(And_expressionContext)
+func NewEmptyPreqContext() *PreqContext {
+	var p = new(PreqContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_and_expression
+	p.RuleIndex = SfplParserRULE_preq
 	return p
 }
 
-func (*And_expressionContext) IsAnd_expressionContext() {}
+func (*PreqContext) IsPreqContext() {}
 
-func NewAnd_expressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *And_expressionContext {
-	var p = new(And_expressionContext)
+func NewPreqContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PreqContext {
+	var p = new(PreqContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_and_expression
+	p.RuleIndex = SfplParserRULE_preq
 
 	return p
 }
 
-func (s *And_expressionContext) GetParser() antlr.Parser { return s.parser }
+func (s *PreqContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *And_expressionContext) AllTerm() []ITermContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ITermContext)(nil)).Elem())
-	var tst = make([]ITermContext, len(ts))
+func (s *PreqContext) DECL() antlr.TerminalNode {
+	return s.GetToken(SfplParserDECL, 0)
+}
 
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(ITermContext)
-		}
-	}
+func (s *PreqContext) REQ() antlr.TerminalNode {
+	return s.GetToken(SfplParserREQ, 0)
+}
 
-	return tst
+func (s *PreqContext) DEF() antlr.TerminalNode {
+	return s.GetToken(SfplParserDEF, 0)
 }
 
-func (s *And_expressionContext) Term(i int) ITermContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ITermContext)(nil)).Elem(), i)
+func (s *PreqContext) Atom() IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(ITermContext)
-}
-
-func (s *And_expressionContext) AllAND() []antlr.TerminalNode {
-	return s.GetTokens(SfplParserAND)
-}
-
-func (s *And_expressionContext) AND(i int) antlr.TerminalNode {
-	return s.GetToken(SfplParserAND, i)
+	return t.(IAtomContext)
 }
 
-func (s *And_expressionContext) GetRuleContext() antlr.RuleContext {
+func (s *PreqContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *And_expressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *PreqContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *And_expressionContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *PreqContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterAnd_expression(s)
+		listenerT.EnterPreq(s)
 	}
 }
 
-func (s *And_expressionContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *PreqContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitAnd_expression(s)
+		listenerT.ExitPreq(s)
 	}
 }
 
-func (s *And_expressionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *PreqContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitAnd_expression(s)
+		return t.VisitPreq(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) And_expression() (localctx IAnd_expressionContext) {
-	localctx = NewAnd_expressionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, SfplParserRULE_and_expression)
-	var _la int
+func (p *SfplParser) Preq() (localctx IPreqContext) {
+	localctx = NewPreqContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 20, SfplParserRULE_preq)
 
 	defer func() {
 		p.ExitRule()
@@ -3074,55 +3748,792 @@ func (p *SfplParser) And_expression() (localctx IAnd_expressionContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(206)
-		p.Term()
+		p.SetState(289)
+		p.Match(SfplParserDECL)
 	}
-	p.SetState(211)
-	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
-
-	for _la == SfplParserAND {
-		{
-			p.SetState(207)
-			p.Match(SfplParserAND)
-		}
-		{
-			p.SetState(208)
-			p.Term()
-		}
-
-		p.SetState(213)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
+	{
+		p.SetState(290)
+		p.Match(SfplParserREQ)
+	}
+	{
+		p.SetState(291)
+		p.Match(SfplParserDEF)
+	}
+	{
+		p.SetState(292)
+		p.Atom()
 	}
 
 	return localctx
 }
 
-// ITermContext is an interface to support dynamic dispatch.
-type ITermContext interface {
+// IListsourceContext is an interface to support dynamic dispatch.
+type IListsourceContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsTermContext differentiates from other interfaces.
-	IsTermContext()
+	// IsListsourceContext differentiates from other interfaces.
+	IsListsourceContext()
 }
 
-type TermContext struct {
+type ListsourceContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyTermContext() *TermContext {
-	var p = new(TermContext)
+func NewEmptyListsourceContext() *ListsourceContext {
+	var p = new(ListsourceContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_term
+	p.RuleIndex = SfplParserRULE_listsource
 	return p
 }
 
-func (*TermContext) IsTermContext() {}
+func (*ListsourceContext) IsListsourceContext() {}
+
+func NewListsourceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ListsourceContext {
+	var p = new(ListsourceContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_listsource
+
+	return p
+}
+
+func (s *ListsourceContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ListsourceContext) AllSOURCE() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserSOURCE)
+}
+
+func (s *ListsourceContext) SOURCE(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserSOURCE, i)
+}
+
+func (s *ListsourceContext) AllDEF() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserDEF)
+}
+
+func (s *ListsourceContext) DEF(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserDEF, i)
+}
+
+func (s *ListsourceContext) AllAtom() []IAtomContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
+	var tst = make([]IAtomContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IAtomContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ListsourceContext) Atom(i int) IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *ListsourceContext) AllFORMAT() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserFORMAT)
+}
+
+func (s *ListsourceContext) FORMAT(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserFORMAT, i)
+}
+
+func (s *ListsourceContext) AllCOLUMN() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserCOLUMN)
+}
+
+func (s *ListsourceContext) COLUMN(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserCOLUMN, i)
+}
+
+func (s *ListsourceContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ListsourceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ListsourceContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterListsource(s)
+	}
+}
+
+func (s *ListsourceContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitListsource(s)
+	}
+}
+
+func (s *ListsourceContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitListsource(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Listsource() (localctx IListsourceContext) {
+	localctx = NewListsourceContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 22, SfplParserRULE_listsource)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(303)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for ok := true; ok; ok = (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserSOURCE)|(1<<SfplParserFORMAT)|(1<<SfplParserCOLUMN))) != 0) {
+		p.SetState(303)
+		p.GetErrorHandler().Sync(p)
+
+		switch p.GetTokenStream().LA(1) {
+		case SfplParserSOURCE:
+			{
+				p.SetState(294)
+				p.Match(SfplParserSOURCE)
+			}
+			{
+				p.SetState(295)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(296)
+				p.Atom()
+			}
+
+		case SfplParserFORMAT:
+			{
+				p.SetState(297)
+				p.Match(SfplParserFORMAT)
+			}
+			{
+				p.SetState(298)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(299)
+				p.Atom()
+			}
+
+		case SfplParserCOLUMN:
+			{
+				p.SetState(300)
+				p.Match(SfplParserCOLUMN)
+			}
+			{
+				p.SetState(301)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(302)
+				p.Atom()
+			}
+
+		default:
+			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		}
+
+		p.SetState(305)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+	return localctx
+}
+
+// IConditionContext is an interface to support dynamic dispatch.
+type IConditionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsConditionContext differentiates from other interfaces.
+	IsConditionContext()
+}
+
+type ConditionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyConditionContext() *ConditionContext {
+	var p = new(ConditionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_condition
+	return p
+}
+
+func (*ConditionContext) IsConditionContext() {}
+
+func NewConditionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ConditionContext {
+	var p = new(ConditionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_condition
+
+	return p
+}
+
+func (s *ConditionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ConditionContext) Expression() IExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExpressionContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *ConditionContext) AND() antlr.TerminalNode {
+	return s.GetToken(SfplParserAND, 0)
+}
+
+func (s *ConditionContext) OR() antlr.TerminalNode {
+	return s.GetToken(SfplParserOR, 0)
+}
+
+func (s *ConditionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ConditionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ConditionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterCondition(s)
+	}
+}
+
+func (s *ConditionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitCondition(s)
+	}
+}
+
+func (s *ConditionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitCondition(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Condition() (localctx IConditionContext) {
+	localctx = NewConditionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 24, SfplParserRULE_condition)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(308)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserAND || _la == SfplParserOR {
+		{
+			p.SetState(307)
+			_la = p.GetTokenStream().LA(1)
+
+			if !(_la == SfplParserAND || _la == SfplParserOR) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+
+	}
+	{
+		p.SetState(310)
+		p.Expression()
+	}
+
+	return localctx
+}
+
+// IExpressionContext is an interface to support dynamic dispatch.
+type IExpressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsExpressionContext differentiates from other interfaces.
+	IsExpressionContext()
+}
+
+type ExpressionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyExpressionContext() *ExpressionContext {
+	var p = new(ExpressionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_expression
+	return p
+}
+
+func (*ExpressionContext) IsExpressionContext() {}
+
+func NewExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ExpressionContext {
+	var p = new(ExpressionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_expression
+
+	return p
+}
+
+func (s *ExpressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ExpressionContext) Or_expression() IOr_expressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IOr_expressionContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IOr_expressionContext)
+}
+
+func (s *ExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterExpression(s)
+	}
+}
+
+func (s *ExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitExpression(s)
+	}
+}
+
+func (s *ExpressionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitExpression(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Expression() (localctx IExpressionContext) {
+	localctx = NewExpressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 26, SfplParserRULE_expression)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(312)
+		p.Or_expression()
+	}
+
+	return localctx
+}
+
+// IOr_expressionContext is an interface to support dynamic dispatch.
+type IOr_expressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsOr_expressionContext differentiates from other interfaces.
+	IsOr_expressionContext()
+}
+
+type Or_expressionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyOr_expressionContext() *Or_expressionContext {
+	var p = new(Or_expressionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_or_expression
+	return p
+}
+
+func (*Or_expressionContext) IsOr_expressionContext() {}
+
+func NewOr_expressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *Or_expressionContext {
+	var p = new(Or_expressionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_or_expression
+
+	return p
+}
+
+func (s *Or_expressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *Or_expressionContext) AllAnd_expression() []IAnd_expressionContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAnd_expressionContext)(nil)).Elem())
+	var tst = make([]IAnd_expressionContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IAnd_expressionContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *Or_expressionContext) And_expression(i int) IAnd_expressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAnd_expressionContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAnd_expressionContext)
+}
+
+func (s *Or_expressionContext) AllOR() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserOR)
+}
+
+func (s *Or_expressionContext) OR(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserOR, i)
+}
+
+func (s *Or_expressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *Or_expressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *Or_expressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterOr_expression(s)
+	}
+}
+
+func (s *Or_expressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitOr_expression(s)
+	}
+}
+
+func (s *Or_expressionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitOr_expression(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Or_expression() (localctx IOr_expressionContext) {
+	localctx = NewOr_expressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 28, SfplParserRULE_or_expression)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(314)
+		p.And_expression()
+	}
+	p.SetState(319)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == SfplParserOR {
+		{
+			p.SetState(315)
+			p.Match(SfplParserOR)
+		}
+		{
+			p.SetState(316)
+			p.And_expression()
+		}
+
+		p.SetState(321)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+	return localctx
+}
+
+// IAnd_expressionContext is an interface to support dynamic dispatch.
+type IAnd_expressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsAnd_expressionContext differentiates from other interfaces.
+	IsAnd_expressionContext()
+}
+
+type And_expressionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyAnd_expressionContext() *And_expressionContext {
+	var p = new(And_expressionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_and_expression
+	return p
+}
+
+func (*And_expressionContext) IsAnd_expressionContext() {}
+
+func NewAnd_expressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *And_expressionContext {
+	var p = new(And_expressionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_and_expression
+
+	return p
+}
+
+func (s *And_expressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *And_expressionContext) AllTerm() []ITermContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ITermContext)(nil)).Elem())
+	var tst = make([]ITermContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(ITermContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *And_expressionContext) Term(i int) ITermContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ITermContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITermContext)
+}
+
+func (s *And_expressionContext) AllAND() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserAND)
+}
+
+func (s *And_expressionContext) AND(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserAND, i)
+}
+
+func (s *And_expressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *And_expressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *And_expressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterAnd_expression(s)
+	}
+}
+
+func (s *And_expressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitAnd_expression(s)
+	}
+}
+
+func (s *And_expressionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitAnd_expression(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) And_expression() (localctx IAnd_expressionContext) {
+	localctx = NewAnd_expressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 30, SfplParserRULE_and_expression)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(322)
+		p.Term()
+	}
+	p.SetState(327)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == SfplParserAND {
+		{
+			p.SetState(323)
+			p.Match(SfplParserAND)
+		}
+		{
+			p.SetState(324)
+			p.Term()
+		}
+
+		p.SetState(329)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+	return localctx
+}
+
+// ITermContext is an interface to support dynamic dispatch.
+type ITermContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsTermContext differentiates from other interfaces.
+	IsTermContext()
+}
+
+type TermContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyTermContext() *TermContext {
+	var p = new(TermContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_term
+	return p
+}
+
+func (*TermContext) IsTermContext() {}
 
 func NewTermContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *TermContext {
 	var p = new(TermContext)
@@ -3130,170 +4541,1524 @@ func NewTermContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokin
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_term
+	p.RuleIndex = SfplParserRULE_term
+
+	return p
+}
+
+func (s *TermContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *TermContext) Variable() IVariableContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IVariableContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IVariableContext)
+}
+
+func (s *TermContext) NOT() antlr.TerminalNode {
+	return s.GetToken(SfplParserNOT, 0)
+}
+
+func (s *TermContext) Term() ITermContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ITermContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITermContext)
+}
+
+func (s *TermContext) AllAtom() []IAtomContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
+	var tst = make([]IAtomContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IAtomContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *TermContext) Atom(i int) IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *TermContext) Unary_operator() IUnary_operatorContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IUnary_operatorContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IUnary_operatorContext)
+}
+
+func (s *TermContext) Binary_operator() IBinary_operatorContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IBinary_operatorContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IBinary_operatorContext)
+}
+
+func (s *TermContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(SfplParserLPAREN, 0)
+}
+
+func (s *TermContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(SfplParserRPAREN, 0)
+}
+
+func (s *TermContext) IN() antlr.TerminalNode {
+	return s.GetToken(SfplParserIN, 0)
+}
+
+func (s *TermContext) PMATCH() antlr.TerminalNode {
+	return s.GetToken(SfplParserPMATCH, 0)
+}
+
+func (s *TermContext) INCIDR() antlr.TerminalNode {
+	return s.GetToken(SfplParserINCIDR, 0)
+}
+
+func (s *TermContext) AllItems() []IItemsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IItemsContext)(nil)).Elem())
+	var tst = make([]IItemsContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IItemsContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *TermContext) Items(i int) IItemsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IItemsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IItemsContext)
+}
+
+func (s *TermContext) AllLISTSEP() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserLISTSEP)
+}
+
+func (s *TermContext) LISTSEP(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserLISTSEP, i)
+}
+
+func (s *TermContext) ANCESTOR() antlr.TerminalNode {
+	return s.GetToken(SfplParserANCESTOR, 0)
+}
+
+func (s *TermContext) Expression() IExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExpressionContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *TermContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TermContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *TermContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterTerm(s)
+	}
+}
+
+func (s *TermContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitTerm(s)
+	}
+}
+
+func (s *TermContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitTerm(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Term() (localctx ITermContext) {
+	localctx = NewTermContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 32, SfplParserRULE_term)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(368)
+	p.GetErrorHandler().Sync(p)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 28, p.GetParserRuleContext()) {
+	case 1:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(330)
+			p.Variable()
+		}
+
+	case 2:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(331)
+			p.Match(SfplParserNOT)
+		}
+		{
+			p.SetState(332)
+			p.Term()
+		}
+
+	case 3:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(333)
+			p.Atom()
+		}
+		{
+			p.SetState(334)
+			p.Unary_operator()
+		}
+
+	case 4:
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(336)
+			p.Atom()
+		}
+		{
+			p.SetState(337)
+			p.Binary_operator()
+		}
+		{
+			p.SetState(338)
+			p.Atom()
+		}
+
+	case 5:
+		p.EnterOuterAlt(localctx, 5)
+		{
+			p.SetState(340)
+			p.Atom()
+		}
+		{
+			p.SetState(341)
+			_la = p.GetTokenStream().LA(1)
+
+			if !(((_la-36)&-(0x1f+1)) == 0 && ((1<<uint((_la-36)))&((1<<(SfplParserIN-36))|(1<<(SfplParserPMATCH-36))|(1<<(SfplParserINCIDR-36)))) != 0) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+		{
+			p.SetState(342)
+			p.Match(SfplParserLPAREN)
+		}
+		p.SetState(345)
+		p.GetErrorHandler().Sync(p)
+
+		switch p.GetTokenStream().LA(1) {
+		case SfplParserEXCEPTIONS, SfplParserFIELDS, SfplParserCOMPS, SfplParserVALUES, SfplParserSOURCE, SfplParserFORMAT, SfplParserCOLUMN, SfplParserSCORE, SfplParserLT, SfplParserGT, SfplParserSEVERITY, SfplParserCIDR, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
+			{
+				p.SetState(343)
+				p.Atom()
+			}
+
+		case SfplParserLBRACK:
+			{
+				p.SetState(344)
+				p.Items()
+			}
+
+		default:
+			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		}
+		p.SetState(354)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		for _la == SfplParserLISTSEP {
+			{
+				p.SetState(347)
+				p.Match(SfplParserLISTSEP)
+			}
+			p.SetState(350)
+			p.GetErrorHandler().Sync(p)
+
+			switch p.GetTokenStream().LA(1) {
+			case SfplParserEXCEPTIONS, SfplParserFIELDS, SfplParserCOMPS, SfplParserVALUES, SfplParserSOURCE, SfplParserFORMAT, SfplParserCOLUMN, SfplParserSCORE, SfplParserLT, SfplParserGT, SfplParserSEVERITY, SfplParserCIDR, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
+				{
+					p.SetState(348)
+					p.Atom()
+				}
+
+			case SfplParserLBRACK:
+				{
+					p.SetState(349)
+					p.Items()
+				}
+
+			default:
+				panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+			}
+
+			p.SetState(356)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
+		}
+		{
+			p.SetState(357)
+			p.Match(SfplParserRPAREN)
+		}
+
+	case 6:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(359)
+			p.Match(SfplParserANCESTOR)
+		}
+		{
+			p.SetState(360)
+			p.Match(SfplParserLPAREN)
+		}
+		{
+			p.SetState(361)
+			p.Expression()
+		}
+		{
+			p.SetState(362)
+			p.Match(SfplParserRPAREN)
+		}
+
+	case 7:
+		p.EnterOuterAlt(localctx, 7)
+		{
+			p.SetState(364)
+			p.Match(SfplParserLPAREN)
+		}
+		{
+			p.SetState(365)
+			p.Expression()
+		}
+		{
+			p.SetState(366)
+			p.Match(SfplParserRPAREN)
+		}
+
+	}
+
+	return localctx
+}
+
+// IItemsContext is an interface to support dynamic dispatch.
+type IItemsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsItemsContext differentiates from other interfaces.
+	IsItemsContext()
+}
+
+type ItemsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyItemsContext() *ItemsContext {
+	var p = new(ItemsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_items
+	return p
+}
+
+func (*ItemsContext) IsItemsContext() {}
+
+func NewItemsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ItemsContext {
+	var p = new(ItemsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_items
+
+	return p
+}
+
+func (s *ItemsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ItemsContext) LBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserLBRACK, 0)
+}
+
+func (s *ItemsContext) RBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserRBRACK, 0)
+}
+
+func (s *ItemsContext) AllAtom() []IAtomContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
+	var tst = make([]IAtomContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IAtomContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ItemsContext) Atom(i int) IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *ItemsContext) AllLISTSEP() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserLISTSEP)
+}
+
+func (s *ItemsContext) LISTSEP(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserLISTSEP, i)
+}
+
+func (s *ItemsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ItemsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ItemsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterItems(s)
+	}
+}
+
+func (s *ItemsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitItems(s)
+	}
+}
+
+func (s *ItemsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitItems(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Items() (localctx IItemsContext) {
+	localctx = NewItemsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 34, SfplParserRULE_items)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(370)
+		p.Match(SfplParserLBRACK)
+	}
+	p.SetState(379)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserEXCEPTIONS)|(1<<SfplParserFIELDS)|(1<<SfplParserCOMPS)|(1<<SfplParserVALUES)|(1<<SfplParserSOURCE)|(1<<SfplParserFORMAT)|(1<<SfplParserCOLUMN)|(1<<SfplParserSCORE)|(1<<SfplParserLT))) != 0) || (((_la-32)&-(0x1f+1)) == 0 && ((1<<uint((_la-32)))&((1<<(SfplParserGT-32))|(1<<(SfplParserSEVERITY-32))|(1<<(SfplParserCIDR-32))|(1<<(SfplParserID-32))|(1<<(SfplParserNUMBER-32))|(1<<(SfplParserPATH-32))|(1<<(SfplParserSTRING-32))|(1<<(SfplParserTAG-32)))) != 0) {
+		{
+			p.SetState(371)
+			p.Atom()
+		}
+		p.SetState(376)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 29, p.GetParserRuleContext())
+
+		for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+			if _alt == 1 {
+				{
+					p.SetState(372)
+					p.Match(SfplParserLISTSEP)
+				}
+				{
+					p.SetState(373)
+					p.Atom()
+				}
+
+			}
+			p.SetState(378)
+			p.GetErrorHandler().Sync(p)
+			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 29, p.GetParserRuleContext())
+		}
+
+	}
+	p.SetState(382)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserLISTSEP {
+		{
+			p.SetState(381)
+			p.Match(SfplParserLISTSEP)
+		}
+
+	}
+	{
+		p.SetState(384)
+		p.Match(SfplParserRBRACK)
+	}
+
+	return localctx
+}
+
+// ITagsContext is an interface to support dynamic dispatch.
+type ITagsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsTagsContext differentiates from other interfaces.
+	IsTagsContext()
+}
+
+type TagsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyTagsContext() *TagsContext {
+	var p = new(TagsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_tags
+	return p
+}
+
+func (*TagsContext) IsTagsContext() {}
+
+func NewTagsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *TagsContext {
+	var p = new(TagsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_tags
+
+	return p
+}
+
+func (s *TagsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *TagsContext) LBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserLBRACK, 0)
+}
+
+func (s *TagsContext) RBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserRBRACK, 0)
+}
+
+func (s *TagsContext) AllAtom() []IAtomContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
+	var tst = make([]IAtomContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IAtomContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *TagsContext) Atom(i int) IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *TagsContext) AllLISTSEP() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserLISTSEP)
+}
+
+func (s *TagsContext) LISTSEP(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserLISTSEP, i)
+}
+
+func (s *TagsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TagsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *TagsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterTags(s)
+	}
+}
+
+func (s *TagsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitTags(s)
+	}
+}
+
+func (s *TagsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitTags(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Tags() (localctx ITagsContext) {
+	localctx = NewTagsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 36, SfplParserRULE_tags)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(386)
+		p.Match(SfplParserLBRACK)
+	}
+	p.SetState(395)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserEXCEPTIONS)|(1<<SfplParserFIELDS)|(1<<SfplParserCOMPS)|(1<<SfplParserVALUES)|(1<<SfplParserSOURCE)|(1<<SfplParserFORMAT)|(1<<SfplParserCOLUMN)|(1<<SfplParserSCORE)|(1<<SfplParserLT))) != 0) || (((_la-32)&-(0x1f+1)) == 0 && ((1<<uint((_la-32)))&((1<<(SfplParserGT-32))|(1<<(SfplParserSEVERITY-32))|(1<<(SfplParserCIDR-32))|(1<<(SfplParserID-32))|(1<<(SfplParserNUMBER-32))|(1<<(SfplParserPATH-32))|(1<<(SfplParserSTRING-32))|(1<<(SfplParserTAG-32)))) != 0) {
+		{
+			p.SetState(387)
+			p.Atom()
+		}
+		p.SetState(392)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 32, p.GetParserRuleContext())
+
+		for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+			if _alt == 1 {
+				{
+					p.SetState(388)
+					p.Match(SfplParserLISTSEP)
+				}
+				{
+					p.SetState(389)
+					p.Atom()
+				}
+
+			}
+			p.SetState(394)
+			p.GetErrorHandler().Sync(p)
+			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 32, p.GetParserRuleContext())
+		}
+
+	}
+	p.SetState(398)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserLISTSEP {
+		{
+			p.SetState(397)
+			p.Match(SfplParserLISTSEP)
+		}
+
+	}
+	{
+		p.SetState(400)
+		p.Match(SfplParserRBRACK)
+	}
+
+	return localctx
+}
+
+// IPrefilterContext is an interface to support dynamic dispatch.
+type IPrefilterContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsPrefilterContext differentiates from other interfaces.
+	IsPrefilterContext()
+}
+
+type PrefilterContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyPrefilterContext() *PrefilterContext {
+	var p = new(PrefilterContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_prefilter
+	return p
+}
+
+func (*PrefilterContext) IsPrefilterContext() {}
+
+func NewPrefilterContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PrefilterContext {
+	var p = new(PrefilterContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_prefilter
+
+	return p
+}
+
+func (s *PrefilterContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *PrefilterContext) Items() IItemsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IItemsContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IItemsContext)
+}
+
+func (s *PrefilterContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *PrefilterContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *PrefilterContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterPrefilter(s)
+	}
+}
+
+func (s *PrefilterContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitPrefilter(s)
+	}
+}
+
+func (s *PrefilterContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitPrefilter(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Prefilter() (localctx IPrefilterContext) {
+	localctx = NewPrefilterContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 38, SfplParserRULE_prefilter)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(402)
+		p.Items()
+	}
+
+	return localctx
+}
+
+// IExceptionsContext is an interface to support dynamic dispatch.
+type IExceptionsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsExceptionsContext differentiates from other interfaces.
+	IsExceptionsContext()
+}
+
+type ExceptionsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyExceptionsContext() *ExceptionsContext {
+	var p = new(ExceptionsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_exceptions
+	return p
+}
+
+func (*ExceptionsContext) IsExceptionsContext() {}
+
+func NewExceptionsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ExceptionsContext {
+	var p = new(ExceptionsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_exceptions
+
+	return p
+}
+
+func (s *ExceptionsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ExceptionsContext) AllException() []IExceptionContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IExceptionContext)(nil)).Elem())
+	var tst = make([]IExceptionContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IExceptionContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ExceptionsContext) Exception(i int) IExceptionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExceptionContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExceptionContext)
+}
+
+func (s *ExceptionsContext) LBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserLBRACK, 0)
+}
+
+func (s *ExceptionsContext) RBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserRBRACK, 0)
+}
+
+func (s *ExceptionsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExceptionsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ExceptionsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterExceptions(s)
+	}
+}
+
+func (s *ExceptionsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitExceptions(s)
+	}
+}
+
+func (s *ExceptionsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitExceptions(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Exceptions() (localctx IExceptionsContext) {
+	localctx = NewExceptionsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 40, SfplParserRULE_exceptions)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	var _alt int
+
+	p.SetState(412)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserEOF, SfplParserCOND, SfplParserDESC, SfplParserACTION, SfplParserOUTPUT, SfplParserPRIORITY, SfplParserTAGS, SfplParserPREFILTER, SfplParserENABLED, SfplParserWARNEVTTYPE, SfplParserSKIPUNKNOWN, SfplParserFAPPEND, SfplParserEXCEPTIONS, SfplParserSCORE, SfplParserDECL:
+		p.EnterOuterAlt(localctx, 1)
+		p.SetState(407)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 35, p.GetParserRuleContext())
+
+		for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+			if _alt == 1 {
+				{
+					p.SetState(404)
+					p.Exception()
+				}
+
+			}
+			p.SetState(409)
+			p.GetErrorHandler().Sync(p)
+			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 35, p.GetParserRuleContext())
+		}
+
+	case SfplParserLBRACK:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(410)
+			p.Match(SfplParserLBRACK)
+		}
+		{
+			p.SetState(411)
+			p.Match(SfplParserRBRACK)
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
+// IExceptionContext is an interface to support dynamic dispatch.
+type IExceptionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsExceptionContext differentiates from other interfaces.
+	IsExceptionContext()
+}
+
+type ExceptionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyExceptionContext() *ExceptionContext {
+	var p = new(ExceptionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_exception
+	return p
+}
+
+func (*ExceptionContext) IsExceptionContext() {}
+
+func NewExceptionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ExceptionContext {
+	var p = new(ExceptionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_exception
+
+	return p
+}
+
+func (s *ExceptionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ExceptionContext) DECL() antlr.TerminalNode {
+	return s.GetToken(SfplParserDECL, 0)
+}
+
+func (s *ExceptionContext) NAME() antlr.TerminalNode {
+	return s.GetToken(SfplParserNAME, 0)
+}
+
+func (s *ExceptionContext) AllDEF() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserDEF)
+}
+
+func (s *ExceptionContext) DEF(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserDEF, i)
+}
+
+func (s *ExceptionContext) ID() antlr.TerminalNode {
+	return s.GetToken(SfplParserID, 0)
+}
+
+func (s *ExceptionContext) AllFIELDS() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserFIELDS)
+}
+
+func (s *ExceptionContext) FIELDS(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserFIELDS, i)
+}
+
+func (s *ExceptionContext) AllFields() []IFieldsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFieldsContext)(nil)).Elem())
+	var tst = make([]IFieldsContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFieldsContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ExceptionContext) Fields(i int) IFieldsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFieldsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFieldsContext)
+}
+
+func (s *ExceptionContext) AllCOMPS() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserCOMPS)
+}
+
+func (s *ExceptionContext) COMPS(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserCOMPS, i)
+}
+
+func (s *ExceptionContext) AllComps() []ICompsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ICompsContext)(nil)).Elem())
+	var tst = make([]ICompsContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(ICompsContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ExceptionContext) Comps(i int) ICompsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICompsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICompsContext)
+}
+
+func (s *ExceptionContext) AllVALUES() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserVALUES)
+}
+
+func (s *ExceptionContext) VALUES(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserVALUES, i)
+}
+
+func (s *ExceptionContext) AllValues() []IValuesContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IValuesContext)(nil)).Elem())
+	var tst = make([]IValuesContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IValuesContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ExceptionContext) Values(i int) IValuesContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IValuesContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IValuesContext)
+}
+
+func (s *ExceptionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExceptionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ExceptionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterException(s)
+	}
+}
+
+func (s *ExceptionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitException(s)
+	}
+}
+
+func (s *ExceptionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitException(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Exception() (localctx IExceptionContext) {
+	localctx = NewExceptionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 42, SfplParserRULE_exception)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(414)
+		p.Match(SfplParserDECL)
+	}
+	{
+		p.SetState(415)
+		p.Match(SfplParserNAME)
+	}
+	{
+		p.SetState(416)
+		p.Match(SfplParserDEF)
+	}
+	{
+		p.SetState(417)
+		p.Match(SfplParserID)
+	}
+	p.SetState(429)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for ((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserFIELDS)|(1<<SfplParserCOMPS)|(1<<SfplParserVALUES))) != 0 {
+		p.SetState(427)
+		p.GetErrorHandler().Sync(p)
+
+		switch p.GetTokenStream().LA(1) {
+		case SfplParserFIELDS:
+			{
+				p.SetState(418)
+				p.Match(SfplParserFIELDS)
+			}
+			{
+				p.SetState(419)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(420)
+				p.Fields()
+			}
+
+		case SfplParserCOMPS:
+			{
+				p.SetState(421)
+				p.Match(SfplParserCOMPS)
+			}
+			{
+				p.SetState(422)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(423)
+				p.Comps()
+			}
+
+		case SfplParserVALUES:
+			{
+				p.SetState(424)
+				p.Match(SfplParserVALUES)
+			}
+			{
+				p.SetState(425)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(426)
+				p.Values()
+			}
+
+		default:
+			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		}
+
+		p.SetState(431)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+	return localctx
+}
+
+// IFieldsContext is an interface to support dynamic dispatch.
+type IFieldsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsFieldsContext differentiates from other interfaces.
+	IsFieldsContext()
+}
+
+type FieldsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyFieldsContext() *FieldsContext {
+	var p = new(FieldsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_fields
+	return p
+}
+
+func (*FieldsContext) IsFieldsContext() {}
+
+func NewFieldsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *FieldsContext {
+	var p = new(FieldsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_fields
 
 	return p
 }
 
-func (s *TermContext) GetParser() antlr.Parser { return s.parser }
+func (s *FieldsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *FieldsContext) Items() IItemsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IItemsContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IItemsContext)
+}
+
+func (s *FieldsContext) Atom() IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *FieldsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *FieldsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *FieldsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterFields(s)
+	}
+}
+
+func (s *FieldsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitFields(s)
+	}
+}
 
-func (s *TermContext) Variable() IVariableContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IVariableContext)(nil)).Elem(), 0)
+func (s *FieldsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitFields(s)
 
-	if t == nil {
-		return nil
+	default:
+		return t.VisitChildren(s)
 	}
-
-	return t.(IVariableContext)
 }
 
-func (s *TermContext) NOT() antlr.TerminalNode {
-	return s.GetToken(SfplParserNOT, 0)
-}
+func (p *SfplParser) Fields() (localctx IFieldsContext) {
+	localctx = NewFieldsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 44, SfplParserRULE_fields)
 
-func (s *TermContext) Term() ITermContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*ITermContext)(nil)).Elem(), 0)
+	defer func() {
+		p.ExitRule()
+	}()
 
-	if t == nil {
-		return nil
-	}
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
 
-	return t.(ITermContext)
-}
+	p.SetState(434)
+	p.GetErrorHandler().Sync(p)
 
-func (s *TermContext) AllAtom() []IAtomContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
-	var tst = make([]IAtomContext, len(ts))
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserLBRACK:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(432)
+			p.Items()
+		}
 
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(IAtomContext)
+	case SfplParserEXCEPTIONS, SfplParserFIELDS, SfplParserCOMPS, SfplParserVALUES, SfplParserSOURCE, SfplParserFORMAT, SfplParserCOLUMN, SfplParserSCORE, SfplParserLT, SfplParserGT, SfplParserSEVERITY, SfplParserCIDR, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(433)
+			p.Atom()
 		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
-	return tst
+	return localctx
 }
 
-func (s *TermContext) Atom(i int) IAtomContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
+// ICompsContext is an interface to support dynamic dispatch.
+type ICompsContext interface {
+	antlr.ParserRuleContext
 
-	if t == nil {
-		return nil
-	}
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
 
-	return t.(IAtomContext)
+	// IsCompsContext differentiates from other interfaces.
+	IsCompsContext()
 }
 
-func (s *TermContext) Unary_operator() IUnary_operatorContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IUnary_operatorContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
+type CompsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
 
-	return t.(IUnary_operatorContext)
+func NewEmptyCompsContext() *CompsContext {
+	var p = new(CompsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_comps
+	return p
 }
 
-func (s *TermContext) Binary_operator() IBinary_operatorContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IBinary_operatorContext)(nil)).Elem(), 0)
+func (*CompsContext) IsCompsContext() {}
 
-	if t == nil {
-		return nil
-	}
+func NewCompsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CompsContext {
+	var p = new(CompsContext)
 
-	return t.(IBinary_operatorContext)
-}
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
-func (s *TermContext) LPAREN() antlr.TerminalNode {
-	return s.GetToken(SfplParserLPAREN, 0)
-}
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_comps
 
-func (s *TermContext) RPAREN() antlr.TerminalNode {
-	return s.GetToken(SfplParserRPAREN, 0)
+	return p
 }
 
-func (s *TermContext) IN() antlr.TerminalNode {
-	return s.GetToken(SfplParserIN, 0)
+func (s *CompsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CompsContext) LBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserLBRACK, 0)
 }
 
-func (s *TermContext) PMATCH() antlr.TerminalNode {
-	return s.GetToken(SfplParserPMATCH, 0)
+func (s *CompsContext) RBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserRBRACK, 0)
 }
 
-func (s *TermContext) AllItems() []IItemsContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IItemsContext)(nil)).Elem())
-	var tst = make([]IItemsContext, len(ts))
+func (s *CompsContext) AllComp() []ICompContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ICompContext)(nil)).Elem())
+	var tst = make([]ICompContext, len(ts))
 
 	for i, t := range ts {
 		if t != nil {
-			tst[i] = t.(IItemsContext)
+			tst[i] = t.(ICompContext)
 		}
 	}
 
 	return tst
 }
 
-func (s *TermContext) Items(i int) IItemsContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IItemsContext)(nil)).Elem(), i)
+func (s *CompsContext) Comp(i int) ICompContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICompContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IItemsContext)
+	return t.(ICompContext)
 }
 
-func (s *TermContext) AllLISTSEP() []antlr.TerminalNode {
+func (s *CompsContext) AllLISTSEP() []antlr.TerminalNode {
 	return s.GetTokens(SfplParserLISTSEP)
 }
 
-func (s *TermContext) LISTSEP(i int) antlr.TerminalNode {
+func (s *CompsContext) LISTSEP(i int) antlr.TerminalNode {
 	return s.GetToken(SfplParserLISTSEP, i)
 }
 
-func (s *TermContext) Expression() IExpressionContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExpressionContext)(nil)).Elem(), 0)
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IExpressionContext)
-}
-
-func (s *TermContext) GetRuleContext() antlr.RuleContext {
+func (s *CompsContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *TermContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *CompsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *TermContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *CompsContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterTerm(s)
+		listenerT.EnterComps(s)
 	}
 }
 
-func (s *TermContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *CompsContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitTerm(s)
+		listenerT.ExitComps(s)
 	}
 }
 
-func (s *TermContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *CompsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitTerm(s)
+		return t.VisitComps(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Term() (localctx ITermContext) {
-	localctx = NewTermContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 24, SfplParserRULE_term)
+func (p *SfplParser) Comps() (localctx ICompsContext) {
+	localctx = NewCompsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 46, SfplParserRULE_comps)
 	var _la int
 
 	defer func() {
@@ -3312,262 +6077,172 @@ func (p *SfplParser) Term() (localctx ITermContext) {
 		}
 	}()
 
-	p.SetState(247)
-	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 16, p.GetParserRuleContext()) {
-	case 1:
-		p.EnterOuterAlt(localctx, 1)
-		{
-			p.SetState(214)
-			p.Variable()
-		}
-
-	case 2:
-		p.EnterOuterAlt(localctx, 2)
-		{
-			p.SetState(215)
-			p.Match(SfplParserNOT)
-		}
-		{
-			p.SetState(216)
-			p.Term()
-		}
-
-	case 3:
-		p.EnterOuterAlt(localctx, 3)
-		{
-			p.SetState(217)
-			p.Atom()
-		}
-		{
-			p.SetState(218)
-			p.Unary_operator()
-		}
-
-	case 4:
-		p.EnterOuterAlt(localctx, 4)
-		{
-			p.SetState(220)
-			p.Atom()
-		}
-		{
-			p.SetState(221)
-			p.Binary_operator()
-		}
-		{
-			p.SetState(222)
-			p.Atom()
-		}
+	var _alt int
 
-	case 5:
-		p.EnterOuterAlt(localctx, 5)
-		{
-			p.SetState(224)
-			p.Atom()
-		}
-		{
-			p.SetState(225)
-			_la = p.GetTokenStream().LA(1)
+	p.SetState(452)
+	p.GetErrorHandler().Sync(p)
 
-			if !(_la == SfplParserIN || _la == SfplParserPMATCH) {
-				p.GetErrorHandler().RecoverInline(p)
-			} else {
-				p.GetErrorHandler().ReportMatch(p)
-				p.Consume()
-			}
-		}
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserLBRACK:
+		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(226)
-			p.Match(SfplParserLPAREN)
+			p.SetState(436)
+			p.Match(SfplParserLBRACK)
 		}
-		p.SetState(229)
+		p.SetState(445)
 		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
 
-		switch p.GetTokenStream().LA(1) {
-		case SfplParserLT, SfplParserGT, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
+		if ((_la-30)&-(0x1f+1)) == 0 && ((1<<uint((_la-30)))&((1<<(SfplParserLT-30))|(1<<(SfplParserLE-30))|(1<<(SfplParserGT-30))|(1<<(SfplParserGE-30))|(1<<(SfplParserEQ-30))|(1<<(SfplParserNEQ-30))|(1<<(SfplParserIN-30))|(1<<(SfplParserCONTAINS-30))|(1<<(SfplParserICONTAINS-30))|(1<<(SfplParserSTARTSWITH-30))|(1<<(SfplParserENDSWITH-30))|(1<<(SfplParserPMATCH-30))|(1<<(SfplParserINCIDR-30)))) != 0 {
 			{
-				p.SetState(227)
-				p.Atom()
+				p.SetState(437)
+				p.Comp()
 			}
+			p.SetState(442)
+			p.GetErrorHandler().Sync(p)
+			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 40, p.GetParserRuleContext())
+
+			for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+				if _alt == 1 {
+					{
+						p.SetState(438)
+						p.Match(SfplParserLISTSEP)
+					}
+					{
+						p.SetState(439)
+						p.Comp()
+					}
 
-		case SfplParserLBRACK:
-			{
-				p.SetState(228)
-				p.Items()
+				}
+				p.SetState(444)
+				p.GetErrorHandler().Sync(p)
+				_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 40, p.GetParserRuleContext())
 			}
 
-		default:
-			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		}
-		p.SetState(238)
+		p.SetState(448)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 
-		for _la == SfplParserLISTSEP {
+		if _la == SfplParserLISTSEP {
 			{
-				p.SetState(231)
+				p.SetState(447)
 				p.Match(SfplParserLISTSEP)
 			}
-			p.SetState(234)
-			p.GetErrorHandler().Sync(p)
-
-			switch p.GetTokenStream().LA(1) {
-			case SfplParserLT, SfplParserGT, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
-				{
-					p.SetState(232)
-					p.Atom()
-				}
-
-			case SfplParserLBRACK:
-				{
-					p.SetState(233)
-					p.Items()
-				}
-
-			default:
-				panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
-			}
 
-			p.SetState(240)
-			p.GetErrorHandler().Sync(p)
-			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(241)
-			p.Match(SfplParserRPAREN)
+			p.SetState(450)
+			p.Match(SfplParserRBRACK)
 		}
 
-	case 6:
-		p.EnterOuterAlt(localctx, 6)
-		{
-			p.SetState(243)
-			p.Match(SfplParserLPAREN)
-		}
-		{
-			p.SetState(244)
-			p.Expression()
-		}
+	case SfplParserLT, SfplParserLE, SfplParserGT, SfplParserGE, SfplParserEQ, SfplParserNEQ, SfplParserIN, SfplParserCONTAINS, SfplParserICONTAINS, SfplParserSTARTSWITH, SfplParserENDSWITH, SfplParserPMATCH, SfplParserINCIDR:
+		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(245)
-			p.Match(SfplParserRPAREN)
+			p.SetState(451)
+			p.Comp()
 		}
 
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
 }
 
-// IItemsContext is an interface to support dynamic dispatch.
-type IItemsContext interface {
+// ICompContext is an interface to support dynamic dispatch.
+type ICompContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsItemsContext differentiates from other interfaces.
-	IsItemsContext()
+	// IsCompContext differentiates from other interfaces.
+	IsCompContext()
 }
 
-type ItemsContext struct {
+type CompContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyItemsContext() *ItemsContext {
-	var p = new(ItemsContext)
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_items
-	return p
-}
-
-func (*ItemsContext) IsItemsContext() {}
-
-func NewItemsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ItemsContext {
-	var p = new(ItemsContext)
-
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
-
-	p.parser = parser
-	p.RuleIndex = SfplParserRULE_items
-
-	return p
-}
-
-func (s *ItemsContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *ItemsContext) LBRACK() antlr.TerminalNode {
-	return s.GetToken(SfplParserLBRACK, 0)
-}
-
-func (s *ItemsContext) RBRACK() antlr.TerminalNode {
-	return s.GetToken(SfplParserRBRACK, 0)
+func NewEmptyCompContext() *CompContext {
+	var p = new(CompContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_comp
+	return p
 }
 
-func (s *ItemsContext) AllAtom() []IAtomContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
-	var tst = make([]IAtomContext, len(ts))
+func (*CompContext) IsCompContext() {}
 
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(IAtomContext)
-		}
-	}
+func NewCompContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CompContext {
+	var p = new(CompContext)
 
-	return tst
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_comp
+
+	return p
 }
 
-func (s *ItemsContext) Atom(i int) IAtomContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
+func (s *CompContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CompContext) Binary_operator() IBinary_operatorContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IBinary_operatorContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IAtomContext)
+	return t.(IBinary_operatorContext)
 }
 
-func (s *ItemsContext) AllLISTSEP() []antlr.TerminalNode {
-	return s.GetTokens(SfplParserLISTSEP)
+func (s *CompContext) IN() antlr.TerminalNode {
+	return s.GetToken(SfplParserIN, 0)
 }
 
-func (s *ItemsContext) LISTSEP(i int) antlr.TerminalNode {
-	return s.GetToken(SfplParserLISTSEP, i)
+func (s *CompContext) PMATCH() antlr.TerminalNode {
+	return s.GetToken(SfplParserPMATCH, 0)
 }
 
-func (s *ItemsContext) GetRuleContext() antlr.RuleContext {
+func (s *CompContext) INCIDR() antlr.TerminalNode {
+	return s.GetToken(SfplParserINCIDR, 0)
+}
+
+func (s *CompContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ItemsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *CompContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *ItemsContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *CompContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterItems(s)
+		listenerT.EnterComp(s)
 	}
 }
 
-func (s *ItemsContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *CompContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitItems(s)
+		listenerT.ExitComp(s)
 	}
 }
 
-func (s *ItemsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *CompContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitItems(s)
+		return t.VisitComp(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Items() (localctx IItemsContext) {
-	localctx = NewItemsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 26, SfplParserRULE_items)
-	var _la int
+func (p *SfplParser) Comp() (localctx ICompContext) {
+	localctx = NewCompContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 48, SfplParserRULE_comp)
 
 	defer func() {
 		p.ExitRule()
@@ -3585,173 +6260,163 @@ func (p *SfplParser) Items() (localctx IItemsContext) {
 		}
 	}()
 
-	var _alt int
-
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(249)
-		p.Match(SfplParserLBRACK)
-	}
-	p.SetState(258)
+	p.SetState(458)
 	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
 
-	if ((_la-22)&-(0x1f+1)) == 0 && ((1<<uint((_la-22)))&((1<<(SfplParserLT-22))|(1<<(SfplParserGT-22))|(1<<(SfplParserID-22))|(1<<(SfplParserNUMBER-22))|(1<<(SfplParserPATH-22))|(1<<(SfplParserSTRING-22))|(1<<(SfplParserTAG-22)))) != 0 {
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserLT, SfplParserLE, SfplParserGT, SfplParserGE, SfplParserEQ, SfplParserNEQ, SfplParserCONTAINS, SfplParserICONTAINS, SfplParserSTARTSWITH, SfplParserENDSWITH:
+		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(250)
-			p.Atom()
+			p.SetState(454)
+			p.Binary_operator()
 		}
-		p.SetState(255)
-		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 17, p.GetParserRuleContext())
-
-		for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
-			if _alt == 1 {
-				{
-					p.SetState(251)
-					p.Match(SfplParserLISTSEP)
-				}
-				{
-					p.SetState(252)
-					p.Atom()
-				}
 
-			}
-			p.SetState(257)
-			p.GetErrorHandler().Sync(p)
-			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 17, p.GetParserRuleContext())
+	case SfplParserIN:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(455)
+			p.Match(SfplParserIN)
 		}
 
-	}
-	p.SetState(261)
-	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
+	case SfplParserPMATCH:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(456)
+			p.Match(SfplParserPMATCH)
+		}
 
-	if _la == SfplParserLISTSEP {
+	case SfplParserINCIDR:
+		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(260)
-			p.Match(SfplParserLISTSEP)
+			p.SetState(457)
+			p.Match(SfplParserINCIDR)
 		}
 
-	}
-	{
-		p.SetState(263)
-		p.Match(SfplParserRBRACK)
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
 }
 
-// ITagsContext is an interface to support dynamic dispatch.
-type ITagsContext interface {
+// IValuesContext is an interface to support dynamic dispatch.
+type IValuesContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsTagsContext differentiates from other interfaces.
-	IsTagsContext()
+	// IsValuesContext differentiates from other interfaces.
+	IsValuesContext()
 }
 
-type TagsContext struct {
+type ValuesContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyTagsContext() *TagsContext {
-	var p = new(TagsContext)
+func NewEmptyValuesContext() *ValuesContext {
+	var p = new(ValuesContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_tags
+	p.RuleIndex = SfplParserRULE_values
 	return p
 }
 
-func (*TagsContext) IsTagsContext() {}
+func (*ValuesContext) IsValuesContext() {}
 
-func NewTagsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *TagsContext {
-	var p = new(TagsContext)
+func NewValuesContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ValuesContext {
+	var p = new(ValuesContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_tags
+	p.RuleIndex = SfplParserRULE_values
 
 	return p
 }
 
-func (s *TagsContext) GetParser() antlr.Parser { return s.parser }
+func (s *ValuesContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *TagsContext) LBRACK() antlr.TerminalNode {
+func (s *ValuesContext) LBRACK() antlr.TerminalNode {
 	return s.GetToken(SfplParserLBRACK, 0)
 }
 
-func (s *TagsContext) RBRACK() antlr.TerminalNode {
+func (s *ValuesContext) RBRACK() antlr.TerminalNode {
 	return s.GetToken(SfplParserRBRACK, 0)
 }
 
-func (s *TagsContext) AllAtom() []IAtomContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
-	var tst = make([]IAtomContext, len(ts))
+func (s *ValuesContext) AllValue() []IValueContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IValueContext)(nil)).Elem())
+	var tst = make([]IValueContext, len(ts))
 
 	for i, t := range ts {
 		if t != nil {
-			tst[i] = t.(IAtomContext)
+			tst[i] = t.(IValueContext)
 		}
 	}
 
 	return tst
 }
 
-func (s *TagsContext) Atom(i int) IAtomContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
+func (s *ValuesContext) Value(i int) IValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IValueContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IAtomContext)
+	return t.(IValueContext)
 }
 
-func (s *TagsContext) AllLISTSEP() []antlr.TerminalNode {
+func (s *ValuesContext) AllLISTSEP() []antlr.TerminalNode {
 	return s.GetTokens(SfplParserLISTSEP)
 }
 
-func (s *TagsContext) LISTSEP(i int) antlr.TerminalNode {
+func (s *ValuesContext) LISTSEP(i int) antlr.TerminalNode {
 	return s.GetToken(SfplParserLISTSEP, i)
 }
 
-func (s *TagsContext) GetRuleContext() antlr.RuleContext {
+func (s *ValuesContext) AllDECL() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserDECL)
+}
+
+func (s *ValuesContext) DECL(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserDECL, i)
+}
+
+func (s *ValuesContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *TagsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ValuesContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *TagsContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ValuesContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterTags(s)
+		listenerT.EnterValues(s)
 	}
 }
 
-func (s *TagsContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ValuesContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitTags(s)
+		listenerT.ExitValues(s)
 	}
 }
 
-func (s *TagsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *ValuesContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitTags(s)
+		return t.VisitValues(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Tags() (localctx ITagsContext) {
-	localctx = NewTagsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 28, SfplParserRULE_tags)
+func (p *SfplParser) Values() (localctx IValuesContext) {
+	localctx = NewValuesContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 50, SfplParserRULE_values)
 	var _la int
 
 	defer func() {
@@ -3772,163 +6437,302 @@ func (p *SfplParser) Tags() (localctx ITagsContext) {
 
 	var _alt int
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(265)
-		p.Match(SfplParserLBRACK)
-	}
-	p.SetState(274)
+	p.SetState(481)
 	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
 
-	if ((_la-22)&-(0x1f+1)) == 0 && ((1<<uint((_la-22)))&((1<<(SfplParserLT-22))|(1<<(SfplParserGT-22))|(1<<(SfplParserID-22))|(1<<(SfplParserNUMBER-22))|(1<<(SfplParserPATH-22))|(1<<(SfplParserSTRING-22))|(1<<(SfplParserTAG-22)))) != 0 {
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserLBRACK:
+		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(266)
-			p.Atom()
+			p.SetState(460)
+			p.Match(SfplParserLBRACK)
 		}
-		p.SetState(271)
+		p.SetState(469)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 20, p.GetParserRuleContext())
+		_la = p.GetTokenStream().LA(1)
 
-		for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
-			if _alt == 1 {
+		if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserEXCEPTIONS)|(1<<SfplParserFIELDS)|(1<<SfplParserCOMPS)|(1<<SfplParserVALUES)|(1<<SfplParserSOURCE)|(1<<SfplParserFORMAT)|(1<<SfplParserCOLUMN)|(1<<SfplParserSCORE)|(1<<SfplParserLT))) != 0) || (((_la-32)&-(0x1f+1)) == 0 && ((1<<uint((_la-32)))&((1<<(SfplParserGT-32))|(1<<(SfplParserLBRACK-32))|(1<<(SfplParserSEVERITY-32))|(1<<(SfplParserCIDR-32))|(1<<(SfplParserID-32))|(1<<(SfplParserNUMBER-32))|(1<<(SfplParserPATH-32))|(1<<(SfplParserSTRING-32))|(1<<(SfplParserTAG-32)))) != 0) {
+			{
+				p.SetState(461)
+				p.Value()
+			}
+			p.SetState(466)
+			p.GetErrorHandler().Sync(p)
+			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 45, p.GetParserRuleContext())
+
+			for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+				if _alt == 1 {
+					{
+						p.SetState(462)
+						p.Match(SfplParserLISTSEP)
+					}
+					{
+						p.SetState(463)
+						p.Value()
+					}
+
+				}
+				p.SetState(468)
+				p.GetErrorHandler().Sync(p)
+				_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 45, p.GetParserRuleContext())
+			}
+
+		}
+		p.SetState(472)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == SfplParserLISTSEP {
+			{
+				p.SetState(471)
+				p.Match(SfplParserLISTSEP)
+			}
+
+		}
+		{
+			p.SetState(474)
+			p.Match(SfplParserRBRACK)
+		}
+
+	case SfplParserDECL:
+		p.EnterOuterAlt(localctx, 2)
+		p.SetState(477)
+		p.GetErrorHandler().Sync(p)
+		_alt = 1
+		for ok := true; ok; ok = _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+			switch _alt {
+			case 1:
 				{
-					p.SetState(267)
-					p.Match(SfplParserLISTSEP)
+					p.SetState(475)
+					p.Match(SfplParserDECL)
 				}
 				{
-					p.SetState(268)
-					p.Atom()
+					p.SetState(476)
+					p.Value()
 				}
 
+			default:
+				panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 			}
-			p.SetState(273)
-			p.GetErrorHandler().Sync(p)
-			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 20, p.GetParserRuleContext())
-		}
 
-	}
-	p.SetState(277)
-	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
-
-	if _la == SfplParserLISTSEP {
-		{
-			p.SetState(276)
-			p.Match(SfplParserLISTSEP)
+			p.SetState(479)
+			p.GetErrorHandler().Sync(p)
+			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 48, p.GetParserRuleContext())
 		}
 
-	}
-	{
-		p.SetState(279)
-		p.Match(SfplParserRBRACK)
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
 }
 
-// IPrefilterContext is an interface to support dynamic dispatch.
-type IPrefilterContext interface {
+// IValueContext is an interface to support dynamic dispatch.
+type IValueContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsPrefilterContext differentiates from other interfaces.
-	IsPrefilterContext()
+	// IsValueContext differentiates from other interfaces.
+	IsValueContext()
 }
 
-type PrefilterContext struct {
+type ValueContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyPrefilterContext() *PrefilterContext {
-	var p = new(PrefilterContext)
+func NewEmptyValueContext() *ValueContext {
+	var p = new(ValueContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_prefilter
+	p.RuleIndex = SfplParserRULE_value
 	return p
 }
 
-func (*PrefilterContext) IsPrefilterContext() {}
+func (*ValueContext) IsValueContext() {}
 
-func NewPrefilterContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PrefilterContext {
-	var p = new(PrefilterContext)
+func NewValueContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ValueContext {
+	var p = new(ValueContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_prefilter
+	p.RuleIndex = SfplParserRULE_value
 
 	return p
 }
 
-func (s *PrefilterContext) GetParser() antlr.Parser { return s.parser }
+func (s *ValueContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *PrefilterContext) Items() IItemsContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IItemsContext)(nil)).Elem(), 0)
+func (s *ValueContext) LBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserLBRACK, 0)
+}
+
+func (s *ValueContext) RBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserRBRACK, 0)
+}
+
+func (s *ValueContext) AllValue() []IValueContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IValueContext)(nil)).Elem())
+	var tst = make([]IValueContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IValueContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ValueContext) Value(i int) IValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IValueContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IItemsContext)
+	return t.(IValueContext)
 }
 
-func (s *PrefilterContext) GetRuleContext() antlr.RuleContext {
+func (s *ValueContext) AllLISTSEP() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserLISTSEP)
+}
+
+func (s *ValueContext) LISTSEP(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserLISTSEP, i)
+}
+
+func (s *ValueContext) Atom() IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *ValueContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *PrefilterContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ValueContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *PrefilterContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ValueContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterPrefilter(s)
+		listenerT.EnterValue(s)
 	}
 }
 
-func (s *PrefilterContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ValueContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitPrefilter(s)
+		listenerT.ExitValue(s)
 	}
 }
 
-func (s *PrefilterContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *ValueContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitPrefilter(s)
+		return t.VisitValue(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Prefilter() (localctx IPrefilterContext) {
-	localctx = NewPrefilterContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 30, SfplParserRULE_prefilter)
+func (p *SfplParser) Value() (localctx IValueContext) {
+	localctx = NewValueContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 52, SfplParserRULE_value)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	var _alt int
+
+	p.SetState(499)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserLBRACK:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(483)
+			p.Match(SfplParserLBRACK)
+		}
+		p.SetState(492)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if (((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserEXCEPTIONS)|(1<<SfplParserFIELDS)|(1<<SfplParserCOMPS)|(1<<SfplParserVALUES)|(1<<SfplParserSOURCE)|(1<<SfplParserFORMAT)|(1<<SfplParserCOLUMN)|(1<<SfplParserSCORE)|(1<<SfplParserLT))) != 0) || (((_la-32)&-(0x1f+1)) == 0 && ((1<<uint((_la-32)))&((1<<(SfplParserGT-32))|(1<<(SfplParserLBRACK-32))|(1<<(SfplParserSEVERITY-32))|(1<<(SfplParserCIDR-32))|(1<<(SfplParserID-32))|(1<<(SfplParserNUMBER-32))|(1<<(SfplParserPATH-32))|(1<<(SfplParserSTRING-32))|(1<<(SfplParserTAG-32)))) != 0) {
+			{
+				p.SetState(484)
+				p.Value()
+			}
+			p.SetState(489)
+			p.GetErrorHandler().Sync(p)
+			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 50, p.GetParserRuleContext())
+
+			for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+				if _alt == 1 {
+					{
+						p.SetState(485)
+						p.Match(SfplParserLISTSEP)
+					}
+					{
+						p.SetState(486)
+						p.Value()
+					}
+
+				}
+				p.SetState(491)
+				p.GetErrorHandler().Sync(p)
+				_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 50, p.GetParserRuleContext())
+			}
 
-	defer func() {
-		p.ExitRule()
-	}()
+		}
+		p.SetState(495)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
 
-	defer func() {
-		if err := recover(); err != nil {
-			if v, ok := err.(antlr.RecognitionException); ok {
-				localctx.SetException(v)
-				p.GetErrorHandler().ReportError(p, v)
-				p.GetErrorHandler().Recover(p, v)
-			} else {
-				panic(err)
+		if _la == SfplParserLISTSEP {
+			{
+				p.SetState(494)
+				p.Match(SfplParserLISTSEP)
 			}
+
+		}
+		{
+			p.SetState(497)
+			p.Match(SfplParserRBRACK)
 		}
-	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(281)
-		p.Items()
+	case SfplParserEXCEPTIONS, SfplParserFIELDS, SfplParserCOMPS, SfplParserVALUES, SfplParserSOURCE, SfplParserFORMAT, SfplParserCOLUMN, SfplParserSCORE, SfplParserLT, SfplParserGT, SfplParserSEVERITY, SfplParserCIDR, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(498)
+			p.Atom()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
@@ -4008,7 +6812,7 @@ func (s *SeverityContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *SfplParser) Severity() (localctx ISeverityContext) {
 	localctx = NewSeverityContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 32, SfplParserRULE_severity)
+	p.EnterRule(localctx, 54, SfplParserRULE_severity)
 
 	defer func() {
 		p.ExitRule()
@@ -4028,7 +6832,7 @@ func (p *SfplParser) Severity() (localctx ISeverityContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(283)
+		p.SetState(501)
 		p.Match(SfplParserSEVERITY)
 	}
 
@@ -4115,7 +6919,7 @@ func (s *EnabledContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *SfplParser) Enabled() (localctx IEnabledContext) {
 	localctx = NewEnabledContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 34, SfplParserRULE_enabled)
+	p.EnterRule(localctx, 56, SfplParserRULE_enabled)
 
 	defer func() {
 		p.ExitRule()
@@ -4135,7 +6939,7 @@ func (p *SfplParser) Enabled() (localctx IEnabledContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(285)
+		p.SetState(503)
 		p.Atom()
 	}
 
@@ -4222,7 +7026,7 @@ func (s *WarnevttypeContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 func (p *SfplParser) Warnevttype() (localctx IWarnevttypeContext) {
 	localctx = NewWarnevttypeContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 36, SfplParserRULE_warnevttype)
+	p.EnterRule(localctx, 58, SfplParserRULE_warnevttype)
 
 	defer func() {
 		p.ExitRule()
@@ -4242,7 +7046,7 @@ func (p *SfplParser) Warnevttype() (localctx IWarnevttypeContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(287)
+		p.SetState(505)
 		p.Atom()
 	}
 
@@ -4329,7 +7133,7 @@ func (s *SkipunknownContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 func (p *SfplParser) Skipunknown() (localctx ISkipunknownContext) {
 	localctx = NewSkipunknownContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 38, SfplParserRULE_skipunknown)
+	p.EnterRule(localctx, 60, SfplParserRULE_skipunknown)
 
 	defer func() {
 		p.ExitRule()
@@ -4349,7 +7153,114 @@ func (p *SfplParser) Skipunknown() (localctx ISkipunknownContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(289)
+		p.SetState(507)
+		p.Atom()
+	}
+
+	return localctx
+}
+
+// IScoreContext is an interface to support dynamic dispatch.
+type IScoreContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsScoreContext differentiates from other interfaces.
+	IsScoreContext()
+}
+
+type ScoreContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyScoreContext() *ScoreContext {
+	var p = new(ScoreContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_score
+	return p
+}
+
+func (*ScoreContext) IsScoreContext() {}
+
+func NewScoreContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ScoreContext {
+	var p = new(ScoreContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_score
+
+	return p
+}
+
+func (s *ScoreContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ScoreContext) Atom() IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *ScoreContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ScoreContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ScoreContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterScore(s)
+	}
+}
+
+func (s *ScoreContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitScore(s)
+	}
+}
+
+func (s *ScoreContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitScore(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Score() (localctx IScoreContext) {
+	localctx = NewScoreContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 62, SfplParserRULE_score)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(509)
 		p.Atom()
 	}
 
@@ -4436,7 +7347,7 @@ func (s *FappendContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *SfplParser) Fappend() (localctx IFappendContext) {
 	localctx = NewFappendContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 40, SfplParserRULE_fappend)
+	p.EnterRule(localctx, 64, SfplParserRULE_fappend)
 
 	defer func() {
 		p.ExitRule()
@@ -4456,7 +7367,7 @@ func (p *SfplParser) Fappend() (localctx IFappendContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(291)
+		p.SetState(511)
 		p.Atom()
 	}
 
@@ -4537,7 +7448,7 @@ func (s *VariableContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *SfplParser) Variable() (localctx IVariableContext) {
 	localctx = NewVariableContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 42, SfplParserRULE_variable)
+	p.EnterRule(localctx, 66, SfplParserRULE_variable)
 
 	defer func() {
 		p.ExitRule()
@@ -4557,7 +7468,7 @@ func (p *SfplParser) Variable() (localctx IVariableContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(293)
+		p.SetState(513)
 		p.Match(SfplParserID)
 	}
 
@@ -4618,10 +7529,28 @@ func (s *AtomContext) TAG() antlr.TerminalNode {
 	return s.GetToken(SfplParserTAG, 0)
 }
 
+func (s *AtomContext) CIDR() antlr.TerminalNode {
+	return s.GetToken(SfplParserCIDR, 0)
+}
+
 func (s *AtomContext) STRING() antlr.TerminalNode {
 	return s.GetToken(SfplParserSTRING, 0)
 }
 
+func (s *AtomContext) SEVERITY() antlr.TerminalNode {
+	return s.GetToken(SfplParserSEVERITY, 0)
+}
+
+func (s *AtomContext) Keyword() IKeywordContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKeywordContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IKeywordContext)
+}
+
 func (s *AtomContext) LT() antlr.TerminalNode {
 	return s.GetToken(SfplParserLT, 0)
 }
@@ -4662,7 +7591,208 @@ func (s *AtomContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *SfplParser) Atom() (localctx IAtomContext) {
 	localctx = NewAtomContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 44, SfplParserRULE_atom)
+	p.EnterRule(localctx, 68, SfplParserRULE_atom)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(525)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserID:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(515)
+			p.Match(SfplParserID)
+		}
+
+	case SfplParserPATH:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(516)
+			p.Match(SfplParserPATH)
+		}
+
+	case SfplParserNUMBER:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(517)
+			p.Match(SfplParserNUMBER)
+		}
+
+	case SfplParserTAG:
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(518)
+			p.Match(SfplParserTAG)
+		}
+
+	case SfplParserCIDR:
+		p.EnterOuterAlt(localctx, 5)
+		{
+			p.SetState(519)
+			p.Match(SfplParserCIDR)
+		}
+
+	case SfplParserSTRING:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(520)
+			p.Match(SfplParserSTRING)
+		}
+
+	case SfplParserSEVERITY:
+		p.EnterOuterAlt(localctx, 7)
+		{
+			p.SetState(521)
+			p.Match(SfplParserSEVERITY)
+		}
+
+	case SfplParserEXCEPTIONS, SfplParserFIELDS, SfplParserCOMPS, SfplParserVALUES, SfplParserSOURCE, SfplParserFORMAT, SfplParserCOLUMN, SfplParserSCORE:
+		p.EnterOuterAlt(localctx, 8)
+		{
+			p.SetState(522)
+			p.Keyword()
+		}
+
+	case SfplParserLT:
+		p.EnterOuterAlt(localctx, 9)
+		{
+			p.SetState(523)
+			p.Match(SfplParserLT)
+		}
+
+	case SfplParserGT:
+		p.EnterOuterAlt(localctx, 10)
+		{
+			p.SetState(524)
+			p.Match(SfplParserGT)
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
+// IKeywordContext is an interface to support dynamic dispatch.
+type IKeywordContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsKeywordContext differentiates from other interfaces.
+	IsKeywordContext()
+}
+
+type KeywordContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyKeywordContext() *KeywordContext {
+	var p = new(KeywordContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_keyword
+	return p
+}
+
+func (*KeywordContext) IsKeywordContext() {}
+
+func NewKeywordContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *KeywordContext {
+	var p = new(KeywordContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_keyword
+
+	return p
+}
+
+func (s *KeywordContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *KeywordContext) EXCEPTIONS() antlr.TerminalNode {
+	return s.GetToken(SfplParserEXCEPTIONS, 0)
+}
+
+func (s *KeywordContext) FIELDS() antlr.TerminalNode {
+	return s.GetToken(SfplParserFIELDS, 0)
+}
+
+func (s *KeywordContext) COMPS() antlr.TerminalNode {
+	return s.GetToken(SfplParserCOMPS, 0)
+}
+
+func (s *KeywordContext) VALUES() antlr.TerminalNode {
+	return s.GetToken(SfplParserVALUES, 0)
+}
+
+func (s *KeywordContext) SOURCE() antlr.TerminalNode {
+	return s.GetToken(SfplParserSOURCE, 0)
+}
+
+func (s *KeywordContext) FORMAT() antlr.TerminalNode {
+	return s.GetToken(SfplParserFORMAT, 0)
+}
+
+func (s *KeywordContext) COLUMN() antlr.TerminalNode {
+	return s.GetToken(SfplParserCOLUMN, 0)
+}
+
+func (s *KeywordContext) SCORE() antlr.TerminalNode {
+	return s.GetToken(SfplParserSCORE, 0)
+}
+
+func (s *KeywordContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *KeywordContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *KeywordContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterKeyword(s)
+	}
+}
+
+func (s *KeywordContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitKeyword(s)
+	}
+}
+
+func (s *KeywordContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitKeyword(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Keyword() (localctx IKeywordContext) {
+	localctx = NewKeywordContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 70, SfplParserRULE_keyword)
 	var _la int
 
 	defer func() {
@@ -4683,10 +7813,10 @@ func (p *SfplParser) Atom() (localctx IAtomContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(295)
+		p.SetState(527)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((_la-22)&-(0x1f+1)) == 0 && ((1<<uint((_la-22)))&((1<<(SfplParserLT-22))|(1<<(SfplParserGT-22))|(1<<(SfplParserID-22))|(1<<(SfplParserNUMBER-22))|(1<<(SfplParserPATH-22))|(1<<(SfplParserSTRING-22))|(1<<(SfplParserTAG-22)))) != 0) {
+		if !(((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserEXCEPTIONS)|(1<<SfplParserFIELDS)|(1<<SfplParserCOMPS)|(1<<SfplParserVALUES)|(1<<SfplParserSOURCE)|(1<<SfplParserFORMAT)|(1<<SfplParserCOLUMN)|(1<<SfplParserSCORE))) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -4766,7 +7896,7 @@ func (s *TextContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *SfplParser) Text() (localctx ITextContext) {
 	localctx = NewTextContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 46, SfplParserRULE_text)
+	p.EnterRule(localctx, 72, SfplParserRULE_text)
 
 	defer func() {
 		p.ExitRule()
@@ -4787,15 +7917,15 @@ func (p *SfplParser) Text() (localctx ITextContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(299)
+	p.SetState(531)
 	p.GetErrorHandler().Sync(p)
 	_alt = 1
 	for ok := true; ok; ok = _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		switch _alt {
 		case 1:
-			p.SetState(297)
+			p.SetState(529)
 
-			if !(!(p.GetCurrentToken().GetText() == "desc" ||
+			if !(!((p.GetCurrentToken().GetText() == "desc" ||
 				p.GetCurrentToken().GetText() == "condition" ||
 				p.GetCurrentToken().GetText() == "action" ||
 				p.GetCurrentToken().GetText() == "output" ||
@@ -4805,19 +7935,22 @@ func (p *SfplParser) Text() (localctx ITextContext) {
 				p.GetCurrentToken().GetText() == "enabled" ||
 				p.GetCurrentToken().GetText() == "warn_evttypes" ||
 				p.GetCurrentToken().GetText() == "skip-if-unknown-filter" ||
-				p.GetCurrentToken().GetText() == "append")) {
-				panic(antlr.NewFailedPredicateException(p, "!(p.GetCurrentToken().GetText() == \"desc\" ||\n\t      p.GetCurrentToken().GetText() == \"condition\" ||\n\t      p.GetCurrentToken().GetText() == \"action\" ||\n\t      p.GetCurrentToken().GetText() == \"output\" ||\n\t      p.GetCurrentToken().GetText() == \"priority\" ||\n\t      p.GetCurrentToken().GetText() == \"tags\" ||\n\t\t  p.GetCurrentToken().GetText() == \"prefilter\" ||\n\t\t  p.GetCurrentToken().GetText() == \"enabled\" ||\n\t\t  p.GetCurrentToken().GetText() == \"warn_evttypes\" ||\n\t\t  p.GetCurrentToken().GetText() == \"skip-if-unknown-filter\" ||\n\t\t  p.GetCurrentToken().GetText() == \"append\")", ""))
+				p.GetCurrentToken().GetText() == "exceptions" ||
+				p.GetCurrentToken().GetText() == "score" ||
+				p.GetCurrentToken().GetText() == "append") &&
+				p.GetTokenStream().LT(2).GetTokenType() == SfplParserDEF)) {
+				panic(antlr.NewFailedPredicateException(p, "!((p.GetCurrentToken().GetText() == \"desc\" ||\n\t      p.GetCurrentToken().GetText() == \"condition\" ||\n\t      p.GetCurrentToken().GetText() == \"action\" ||\n\t      p.GetCurrentToken().GetText() == \"output\" ||\n\t      p.GetCurrentToken().GetText() == \"priority\" ||\n\t      p.GetCurrentToken().GetText() == \"tags\" ||\n\t\t  p.GetCurrentToken().GetText() == \"prefilter\" ||\n\t\t  p.GetCurrentToken().GetText() == \"enabled\" ||\n\t\t  p.GetCurrentToken().GetText() == \"warn_evttypes\" ||\n\t\t  p.GetCurrentToken().GetText() == \"skip-if-unknown-filter\" ||\n\t\t  p.GetCurrentToken().GetText() == \"exceptions\" ||\n\t\t  p.GetCurrentToken().GetText() == \"score\" ||\n\t\t  p.GetCurrentToken().GetText() == \"append\") &&\n\t\t  p.GetTokenStream().LT(2).GetTokenType() == SfplParserDEF)", ""))
 			}
-			p.SetState(298)
+			p.SetState(530)
 			p.MatchWildcard()
 
 		default:
 			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		}
 
-		p.SetState(301)
+		p.SetState(533)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 23, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 55, p.GetParserRuleContext())
 	}
 
 	return localctx
@@ -4933,7 +8066,7 @@ func (s *Binary_operatorContext) Accept(visitor antlr.ParseTreeVisitor) interfac
 
 func (p *SfplParser) Binary_operator() (localctx IBinary_operatorContext) {
 	localctx = NewBinary_operatorContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 48, SfplParserRULE_binary_operator)
+	p.EnterRule(localctx, 74, SfplParserRULE_binary_operator)
 	var _la int
 
 	defer func() {
@@ -4954,10 +8087,10 @@ func (p *SfplParser) Binary_operator() (localctx IBinary_operatorContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(303)
+		p.SetState(535)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((_la-22)&-(0x1f+1)) == 0 && ((1<<uint((_la-22)))&((1<<(SfplParserLT-22))|(1<<(SfplParserLE-22))|(1<<(SfplParserGT-22))|(1<<(SfplParserGE-22))|(1<<(SfplParserEQ-22))|(1<<(SfplParserNEQ-22))|(1<<(SfplParserCONTAINS-22))|(1<<(SfplParserICONTAINS-22))|(1<<(SfplParserSTARTSWITH-22))|(1<<(SfplParserENDSWITH-22)))) != 0) {
+		if !(((_la-30)&-(0x1f+1)) == 0 && ((1<<uint((_la-30)))&((1<<(SfplParserLT-30))|(1<<(SfplParserLE-30))|(1<<(SfplParserGT-30))|(1<<(SfplParserGE-30))|(1<<(SfplParserEQ-30))|(1<<(SfplParserNEQ-30))|(1<<(SfplParserCONTAINS-30))|(1<<(SfplParserICONTAINS-30))|(1<<(SfplParserSTARTSWITH-30))|(1<<(SfplParserENDSWITH-30)))) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -5042,7 +8175,7 @@ func (s *Unary_operatorContext) Accept(visitor antlr.ParseTreeVisitor) interface
 
 func (p *SfplParser) Unary_operator() (localctx IUnary_operatorContext) {
 	localctx = NewUnary_operatorContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 50, SfplParserRULE_unary_operator)
+	p.EnterRule(localctx, 76, SfplParserRULE_unary_operator)
 
 	defer func() {
 		p.ExitRule()
@@ -5062,7 +8195,7 @@ func (p *SfplParser) Unary_operator() (localctx IUnary_operatorContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(305)
+		p.SetState(537)
 		p.Match(SfplParserEXISTS)
 	}
 
@@ -5071,7 +8204,7 @@ func (p *SfplParser) Unary_operator() (localctx IUnary_operatorContext) {
 
 func (p *SfplParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
-	case 23:
+	case 36:
 		var t *TextContext = nil
 		if localctx != nil {
 			t = localctx.(*TextContext)
@@ -5086,7 +8219,7 @@ func (p *SfplParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex in
 func (p *SfplParser) Text_Sempred(localctx antlr.RuleContext, predIndex int) bool {
 	switch predIndex {
 	case 0:
-		return !(p.GetCurrentToken().GetText() == "desc" ||
+		return !((p.GetCurrentToken().GetText() == "desc" ||
 			p.GetCurrentToken().GetText() == "condition" ||
 			p.GetCurrentToken().GetText() == "action" ||
 			p.GetCurrentToken().GetText() == "output" ||
@@ -5096,7 +8229,10 @@ func (p *SfplParser) Text_Sempred(localctx antlr.RuleContext, predIndex int) boo
 			p.GetCurrentToken().GetText() == "enabled" ||
 			p.GetCurrentToken().GetText() == "warn_evttypes" ||
 			p.GetCurrentToken().GetText() == "skip-if-unknown-filter" ||
-			p.GetCurrentToken().GetText() == "append")
+			p.GetCurrentToken().GetText() == "exceptions" ||
+			p.GetCurrentToken().GetText() == "score" ||
+			p.GetCurrentToken().GetText() == "append") &&
+			p.GetTokenStream().LT(2).GetTokenType() == SfplParserDEF)
 
 	default:
 		panic("No predicate with index: " + fmt.Sprint(predIndex))
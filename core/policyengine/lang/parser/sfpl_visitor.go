@@ -28,12 +28,24 @@ type SfplVisitor interface {
 	// Visit a parse tree produced by SfplParser#pmacro.
 	VisitPmacro(ctx *PmacroContext) interface{}
 
+	// Visit a parse tree produced by SfplParser#smacro.
+	VisitSmacro(ctx *SmacroContext) interface{}
+
 	// Visit a parse tree produced by SfplParser#plist.
 	VisitPlist(ctx *PlistContext) interface{}
 
+	// Visit a parse tree produced by SfplParser#slist.
+	VisitSlist(ctx *SlistContext) interface{}
+
 	// Visit a parse tree produced by SfplParser#preq.
 	VisitPreq(ctx *PreqContext) interface{}
 
+	// Visit a parse tree produced by SfplParser#listsource.
+	VisitListsource(ctx *ListsourceContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#condition.
+	VisitCondition(ctx *ConditionContext) interface{}
+
 	// Visit a parse tree produced by SfplParser#expression.
 	VisitExpression(ctx *ExpressionContext) interface{}
 
@@ -55,6 +67,27 @@ type SfplVisitor interface {
 	// Visit a parse tree produced by SfplParser#prefilter.
 	VisitPrefilter(ctx *PrefilterContext) interface{}
 
+	// Visit a parse tree produced by SfplParser#exceptions.
+	VisitExceptions(ctx *ExceptionsContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#exception.
+	VisitException(ctx *ExceptionContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#fields.
+	VisitFields(ctx *FieldsContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#comps.
+	VisitComps(ctx *CompsContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#comp.
+	VisitComp(ctx *CompContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#values.
+	VisitValues(ctx *ValuesContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#value.
+	VisitValue(ctx *ValueContext) interface{}
+
 	// Visit a parse tree produced by SfplParser#severity.
 	VisitSeverity(ctx *SeverityContext) interface{}
 
@@ -67,6 +100,9 @@ type SfplVisitor interface {
 	// Visit a parse tree produced by SfplParser#skipunknown.
 	VisitSkipunknown(ctx *SkipunknownContext) interface{}
 
+	// Visit a parse tree produced by SfplParser#score.
+	VisitScore(ctx *ScoreContext) interface{}
+
 	// Visit a parse tree produced by SfplParser#fappend.
 	VisitFappend(ctx *FappendContext) interface{}
 
@@ -76,6 +112,9 @@ type SfplVisitor interface {
 	// Visit a parse tree produced by SfplParser#atom.
 	VisitAtom(ctx *AtomContext) interface{}
 
+	// Visit a parse tree produced by SfplParser#keyword.
+	VisitKeyword(ctx *KeywordContext) interface{}
+
 	// Visit a parse tree produced by SfplParser#text.
 	VisitText(ctx *TextContext) interface{}
 
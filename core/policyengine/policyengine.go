@@ -26,20 +26,23 @@ import (
 	"github.com/sysflow-telemetry/sf-apis/go/ioutils"
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
 	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/aggregator"
 	"github.com/sysflow-telemetry/sf-processor/core/cache"
 	"github.com/sysflow-telemetry/sf-processor/core/flattener"
 	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
 )
 
 const (
-	pluginName  string = "policyengine"
-	channelName string = "eventchan"
+	pluginName    string = "policyengine"
+	channelName   string = "eventchan"
+	inChannelName string = "flattenerchan"
 )
 
 // PolicyEngine defines a driver for the Policy Engine plugin.
 type PolicyEngine struct {
 	pi         engine.PolicyInterpreter
-	tables     *cache.SFTables
 	outCh      chan *engine.Record
 	filterOnly bool
 	bypass     bool
@@ -75,7 +78,17 @@ func (s *PolicyEngine) Init(conf map[string]string) error {
 	}
 	s.config = config
 	s.pi = engine.NewPolicyInterpreter(s.config)
-	s.tables = cache.GetInstance()
+	if s.config.PodSource != "" {
+		logger.Trace.Println("Resolving pod metadata from: ", s.config.PodSource)
+		src, err := engine.NewPodSource(s.config.PodSource, s.config.PodCA, s.config.PodInsecure)
+		if err != nil {
+			return err
+		}
+		if s.config.PodInsecure {
+			logger.Warn.Println("Skipping verification of kubelet certificates")
+		}
+		engine.SetPodResolver(engine.NewPodResolver(src, s.config.PodRefresh))
+	}
 	if s.config.Mode == engine.FilterMode {
 		logger.Trace.Println("Setting policy engine in filter mode")
 		s.filterOnly = true
@@ -101,12 +114,16 @@ func (s *PolicyEngine) Process(ch interface{}, wg *sync.WaitGroup) {
 	defer wg.Done()
 	logger.Trace.Println("Starting policy engine with capacity: ", cap(in))
 	out := func(r *engine.Record) { s.outCh <- r }
+	if !s.bypass && s.config.Concurrency > 1 {
+		s.processParallel(in, out)
+		return
+	}
 	for {
 		if fc, ok := <-in; ok {
 			if s.bypass {
-				out(engine.NewRecord(*fc, s.tables))
+				out(newRecord(fc))
 			} else {
-				s.pi.ProcessAsync(true, s.filterOnly, engine.NewRecord(*fc, s.tables), out)
+				s.pi.ProcessAsync(true, s.filterOnly, newRecord(fc), out)
 			}
 		} else {
 			logger.Trace.Println("Input channel closed. Shutting down.")
@@ -115,6 +132,27 @@ func (s *PolicyEngine) Process(ch interface{}, wg *sync.WaitGroup) {
 	}
 }
 
+// processParallel evaluates records from in using a pool of policy workers.
+func (s *PolicyEngine) processParallel(in chan *sfgo.FlatRecord, out func(r *engine.Record)) {
+	logger.Trace.Printf("Starting %d policy workers with %s ordering\n", s.config.Concurrency, s.config.Ordering.String())
+	wp := engine.NewWorkerPool(s.pi, s.config, true, s.filterOnly, out)
+	for fc := range in {
+		wp.Submit(newRecord(fc))
+	}
+	logger.Trace.Println("Input channel closed. Draining policy workers.")
+	wp.Close()
+}
+
+// newRecord creates a record from a flat record, restoring the flow count of aggregated flows.
+func newRecord(fc *sfgo.FlatRecord) *engine.Record {
+	return aggregator.RestoreFlowCount(engine.NewRecord(*fc, cache.GetRecordInstance(fc)))
+}
+
+// ConfigSchema returns the plugin config schema.
+func (s *PolicyEngine) ConfigSchema() schema.Schema {
+	return schema.Schema{Attrs: engine.ConfigAttrs, In: []string{inChannelName}, Out: channelName}
+}
+
 // SetOutChan sets the output channel of the plugin.
 func (s *PolicyEngine) SetOutChan(ch interface{}) {
 	s.outCh = (ch.(*engine.RecordChannel)).In
@@ -123,6 +161,7 @@ func (s *PolicyEngine) SetOutChan(ch interface{}) {
 // Cleanup clean up the plugin resources.
 func (s *PolicyEngine) Cleanup() {
 	logger.Trace.Println("Exiting ", pluginName)
+	s.pi.Close()
 	if s.outCh != nil {
 		close(s.outCh)
 	}
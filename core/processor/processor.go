@@ -20,6 +20,7 @@
 package processor
 
 import (
+	"container/list"
 	"sync"
 
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
@@ -27,6 +28,7 @@ import (
 	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
 	"github.com/sysflow-telemetry/sf-processor/core/cache"
 	"github.com/sysflow-telemetry/sf-processor/core/flattener"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
 )
 
 const (
@@ -36,11 +38,37 @@ const (
 
 // SysFlowProcessor defines the main processor class.
 type SysFlowProcessor struct {
-	hdr    *sfgo.SFHeader
-	hdl    plugins.SFHandler
-	tables *cache.SFTables
+	hdr        *sfgo.SFHeader
+	hdl        plugins.SFHandler
+	tables     *cache.SFTables
+	streams    map[streamOrigin]*list.Element
+	lru        *list.List
+	maxStreams int
 }
 
+// streamOrigin identifies the streams of an exporter, given by the exporter and ip fields that
+// collectors or drivers attach to stream headers.
+type streamOrigin struct {
+	exporter string
+	ip       string
+}
+
+// streamState tracks the streams of an origin, which share its entity tables.
+type streamState struct {
+	origin  streamOrigin
+	tables  *cache.SFTables
+	hdr     *sfgo.SFHeader
+	headers []*sfgo.SFHeader
+	shared  bool
+}
+
+const (
+	// maxStreams bounds the number of stream origins tracked by the processor.
+	maxStreams = 4096
+	// maxOriginHeaders bounds the number of stream headers tracked per origin.
+	maxOriginHeaders = 64
+)
+
 // NewSysFlowProcessor creates a new SysFlowProcessor instance.
 func NewSysFlowProcessor(hdl plugins.SFHandler) plugins.SFProcessor {
 	logger.Trace.Println("Calling NewSysFlowProc")
@@ -66,9 +94,17 @@ func (s *SysFlowProcessor) Register(pc plugins.SFPluginCache) {
 	(&flattener.Flattener{}).Register(pc)
 }
 
+// ConfigSchema returns the plugin config schema. The output channel type is given by the handler.
+func (s *SysFlowProcessor) ConfigSchema() schema.Schema {
+	return schema.Schema{In: []string{channelName}}
+}
+
 // Init initializes the processor with a configuration map.
 func (s *SysFlowProcessor) Init(conf map[string]string) error {
 	s.tables = cache.GetInstance()
+	s.streams = make(map[streamOrigin]*list.Element)
+	s.lru = list.New()
+	s.maxStreams = maxStreams
 	return nil
 }
 
@@ -94,7 +130,7 @@ func (s *SysFlowProcessor) Process(ch interface{}, wg *sync.WaitGroup) {
 		case sfgo.SF_HEADER:
 			hdr := sf.Rec.SFHeader
 			s.hdr = hdr
-			s.tables.Reset()
+			s.setStream(hdr)
 			if entEnabled {
 				s.hdl.HandleHeader(s.hdr)
 			}
@@ -145,6 +181,57 @@ func (s *SysFlowProcessor) Process(ch interface{}, wg *sync.WaitGroup) {
 	}
 }
 
+// setStream selects the entity tables of the stream of a header. Entities are cached per origin,
+// and a new header starts a new generation of its origin's tables, unless another stream of the
+// same origin is still live. Drivers multiplexing several streams re-send a stream's header when
+// switching streams, which tells live streams apart from replaced ones.
+func (s *SysFlowProcessor) setStream(hdr *sfgo.SFHeader) {
+	st := s.getStream(streamOrigin{hdr.Exporter, hdr.Ip})
+	s.tables = st.tables
+	if !st.seen(hdr) {
+		if !st.shared {
+			s.tables.Reset()
+		}
+		st.hdr, st.shared = hdr, false
+	} else if hdr != st.hdr {
+		st.shared = true
+	}
+}
+
+// getStream returns the state of the streams of an origin, tracking the origin as most recently
+// used. Past maxStreams origins, the least recently used origin is evicted and its tables released.
+func (s *SysFlowProcessor) getStream(origin streamOrigin) *streamState {
+	if e, ok := s.streams[origin]; ok {
+		s.lru.MoveToFront(e)
+		return e.Value.(*streamState)
+	}
+	st := &streamState{origin: origin, tables: cache.GetStreamInstance(origin.exporter, origin.ip)}
+	s.streams[origin] = s.lru.PushFront(st)
+	if s.lru.Len() > s.maxStreams {
+		old := s.lru.Remove(s.lru.Back()).(*streamState)
+		delete(s.streams, old.origin)
+		cache.ReleaseStreamInstance(old.origin.exporter, old.origin.ip)
+	}
+	return st
+}
+
+// seen reports whether hdr was seen before, and tracks it as the most recently seen header of the
+// origin. Past maxOriginHeaders headers, the least recently seen header is forgotten.
+func (st *streamState) seen(hdr *sfgo.SFHeader) bool {
+	for i, h := range st.headers {
+		if h == hdr {
+			copy(st.headers[i:], st.headers[i+1:])
+			st.headers[len(st.headers)-1] = hdr
+			return true
+		}
+	}
+	if len(st.headers) >= maxOriginHeaders {
+		st.headers = append(st.headers[:0], st.headers[1:]...)
+	}
+	st.headers = append(st.headers, hdr)
+	return false
+}
+
 // Cleanup tears down the plugin resources.
 func (s *SysFlowProcessor) Cleanup() {
 	logger.Trace.Println("Exiting ", pluginName)
@@ -0,0 +1,105 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package processor_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/cache"
+	"github.com/sysflow-telemetry/sf-processor/core/flattener"
+	. "github.com/sysflow-telemetry/sf-processor/core/processor"
+	"github.com/sysflow-telemetry/sf-processor/core/sysflowtest"
+)
+
+
+
+
+
+// process runs records through a processor tracking at most maxStreams origins, returning the
+// executables of the flattened event records.
+func process(maxStreams int, records ...*sfgo.SysFlow) []string {
+	ch := flattener.NewFlattenerChan(len(records)).(*flattener.FlatChannel)
+	proc := NewSysFlowProcessor(flattener.NewFlattener()).(*SysFlowProcessor)
+	proc.Init(map[string]string{})
+	SetMaxStreams(proc, maxStreams)
+	proc.SetOutChan(ch)
+	in := NewSysFlowChan(len(records)).(*plugins.SFChannel)
+	for _, r := range records {
+		in.In <- r
+	}
+	close(in.In)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	proc.Process(in, &wg)
+	close(ch.In)
+	var exes []string
+	for fr := range ch.In {
+		exes = append(exes, fr.Strs[sfgo.SYSFLOW_IDX][sfgo.PROC_EXE_STR])
+	}
+	return exes
+}
+
+func TestStreamEviction(t *testing.T) {
+	a, b, c := sysflowtest.Header("evict-a"), sysflowtest.Header("evict-b"), sysflowtest.Header("evict-c")
+	tables := cache.GetStreamInstance("evict-a", "")
+	exes := process(2,
+		a, sysflowtest.Process(1, "/bin/a"),
+		b, sysflowtest.Process(1, "/bin/b"),
+		c, sysflowtest.Process(1, "/bin/c"),
+		// evicting the least recently used origin does not reset the live streams
+		b, sysflowtest.ProcEvt(1, 1),
+		c, sysflowtest.ProcEvt(1, 2),
+		b, sysflowtest.ProcEvt(1, 3),
+		c, sysflowtest.ProcEvt(1, 4),
+		b, sysflowtest.ProcEvt(1, 5),
+	)
+	assert.Equal(t, []string{"/bin/b", "/bin/c", "/bin/b", "/bin/c", "/bin/b"}, exes)
+	// records of the evicted origin resolve to empty tables, which is logged once
+	var warnings bytes.Buffer
+	logger.Warn.SetOutput(&warnings)
+	fr := &sfgo.FlatRecord{Strs: [][]string{make([]string, sfgo.STR_ARRAY_SIZE)}}
+	fr.Strs[sfgo.SYSFLOW_IDX][sfgo.SFHE_EXPORTER_STR] = "evict-a"
+	assert.Nil(t, cache.GetRecordInstance(fr).GetProc(sfgo.OID{CreateTS: 1, Hpid: 1}))
+	assert.Nil(t, cache.GetRecordInstance(fr).GetProc(sfgo.OID{CreateTS: 1, Hpid: 1}))
+	logger.Warn.SetOutput(os.Stdout)
+	assert.Equal(t, 1, strings.Count(warnings.String(), "evict-a"))
+	// the tables of the evicted origin are released
+	assert.NotSame(t, tables, cache.GetStreamInstance("evict-a", ""))
+	assert.Nil(t, cache.GetStreamInstance("evict-a", "").GetProc(sfgo.OID{CreateTS: 1, Hpid: 1}))
+	assert.Equal(t, "/bin/b", cache.GetStreamInstance("evict-b", "").GetProc(sfgo.OID{CreateTS: 1, Hpid: 1}).Exe)
+}
+
+func TestStreamReplaced(t *testing.T) {
+	// a new header of an origin starts a new generation of its tables
+	exes := process(2,
+		sysflowtest.Header("replace"), sysflowtest.Process(1, "/bin/old"), sysflowtest.ProcEvt(1, 1),
+		sysflowtest.Header("replace"), sysflowtest.ProcEvt(1, 2),
+		sysflowtest.Header("replace"), sysflowtest.ProcEvt(1, 3),
+	)
+	assert.Equal(t, []string{"/bin/old", "/bin/old", ""}, exes)
+}
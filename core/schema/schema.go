@@ -0,0 +1,201 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Package schema defines config schemas declared by pipeline plugins.
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type denotes the type of a config attribute value.
+type Type int
+
+// Config attribute types.
+const (
+	String Type = iota
+	Int
+	Bool
+	Duration
+	Enum
+)
+
+func (t Type) String() string {
+	return [...]string{"string", "integer", "boolean", "duration", "enum"}[t]
+}
+
+// Attr describes a plugin config attribute.
+type Attr struct {
+	Key      string
+	Type     Type
+	Default  string
+	Values   []string
+	Required bool
+	Check    func(v string) error
+}
+
+// check checks that v is a valid value for the attribute.
+func (a Attr) check(v string) error {
+	var err error
+	switch a.Type {
+	case Int:
+		_, err = strconv.Atoi(v)
+	case Bool:
+		_, err = strconv.ParseBool(v)
+	case Duration:
+		_, err = time.ParseDuration(v)
+	case Enum:
+		if !contains(a.Values, v) {
+			return fmt.Errorf("'%s' must be one of %s: %s", a.Key, strings.Join(a.Values, ", "), v)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("'%s' must be of type %s: %s", a.Key, a.Type.String(), v)
+	}
+	if a.Check != nil {
+		if err := a.Check(v); err != nil {
+			return fmt.Errorf("'%s' has invalid value '%s': %v", a.Key, v, err)
+		}
+	}
+	return nil
+}
+
+// Schema describes the config attributes and channel types of a plugin. In lists the channel
+// types the plugin consumes, and Out is the channel type it produces, if any.
+//
+// Prefixes names a list attribute whose items are instance names, each configured by attributes
+// prefixed with the instance name and a dot or underscore (e.g., alerts.export). Names names a
+// list attribute whose items are also keys of the plugin config.
+type Schema struct {
+	Attrs    []Attr
+	In       []string
+	Out      string
+	Prefixes string
+	Names    string
+}
+
+// SameAsIn is the Out channel type of plugins that write to a channel of the same type as their
+// in channel.
+const SameAsIn = "<in>"
+
+// Provider is implemented by plugins that declare a config schema.
+type Provider interface {
+	ConfigSchema() Schema
+}
+
+// attr returns the attribute for key.
+func (s Schema) attr(key string) (Attr, bool) {
+	for _, a := range s.Attrs {
+		if a.Key == key {
+			return a, true
+		}
+	}
+	return Attr{}, false
+}
+
+// Validate checks conf against the schema, reporting unknown keys, missing required keys, and invalid values.
+// Reserved keys are accepted without checks.
+func (s Schema) Validate(conf map[string]string, reserved ...string) error {
+	var errs []string
+	for _, a := range s.Attrs {
+		if _, ok := conf[a.Key]; a.Required && !ok {
+			errs = append(errs, fmt.Sprintf("'%s' is required", a.Key))
+		}
+	}
+	keys := make([]string, 0, len(conf))
+	for k := range conf {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := s.validateKey(conf, k, conf[k], reserved); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validateKey checks a single config key and value.
+func (s Schema) validateKey(conf map[string]string, k string, v string, reserved []string) error {
+	for _, r := range reserved {
+		if k == r {
+			return nil
+		}
+	}
+	if a, ok := s.attr(k); ok {
+		return a.check(v)
+	}
+	if s.Names != "" && contains(listItems(conf[s.Names]), k) {
+		return nil
+	}
+	if s.Prefixes != "" {
+		for _, name := range listItems(conf[s.Prefixes]) {
+			for _, sep := range []string{".", "_"} {
+				if strings.HasPrefix(k, name+sep) {
+					if a, ok := s.attr(strings.TrimPrefix(k, name+sep)); ok && a.Key != s.Prefixes {
+						return a.check(v)
+					}
+				}
+			}
+		}
+	}
+	return fmt.Errorf("unknown attribute '%s'", k)
+}
+
+// Resolve returns a copy of conf with default values for unset attributes.
+func (s Schema) Resolve(conf map[string]string) map[string]string {
+	rc := make(map[string]string)
+	for _, a := range s.Attrs {
+		if a.Default != "" {
+			rc[a.Key] = a.Default
+		}
+	}
+	for k, v := range conf {
+		rc[k] = v
+	}
+	return rc
+}
+
+// listItems returns the non-empty items of a comma-separated list.
+func listItems(v string) []string {
+	var items []string
+	for _, i := range strings.Split(v, ",") {
+		if i = strings.TrimSpace(i); i != "" {
+			items = append(items, i)
+		}
+	}
+	return items
+}
+
+func contains(items []string, s string) bool {
+	for _, i := range items {
+		if i == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package schema_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
+)
+
+var testAttrs = []schema.Attr{
+	{Key: "path", Type: schema.String, Required: true},
+	{Key: "size", Type: schema.Int, Default: "10"},
+	{Key: "follow", Type: schema.Bool, Default: "false"},
+	{Key: "timeout", Type: schema.Duration},
+	{Key: "export", Type: schema.Enum, Default: "terminal", Values: []string{"terminal", "file", "syslog"}},
+	{Key: "tag", Type: schema.String, Check: func(v string) error {
+		if v == "" {
+			return errors.New("empty tag")
+		}
+		return nil
+	}},
+}
+
+func TestValidate(t *testing.T) {
+	plain := schema.Schema{Attrs: testAttrs}
+	sinks := schema.Schema{Attrs: append([]schema.Attr{{Key: "sinks", Type: schema.String}}, testAttrs...), Prefixes: "sinks"}
+	handlers := schema.Schema{Attrs: append([]schema.Attr{{Key: "handlers", Type: schema.String}}, testAttrs...), Names: "handlers"}
+	for _, tc := range []struct {
+		name   string
+		schema schema.Schema
+		conf   map[string]string
+		errs   []string
+	}{
+		{"valid", plain, map[string]string{"path": "a", "size": "5", "follow": "true", "timeout": "1s", "export": "file", "tag": "t"}, nil},
+		{"required", plain, map[string]string{"size": "5"}, []string{"'path' is required"}},
+		{"int", plain, map[string]string{"path": "a", "size": "five"}, []string{"'size' must be of type integer: five"}},
+		{"bool", plain, map[string]string{"path": "a", "follow": "yes"}, []string{"'follow' must be of type boolean: yes"}},
+		{"duration", plain, map[string]string{"path": "a", "timeout": "1"}, []string{"'timeout' must be of type duration: 1"}},
+		{"enum", plain, map[string]string{"path": "a", "export": "kafka"}, []string{"'export' must be one of terminal, file, syslog: kafka"}},
+		{"check", plain, map[string]string{"path": "a", "tag": ""}, []string{"'tag' has invalid value '': empty tag"}},
+		{"unknown", plain, map[string]string{"path": "a", "other": "x"}, []string{"unknown attribute 'other'"}},
+		{"reserved", plain, map[string]string{"path": "a", "in": "evt eventchan"}, nil},
+		{"all errors", plain, map[string]string{"size": "x", "other": "x"}, []string{"'path' is required", "'size' must be of type integer", "unknown attribute 'other'"}},
+		{"prefixed", sinks, map[string]string{"path": "a", "sinks": "siem,all", "siem.export": "syslog", "all_export": "file", "siem.size": "3"}, nil},
+		{"prefixed value", sinks, map[string]string{"path": "a", "sinks": "siem", "siem.export": "kafka"}, []string{"'export' must be one of"}},
+		{"prefixed unlisted", sinks, map[string]string{"path": "a", "sinks": "siem", "other.export": "file"}, []string{"unknown attribute 'other.export'"}},
+		{"prefixed unknown", sinks, map[string]string{"path": "a", "sinks": "siem", "siem.other": "x"}, []string{"unknown attribute 'siem.other'"}},
+		{"prefixed list", sinks, map[string]string{"path": "a", "sinks": "siem", "siem.sinks": "all"}, []string{"unknown attribute 'siem.sinks'"}},
+		{"prefixed separator", sinks, map[string]string{"path": "a", "sinks": "siem", "siemexport": "file"}, []string{"unknown attribute 'siemexport'"}},
+		{"names", handlers, map[string]string{"path": "a", "handlers": "hosts, passwd", "hosts": "/etc/hosts"}, nil},
+		{"names unlisted", handlers, map[string]string{"path": "a", "handlers": "hosts", "passwd": "/etc/passwd"}, []string{"unknown attribute 'passwd'"}},
+	} {
+		err := tc.schema.Validate(tc.conf, "in")
+		if len(tc.errs) == 0 {
+			assert.NoError(t, err, tc.name)
+			continue
+		}
+		if assert.Error(t, err, tc.name) {
+			for _, e := range tc.errs {
+				assert.Contains(t, err.Error(), e, tc.name)
+			}
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	s := schema.Schema{Attrs: testAttrs}
+	conf := map[string]string{"path": "a", "size": "5", "other": "x"}
+	assert.Equal(t, map[string]string{"path": "a", "size": "5", "other": "x", "follow": "false", "export": "terminal"}, s.Resolve(conf))
+	assert.Equal(t, map[string]string{"path": "a", "size": "5", "other": "x"}, conf)
+	assert.Equal(t, map[string]string{"size": "10", "follow": "false", "export": "terminal"}, s.Resolve(nil))
+}
@@ -0,0 +1,52 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Package sysflowtest provides SysFlow record fixtures for tests of plugins and drivers.
+package sysflowtest
+
+import "github.com/sysflow-telemetry/sf-apis/go/sfgo"
+
+// Record creates an empty SysFlow record of type t.
+func Record(t sfgo.UnionSFHeaderContainerProcessFileProcessEventNetworkFlowFileFlowFileEventNetworkEventProcessFlowTypeEnum) *sfgo.SysFlow {
+	sf := sfgo.NewSysFlow()
+	sf.Rec = sfgo.NewUnionSFHeaderContainerProcessFileProcessEventNetworkFlowFileFlowFileEventNetworkEventProcessFlow()
+	sf.Rec.UnionType = t
+	return sf
+}
+
+// Header creates a SysFlow header record of exporter.
+func Header(exporter string) *sfgo.SysFlow {
+	sf := Record(sfgo.UnionSFHeaderContainerProcessFileProcessEventNetworkFlowFileFlowFileEventNetworkEventProcessFlowTypeEnumSFHeader)
+	sf.Rec.SFHeader = &sfgo.SFHeader{Version: 4, Exporter: exporter}
+	return sf
+}
+
+// Process creates a process record of exe with host PID hpid.
+func Process(hpid int64, exe string) *sfgo.SysFlow {
+	sf := Record(sfgo.UnionSFHeaderContainerProcessFileProcessEventNetworkFlowFileFlowFileEventNetworkEventProcessFlowTypeEnumProcess)
+	sf.Rec.Process = &sfgo.Process{Oid: &sfgo.OID{CreateTS: 1, Hpid: hpid}, Exe: exe}
+	return sf
+}
+
+// ProcEvt creates a process event record of the process with host PID hpid at time ts.
+func ProcEvt(hpid int64, ts int64) *sfgo.SysFlow {
+	sf := Record(sfgo.UnionSFHeaderContainerProcessFileProcessEventNetworkFlowFileFlowFileEventNetworkEventProcessFlowTypeEnumProcessEvent)
+	sf.Rec.ProcessEvent = &sfgo.ProcessEvent{ProcOID: &sfgo.OID{CreateTS: 1, Hpid: hpid}, Ts: ts}
+	return sf
+}
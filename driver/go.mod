@@ -22,23 +22,16 @@ module github.com/sysflow-telemetry/sf-processor/driver
 go 1.14
 
 require (
+	github.com/DataDog/zstd v1.4.5
 	github.com/actgardner/gogen-avro/v7 v7.1.1
-	github.com/fsnotify/fsnotify v1.4.9 // indirect
 	github.com/kr/pretty v0.2.0 // indirect
 	github.com/linkedin/goavro v2.1.0+incompatible
-	github.com/mitchellh/mapstructure v1.2.2 // indirect
-	github.com/pelletier/go-toml v1.7.0 // indirect
-	github.com/spf13/afero v1.2.2 // indirect
-	github.com/spf13/cast v1.3.1 // indirect
-	github.com/spf13/jwalterweatherman v1.1.0 // indirect
-	github.com/spf13/pflag v1.0.5 // indirect
-	github.com/spf13/viper v1.6.3
+	github.com/stretchr/testify v1.6.1
 	github.com/sysflow-telemetry/sf-apis/go v0.0.0-20201207153955-828257760aa4
 	github.com/sysflow-telemetry/sf-processor/core v0.0.0-20201206060647-9992298f1357
-	golang.org/x/sys v0.0.0-20200413165638-669c56c373c4 // indirect
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
-	gopkg.in/ini.v1 v1.55.0 // indirect
 	gopkg.in/linkedin/goavro.v1 v1.0.5 // indirect
+	gopkg.in/yaml.v2 v2.2.8
 )
 
 replace github.com/sysflow-telemetry/sf-processor/core => ../core
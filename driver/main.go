@@ -28,35 +28,46 @@ import (
 	"runtime"
 	"runtime/pprof"
 	"syscall"
+	"time"
 
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
-	"github.com/sysflow-telemetry/sf-apis/go/plugins"
 	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
 	"github.com/sysflow-telemetry/sf-processor/driver/manifest"
 	"github.com/sysflow-telemetry/sf-processor/driver/pipeline"
+	"github.com/sysflow-telemetry/sf-processor/driver/sysflow"
 )
 
-var pl plugins.SFPipeline
+var pl *pipeline.Pipeline
 
-func initSigTerm() {
-	c := make(chan os.Signal)
+func initSigTerm(timeout *time.Duration) {
+	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-c
 		fmt.Println("\r- Ctrl+C pressed in terminal")
 		if pl != nil {
+			go func() {
+				<-c
+				fmt.Println("\r- Forcing shutdown")
+				os.Exit(1)
+			}()
 			pl.Shutdown()
+			if !pl.Drain(*timeout) {
+				os.Exit(1)
+			}
 		}
 	}()
 }
 
 func main() {
 
+	shutdownTimeout := flag.Duration("shutdowntimeout", 30*time.Second, "Time to drain in-flight records on shutdown")
+
 	// setup interruption handler
-	initSigTerm()
+	initSigTerm(shutdownTimeout)
 
 	// setup arg parsing
-	inputType := flag.String("driver", "file", fmt.Sprintf("Driver name {file|socket|<custom>}"))
+	inputType := flag.String("driver", "file", fmt.Sprintf("Driver name {file|socket|tcp|replay|<custom>}"))
 	cpuprofile := flag.String("cpuprofile", "", "Write cpu profile to `file`")
 	memprofile := flag.String("memprofile", "", "Write memory profile to `file`")
 	configFile := flag.String("config", "pipeline.json", "Path to pipeline configuration file")
@@ -64,9 +75,12 @@ func main() {
 	driverDir := flag.String("driverdir", pipeline.DriverDir, "Dynamic driver directory")
 	pluginDir := flag.String("plugdir", pipeline.PluginDir, "Dynamic plugins directory")
 	version := flag.Bool("version", false, "Outputs version information")
+	dryrun := flag.Bool("dryrun", false, "Validates and outputs the resolved pipeline configuration")
+	from := flag.String("from", "", "Start `time` of the records read by the file driver (RFC 3339 time, date, or Unix timestamp)")
+	to := flag.String("to", "", "End `time` (exclusive) of the records read by the file driver (RFC 3339 time, date, or Unix timestamp)")
 
 	flag.Usage = func() {
-		fmt.Println("Usage: sfprocessor [[-version]|[-driver <value>] [-log <value>] [-driverdir <value>] [-plugdir <value>] path]")
+		fmt.Println("Usage: sfprocessor [[-version]|[-dryrun]|[-driver <value>] [-log <value>] [-driverdir <value>] [-plugdir <value>] path]")
 		fmt.Println()
 		fmt.Println("Positional arguments:")
 		fmt.Println("  path string\n\tInput path")
@@ -78,7 +92,7 @@ func main() {
 
 	// parse args and validade positional args
 	flag.Parse()
-	if !*version && flag.NArg() < 1 {
+	if !*version && !*dryrun && flag.NArg() < 1 {
 		flag.Usage()
 		os.Exit(1)
 	}
@@ -111,8 +125,25 @@ func main() {
 		defer pprof.StopCPUProfile()
 	}
 
+	// create pipeline, with driver attributes set by flags
+	p := pipeline.New(*driverDir, *pluginDir, *configFile)
+	if *from != "" {
+		p.SetDriverAttr(sysflow.FromConfig, *from)
+	}
+	if *to != "" {
+		p.SetDriverAttr(sysflow.ToConfig, *to)
+	}
+
+	// validates and prints the resolved pipeline configuration and exits
+	if *dryrun {
+		if err := p.DryRun(*inputType, os.Stdout); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// load pipeline
-	pl = pipeline.New(*driverDir, *pluginDir, *configFile)
+	pl = p
 	err := pl.Load(*inputType)
 	if err != nil {
 		logger.Error.Println("Unable to load pipeline error: " + err.Error())
@@ -27,11 +27,17 @@ import (
 
 // Config attributes
 const (
-	ModConfig     string = "mod"
-	ProcConfig    string = "processor"
-	HdlConfig     string = "handler"
-	InChanConfig  string = "in"
-	OutChanConfig string = "out"
+	ModConfig      string = "mod"
+	ProcConfig     string = "processor"
+	HdlConfig      string = "handler"
+	InChanConfig   string = "in"
+	OutChanConfig  string = "out"
+	ChanListSep    string = ","
+	PipelineConfig string = "pipeline"
+	DriverConfig   string = "driver"
+	ChannelsConfig string = "channels"
+	IncludeConfig  string = "include"
+	NameConfig     string = "name"
 )
 
 // Driver constants/defaults
@@ -42,6 +48,22 @@ const (
 	DriverDir  = "../resources/drivers"
 	PluginDir  = "../resources/plugins"
 	ChanSize   = 100000
+	ValveSize  = 1024
+)
+
+// Channel config attributes
+const (
+	ChanSizeConfig   string = "size"
+	ChanPolicyConfig string = "policy"
+	ChanRateConfig   string = "rate"
+)
+
+// Channel overflow policies
+const (
+	PolicyBlock      = "block"
+	PolicyDropNewest = "drop-newest"
+	PolicyDropOldest = "drop-oldest"
+	PolicySample     = "sample"
 )
 
 type inputType int
@@ -51,7 +73,9 @@ type PluginConfig map[string]string
 
 // Config defines a pipeline configuration object
 type Config struct {
-	Pipeline []PluginConfig `json,mapstructures:"pipeline"`
+	Pipeline []PluginConfig          `json,mapstructures:"pipeline"`
+	Driver   PluginConfig            `json,mapstructures:"driver"`
+	Channels map[string]PluginConfig `json,mapstructures:"channels"`
 }
 
 // setManifestInfo sets manifest attributes to plugins configuration items.
@@ -0,0 +1,221 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package pipeline
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+// chanSpec represents a channel reference of the form <identifier> <type>.
+type chanSpec struct {
+	name   string
+	chType string
+}
+
+// String returns the channel reference as expected by the plugin cache.
+func (s chanSpec) String() string {
+	return s.name + " " + s.chType
+}
+
+// parseChanSpecs parses a comma-separated list of channel references.
+func parseChanSpecs(v string) ([]chanSpec, error) {
+	var specs []chanSpec
+	seen := make(map[string]bool)
+	for _, c := range strings.Split(v, ChanListSep) {
+		fields := strings.Fields(c)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("Channel '%s' must be of the form <identifier> <type>", strings.TrimSpace(c))
+		}
+		if seen[fields[0]] {
+			return nil, fmt.Errorf("Channel '%s' referenced more than once in '%s'", fields[0], v)
+		}
+		seen[fields[0]] = true
+		specs = append(specs, chanSpec{name: fields[0], chType: fields[1]})
+	}
+	return specs, nil
+}
+
+// chanValue returns the Go channel wrapped by a plugin channel object.
+func chanValue(ch interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(ch)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		v = v.FieldByName("In")
+	}
+	if !v.IsValid() || v.Kind() != reflect.Chan {
+		return reflect.Value{}, fmt.Errorf("Channel of type %T does not wrap a Go channel", ch)
+	}
+	return v, nil
+}
+
+// fanIn tracks the producers merged into a shared channel.
+type fanIn struct {
+	out reflect.Value
+	ins []reflect.Value
+}
+
+// outChan resolves the out channel of a stage. A stage writing to several channels gets a private
+// channel that is teed to all of them, and a channel written by several stages gets a private
+// channel per producer that is merged into it.
+func (pl *Pipeline) outChan(v string, producers map[string]int) (interface{}, error) {
+	specs, err := parseChanSpecs(v)
+	if err != nil {
+		return nil, err
+	}
+	var outs []interface{}
+	for _, s := range specs {
+		out, err := pl.pluginCache.GetChan(s.String(), pl.chanSize(s.name))
+		if err != nil {
+			return nil, err
+		}
+		pl.channels = append(pl.channels, out)
+		if out, err = pl.throttle(s, out); err != nil {
+			return nil, err
+		}
+		if producers[s.name] > 1 {
+			if out, err = pl.mergeInto(s, out); err != nil {
+				return nil, err
+			}
+		}
+		outs = append(outs, out)
+	}
+	if len(outs) == 1 {
+		return outs[0], nil
+	}
+	src, err := pl.pluginCache.NewChan(specs[0].chType, pl.chanSize(specs[0].name))
+	if err != nil {
+		return nil, err
+	}
+	pl.channels = append(pl.channels, src)
+	in, err := chanValue(src)
+	if err != nil {
+		return nil, err
+	}
+	var teeOuts []reflect.Value
+	for i, o := range outs {
+		out, err := chanValue(o)
+		if err != nil {
+			return nil, err
+		}
+		if out.Type() != in.Type() {
+			return nil, fmt.Errorf("Channel '%s' is not of the same type as channel '%s'", specs[i], specs[0])
+		}
+		teeOuts = append(teeOuts, out)
+	}
+	logger.Trace.Printf("Teeing stage output to %d channels\n", len(teeOuts))
+	pl.wg.Add(1)
+	go tee(in, teeOuts, pl.wg)
+	return src, nil
+}
+
+// mergeInto creates a private producer channel to be merged into the shared channel ch.
+func (pl *Pipeline) mergeInto(s chanSpec, ch interface{}) (interface{}, error) {
+	src, err := pl.pluginCache.NewChan(s.chType, pl.chanSize(s.name))
+	if err != nil {
+		return nil, err
+	}
+	pl.channels = append(pl.channels, src)
+	in, err := chanValue(src)
+	if err != nil {
+		return nil, err
+	}
+	out, err := chanValue(ch)
+	if err != nil {
+		return nil, err
+	}
+	fi, ok := pl.merges[s.name]
+	if !ok {
+		fi = &fanIn{out: out}
+		pl.merges[s.name] = fi
+	}
+	fi.ins = append(fi.ins, in)
+	return src, nil
+}
+
+// startMerges starts a merger for each shared channel written by several stages.
+func (pl *Pipeline) startMerges() {
+	for name, fi := range pl.merges {
+		logger.Trace.Printf("Merging %d producers into channel %s\n", len(fi.ins), name)
+		pl.wg.Add(1)
+		go merge(fi.ins, fi.out, pl.wg)
+	}
+}
+
+// tee sends every record received on in to all outs, and closes them once in is closed.
+// The first out receives the record itself, and the others receive copies of event records,
+// whose contexts are updated in place by stages such as the enricher.
+func tee(in reflect.Value, outs []reflect.Value, wg *sync.WaitGroup) {
+	defer wg.Done()
+	rs := make([]reflect.Value, len(outs))
+	for {
+		r, ok := in.Recv()
+		if !ok {
+			break
+		}
+		rs[0] = r
+		for i := 1; i < len(rs); i++ {
+			rs[i] = branchCopy(r)
+		}
+		for i, out := range outs {
+			out.Send(rs[i])
+		}
+	}
+	for _, out := range outs {
+		out.Close()
+	}
+}
+
+// branchCopy returns the record sent to an extra branch of a tee. Event records are copied before
+// any branch receives them, and other records are shared.
+func branchCopy(r reflect.Value) reflect.Value {
+	if rec, ok := r.Interface().(*engine.Record); ok {
+		return reflect.ValueOf(rec.Copy())
+	}
+	return r
+}
+
+// merge forwards records received on ins to out, and closes out once all ins are closed.
+func merge(ins []reflect.Value, out reflect.Value, wg *sync.WaitGroup) {
+	defer wg.Done()
+	var fwg sync.WaitGroup
+	for _, in := range ins {
+		fwg.Add(1)
+		go func(in reflect.Value) {
+			defer fwg.Done()
+			for {
+				r, ok := in.Recv()
+				if !ok {
+					return
+				}
+				out.Send(r)
+			}
+		}(in)
+	}
+	fwg.Wait()
+	out.Close()
+}
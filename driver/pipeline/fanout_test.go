@@ -0,0 +1,190 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package pipeline
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/flattener"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+// drain receives all records of ch until it is closed.
+func drain(ch reflect.Value) []interface{} {
+	var rs []interface{}
+	for {
+		r, ok := ch.Recv()
+		if !ok {
+			return rs
+		}
+		rs = append(rs, r.Interface())
+	}
+}
+
+func TestParseChanSpecs(t *testing.T) {
+	specs, err := parseChanSpecs("a flattenerchan, b  eventchan")
+	assert.NoError(t, err)
+	assert.Equal(t, []chanSpec{{"a", "flattenerchan"}, {"b", "eventchan"}}, specs)
+	assert.Equal(t, "b eventchan", specs[1].String())
+	_, err = parseChanSpecs("a")
+	assert.Error(t, err)
+	_, err = parseChanSpecs("a flattenerchan, a eventchan")
+	assert.Error(t, err)
+}
+
+func TestTee(t *testing.T) {
+	in := reflect.ValueOf(make(chan int))
+	outs := []reflect.Value{reflect.ValueOf(make(chan int, 100)), reflect.ValueOf(make(chan int, 100)), reflect.ValueOf(make(chan int, 100))}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go tee(in, outs, &wg)
+	for i := 0; i < 100; i++ {
+		in.Send(reflect.ValueOf(i))
+	}
+	in.Close()
+	wg.Wait()
+	for _, out := range outs {
+		rs := drain(out)
+		assert.Len(t, rs, 100)
+		for i, r := range rs {
+			assert.Equal(t, i, r)
+		}
+	}
+}
+
+func TestTeeCopiesRecords(t *testing.T) {
+	in := reflect.ValueOf(make(chan *engine.Record))
+	outs := []reflect.Value{reflect.ValueOf(make(chan *engine.Record)), reflect.ValueOf(make(chan *engine.Record))}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go tee(in, outs, &wg)
+	// branches update and read record contexts concurrently, like an enricher and an exporter
+	res := make([][]*engine.Record, len(outs))
+	var cwg sync.WaitGroup
+	for i, out := range outs {
+		cwg.Add(1)
+		go func(i int, out reflect.Value) {
+			defer cwg.Done()
+			for _, r := range drain(out) {
+				rec := r.(*engine.Record)
+				rec.Ctx.AddRule(engine.Rule{Name: string(rune('a' + i))})
+				rec.Ctx.AddEnrichment(string(rune('a'+i)), i)
+				for range rec.Ctx.GetEnrichment() {
+				}
+				res[i] = append(res[i], rec)
+			}
+		}(i, out)
+	}
+	var recs []*engine.Record
+	for i := 0; i < 100; i++ {
+		r := engine.NewRecord(sfgo.FlatRecord{}, nil)
+		r.Ctx.AddRule(engine.Rule{Name: "r"})
+		r.Ctx.AddEnrichment("k", i)
+		recs = append(recs, r)
+		in.Send(reflect.ValueOf(r))
+	}
+	in.Close()
+	wg.Wait()
+	cwg.Wait()
+	for i, rs := range res {
+		assert.Len(t, rs, len(recs))
+		for j, r := range rs {
+			if i == 0 {
+				assert.Same(t, recs[j], r)
+			} else {
+				assert.NotSame(t, recs[j], r)
+			}
+			assert.Equal(t, map[string]interface{}{"k": j, string(rune('a' + i)): i}, r.Ctx.GetEnrichment())
+			assert.Len(t, r.Ctx.GetRules(), 2)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	ins := []reflect.Value{reflect.ValueOf(make(chan int)), reflect.ValueOf(make(chan int)), reflect.ValueOf(make(chan int))}
+	out := reflect.ValueOf(make(chan int, 300))
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go merge(ins, out, &wg)
+	send := func(in reflect.Value, base int) {
+		for i := 0; i < 100; i++ {
+			in.Send(reflect.ValueOf(base + i))
+		}
+	}
+	var pwg sync.WaitGroup
+	for p, in := range ins[:2] {
+		pwg.Add(1)
+		go func(p int, in reflect.Value) {
+			defer pwg.Done()
+			send(in, p*100)
+			in.Close()
+		}(p, in)
+	}
+	pwg.Wait()
+	// out stays open while a producer is still open
+	send(ins[2], 200)
+	ins[2].Close()
+	wg.Wait()
+	var vs []int
+	for _, r := range drain(out) {
+		vs = append(vs, r.(int))
+	}
+	sort.Ints(vs)
+	assert.Len(t, vs, 300)
+	for i, v := range vs {
+		assert.Equal(t, i, v)
+	}
+}
+
+func TestOutChanFanout(t *testing.T) {
+	pl := New("", "", "")
+	pl.chanConf = map[string]map[string]string{"a": chanSchema.Resolve(map[string]string{ChanSizeConfig: "7"})}
+	src, err := pl.outChan("a flattenerchan, b flattenerchan", map[string]int{"a": 1, "b": 1})
+	assert.NoError(t, err)
+	in := src.(*flattener.FlatChannel).In
+	assert.Equal(t, 7, cap(in))
+	p1, err := pl.outChan("m flattenerchan", map[string]int{"m": 2})
+	assert.NoError(t, err)
+	p2, err := pl.outChan("m flattenerchan", map[string]int{"m": 2})
+	assert.NoError(t, err)
+	pl.startMerges()
+	fr := &sfgo.FlatRecord{}
+	in <- fr
+	close(in)
+	p1.(*flattener.FlatChannel).In <- fr
+	close(p1.(*flattener.FlatChannel).In)
+	p2.(*flattener.FlatChannel).In <- fr
+	close(p2.(*flattener.FlatChannel).In)
+	pl.wg.Wait()
+	for name, n := range map[string]int{"a": 1, "b": 1, "m": 2} {
+		ch, err := pl.pluginCache.GetChan(name+" flattenerchan", ChanSize)
+		assert.NoError(t, err)
+		rs := drain(reflect.ValueOf(ch.(*flattener.FlatChannel).In))
+		assert.Len(t, rs, n, name)
+		for _, r := range rs {
+			assert.Same(t, fr, r)
+		}
+	}
+}
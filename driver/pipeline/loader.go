@@ -0,0 +1,320 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package pipeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// envRef matches environment variable references of the form ${VAR} or ${VAR:-default}. $${VAR} escapes a reference.
+var envRef = regexp.MustCompile(`\$(\$)?\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// loadConfig reads a JSON or YAML pipeline config file. Stage includes and environment variable
+// references are resolved, and structured stage attributes are flattened into plugin configs.
+func loadConfig(path string) (*Config, error) {
+	doc, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	stages, ok := doc[PipelineConfig].([]interface{})
+	if !ok {
+		return nil, errors.New("Pipeline config must define a list of stages in the 'pipeline' attribute")
+	}
+	abs, _ := filepath.Abs(path)
+	conf := new(Config)
+	for i, s := range stages {
+		stage, ok := s.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Pipeline stage %d must be an object", i+1)
+		}
+		if stage, err = resolveIncludes(stage, filepath.Dir(path), []string{abs}); err != nil {
+			return nil, fmt.Errorf("Pipeline stage %d: %v", i+1, err)
+		}
+		pc := make(PluginConfig)
+		if err := flatten("", stage, pc); err != nil {
+			return nil, fmt.Errorf("Pipeline stage %d: %v", i+1, err)
+		}
+		conf.Pipeline = append(conf.Pipeline, pc)
+	}
+	conf.Driver = make(PluginConfig)
+	if d, ok := doc[DriverConfig]; ok {
+		if _, ok := d.(map[string]interface{}); !ok {
+			return nil, errors.New("Driver config must be an object")
+		}
+		if err := flatten("", d, conf.Driver); err != nil {
+			return nil, fmt.Errorf("Driver config: %v", err)
+		}
+	}
+	conf.Channels = make(map[string]PluginConfig)
+	if c, ok := doc[ChannelsConfig]; ok {
+		chans, ok := c.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("Channels config must be an object")
+		}
+		for name, ch := range chans {
+			if _, ok := ch.(map[string]interface{}); !ok {
+				return nil, fmt.Errorf("Channel config '%s' must be an object", name)
+			}
+			pc := make(PluginConfig)
+			if err := flatten("", ch, pc); err != nil {
+				return nil, fmt.Errorf("Channel config '%s': %v", name, err)
+			}
+			conf.Channels[name] = pc
+		}
+	}
+	return conf, nil
+}
+
+// readConfigFile parses a YAML (.yaml, .yml) or JSON config file into a map with lower case keys.
+func readConfigFile(path string) (map[string]interface{}, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, &doc)
+	default:
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		err = dec.Decode(&doc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse config file %s: %v", path, err)
+	}
+	m, ok := normalize(doc, "").(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Config file %s must contain an object", path)
+	}
+	return m, nil
+}
+
+// normalize converts YAML maps into maps with lower case string keys. The names of configured
+// channels are identifiers referenced by stages, and are kept as written. Key is the dotted key of v.
+func normalize(v interface{}, key string) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{})
+		for k, e := range t {
+			normalizeEntry(m, key, fmt.Sprint(k), e)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{})
+		for k, e := range t {
+			normalizeEntry(m, key, k, e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range t {
+			t[i] = normalize(e, key)
+		}
+	}
+	return v
+}
+
+func normalizeEntry(m map[string]interface{}, key string, k string, v interface{}) {
+	if key != ChannelsConfig {
+		k = strings.ToLower(k)
+	}
+	m[k] = normalize(v, joinKey(key, k))
+}
+
+// resolveIncludes merges the fragments listed in the include attribute of a stage into the stage.
+// Include paths are relative to dir, and stage attributes override included attributes.
+func resolveIncludes(stage map[string]interface{}, dir string, stack []string) (map[string]interface{}, error) {
+	inc, ok := stage[IncludeConfig]
+	if !ok {
+		return stage, nil
+	}
+	var paths []string
+	switch t := inc.(type) {
+	case string:
+		paths = append(paths, t)
+	case []interface{}:
+		for _, p := range t {
+			s, ok := p.(string)
+			if !ok {
+				return nil, errors.New("include must be a path or a list of paths")
+			}
+			paths = append(paths, s)
+		}
+	default:
+		return nil, errors.New("include must be a path or a list of paths")
+	}
+	merged := make(map[string]interface{})
+	for _, p := range paths {
+		p, err := interpolate(p)
+		if err != nil {
+			return nil, err
+		}
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(dir, p)
+		}
+		p, _ = filepath.Abs(p)
+		for _, s := range stack {
+			if s == p {
+				return nil, fmt.Errorf("circular include of %s", p)
+			}
+		}
+		frag, err := readConfigFile(p)
+		if err != nil {
+			return nil, err
+		}
+		if frag, err = resolveIncludes(frag, filepath.Dir(p), append(stack, p)); err != nil {
+			return nil, err
+		}
+		mergeMaps(merged, frag)
+	}
+	delete(stage, IncludeConfig)
+	mergeMaps(merged, stage)
+	return merged, nil
+}
+
+// mergeMaps deep merges src into dst.
+func mergeMaps(dst map[string]interface{}, src map[string]interface{}) {
+	for k, v := range src {
+		if sm, ok := v.(map[string]interface{}); ok {
+			if dm, ok := dst[k].(map[string]interface{}); ok {
+				mergeMaps(dm, sm)
+				continue
+			}
+			dm := make(map[string]interface{})
+			mergeMaps(dm, sm)
+			v = dm
+		}
+		dst[k] = v
+	}
+}
+
+// flatten adds the attributes of a structured value to a plugin config. Nested objects are
+// flattened into dotted keys (e.g., tls.cert), and lists into comma-separated values, so list
+// items and names cannot contain commas. A list of named objects becomes the list of names, and
+// each object's attributes are prefixed with its name (e.g., sinks: [{name: siem, export: syslog}]
+// becomes sinks=siem and siem.export=syslog).
+func flatten(key string, v interface{}, pc PluginConfig) error {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			if err := flatten(joinKey(key, k), e, pc); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		var items []string
+		for _, e := range t {
+			switch et := e.(type) {
+			case map[string]interface{}:
+				name, ok := et[NameConfig].(string)
+				if !ok || name == "" {
+					return fmt.Errorf("objects in list '%s' must have a name", key)
+				}
+				if strings.Contains(name, ",") {
+					return fmt.Errorf("name '%s' in list '%s' must not contain commas", name, key)
+				}
+				items = append(items, name)
+				prefix := name
+				if i := strings.LastIndex(key, "."); i >= 0 {
+					prefix = joinKey(key[:i], name)
+				}
+				for k, f := range et {
+					if k == NameConfig {
+						continue
+					}
+					if err := flatten(joinKey(prefix, k), f, pc); err != nil {
+						return err
+					}
+				}
+			case []interface{}:
+				return fmt.Errorf("nested lists are not supported in '%s'", key)
+			default:
+				s, err := scalar(e)
+				if err != nil {
+					return err
+				}
+				if strings.Contains(s, ",") {
+					return fmt.Errorf("item '%s' in list '%s' must not contain commas", s, key)
+				}
+				items = append(items, s)
+			}
+		}
+		pc[key] = strings.Join(items, ",")
+		return nil
+	}
+	s, err := scalar(v)
+	if err != nil {
+		return err
+	}
+	pc[key] = s
+	return nil
+}
+
+// scalar returns the string value of a scalar, with environment variable references resolved.
+func scalar(v interface{}) (string, error) {
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return interpolate(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+// interpolate replaces environment variable references in s.
+func interpolate(s string) (string, error) {
+	var err error
+	r := envRef.ReplaceAllStringFunc(s, func(m string) string {
+		sm := envRef.FindStringSubmatch(m)
+		if sm[1] != "" {
+			return m[1:]
+		}
+		if v, ok := os.LookupEnv(sm[2]); ok {
+			return v
+		}
+		if sm[3] != "" {
+			return sm[4]
+		}
+		err = fmt.Errorf("environment variable '%s' is not set", sm[2])
+		return m
+	})
+	return r, err
+}
+
+func joinKey(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,198 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package pipeline
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfigFile(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func tempConfigDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "loader")
+	assert.NoError(t, err)
+	return dir
+}
+
+func TestLoadConfigFormats(t *testing.T) {
+	dir := tempConfigDir(t)
+	defer os.RemoveAll(dir)
+	yc, err := loadConfig(writeConfigFile(t, dir, "pipeline.yaml", `
+pipeline:
+  - processor: exporter
+    In: evt eventchan
+    queue: 512
+    flat: true
+    handlers: [hosts, passwd]
+    tls:
+      cert: c.pem
+    sinks:
+      - name: siem
+        export: syslog
+      - name: all
+driver:
+  follow: true
+channels:
+  evt:
+    size: 10
+`))
+	assert.NoError(t, err)
+	jc, err := loadConfig(writeConfigFile(t, dir, "pipeline.json", `{
+  "pipeline": [{
+    "processor": "exporter",
+    "IN": "evt eventchan",
+    "queue": 512,
+    "flat": true,
+    "handlers": ["hosts", "passwd"],
+    "tls": {"cert": "c.pem"},
+    "sinks": [{"name": "siem", "export": "syslog"}, {"name": "all"}]
+  }],
+  "driver": {"follow": true},
+  "channels": {"evt": {"size": 10}}
+}`))
+	assert.NoError(t, err)
+	assert.Equal(t, yc, jc)
+	assert.Equal(t, PluginConfig{
+		"processor":   "exporter",
+		"in":          "evt eventchan",
+		"queue":       "512",
+		"flat":        "true",
+		"handlers":    "hosts,passwd",
+		"tls.cert":    "c.pem",
+		"sinks":       "siem,all",
+		"siem.export": "syslog",
+	}, yc.Pipeline[0])
+	assert.Equal(t, PluginConfig{"follow": "true"}, yc.Driver)
+	assert.Equal(t, map[string]PluginConfig{"evt": {"size": "10"}}, yc.Channels)
+}
+
+func TestLoadConfigLegacy(t *testing.T) {
+	conf, err := loadConfig("../../resources/pipelines/pipeline.distribution.json")
+	assert.NoError(t, err)
+	assert.Len(t, conf.Pipeline, 3)
+	assert.Equal(t, "flat flattenerchan", conf.Pipeline[0][OutChanConfig])
+	assert.Equal(t, "514", conf.Pipeline[2]["port"])
+	assert.Empty(t, conf.Driver)
+	assert.Empty(t, conf.Channels)
+}
+
+func TestLoadConfigIncludes(t *testing.T) {
+	dir := tempConfigDir(t)
+	defer os.RemoveAll(dir)
+	writeConfigFile(t, dir, "stages/base.yaml", "export: file\npath: base.out\ntls: {cert: base.pem, key: base.key}\n")
+	writeConfigFile(t, dir, "stages/exporter.json", `{"include": "base.yaml", "path": "exporter.out", "tls": {"cert": "exporter.pem"}}`)
+	conf, err := loadConfig(writeConfigFile(t, dir, "pipeline.yaml", `
+pipeline:
+  - include: [stages/exporter.json]
+    processor: exporter
+    in: evt eventchan
+    tls:
+      key: stage.key
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, PluginConfig{
+		"processor": "exporter",
+		"in":        "evt eventchan",
+		"export":    "file",
+		"path":      "exporter.out",
+		"tls.cert":  "exporter.pem",
+		"tls.key":   "stage.key",
+	}, conf.Pipeline[0])
+	writeConfigFile(t, dir, "stages/a.yaml", "include: b.yaml\n")
+	writeConfigFile(t, dir, "stages/b.yaml", "include: a.yaml\n")
+	_, err = loadConfig(writeConfigFile(t, dir, "circular.yaml", "pipeline:\n  - include: stages/a.yaml\n"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "circular include")
+	_, err = loadConfig(writeConfigFile(t, dir, "self.yaml", "pipeline:\n  - include: self.yaml\n"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "circular include")
+	_, err = loadConfig(writeConfigFile(t, dir, "missing.yaml", "pipeline:\n  - include: stages/missing.yaml\n"))
+	assert.Error(t, err)
+}
+
+func TestLoadConfigEnv(t *testing.T) {
+	dir := tempConfigDir(t)
+	defer os.RemoveAll(dir)
+	os.Setenv("SF_LOADER_TEST_HOST", "siem.example.com")
+	defer os.Unsetenv("SF_LOADER_TEST_HOST")
+	os.Unsetenv("SF_LOADER_TEST_UNSET")
+	conf, err := loadConfig(writeConfigFile(t, dir, "pipeline.yaml", `
+pipeline:
+  - processor: exporter
+    host: ${SF_LOADER_TEST_HOST}
+    port: ${SF_LOADER_TEST_UNSET:-514}
+    tag: ${SF_LOADER_TEST_UNSET:-}
+    path: /var/$${SF_LOADER_TEST_HOST}/${SF_LOADER_TEST_HOST}.out
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, "siem.example.com", conf.Pipeline[0]["host"])
+	assert.Equal(t, "514", conf.Pipeline[0]["port"])
+	assert.Equal(t, "", conf.Pipeline[0]["tag"])
+	assert.Equal(t, "/var/${SF_LOADER_TEST_HOST}/siem.example.com.out", conf.Pipeline[0]["path"])
+	_, err = loadConfig(writeConfigFile(t, dir, "unset.yaml", "pipeline:\n  - host: ${SF_LOADER_TEST_UNSET}\n"))
+	assert.Error(t, err)
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	dir := tempConfigDir(t)
+	defer os.RemoveAll(dir)
+	for name, content := range map[string]string{
+		"nopipeline.yaml": "driver: {}\n",
+		"stage.yaml":      "pipeline: [exporter]\n",
+		"comma.yaml":      "pipeline:\n  - handlers: [hosts, 'a,b']\n",
+		"name.yaml":       "pipeline:\n  - sinks: [{name: 'a,b'}]\n",
+		"unnamed.yaml":    "pipeline:\n  - sinks: [{export: file}]\n",
+		"nested.yaml":     "pipeline:\n  - handlers: [[hosts]]\n",
+		"channel.yaml":    "pipeline: []\nchannels: {evt: 10}\n",
+		"invalid.json":    "{",
+	} {
+		_, err := loadConfig(writeConfigFile(t, dir, name, content))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestChannelNames(t *testing.T) {
+	dir := tempConfigDir(t)
+	defer os.RemoveAll(dir)
+	conf, err := loadConfig(writeConfigFile(t, dir, "pipeline.yaml", `
+pipeline:
+  - processor: policyengine
+    in: flatChan flattenerchan
+channels:
+  flatChan:
+    Size: 10
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]PluginConfig{"flatChan": {"size": "10"}}, conf.Channels)
+	pl := New("", "", "")
+	assert.NoError(t, pl.validateChannels(conf, []string{"flatChan"}))
+	assert.Equal(t, 10, pl.chanSize("flatChan"))
+	assert.Error(t, pl.validateChannels(conf, []string{"flatchan"}))
+}
@@ -20,9 +20,12 @@
 package pipeline
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
 	"github.com/sysflow-telemetry/sf-apis/go/plugins"
@@ -34,22 +37,63 @@ type Pipeline struct {
 	driver      plugins.SFDriver
 	processors  []plugins.SFProcessor
 	channels    []interface{}
+	merges      map[string]*fanIn
+	valves      map[string]valveChan
+	root        interface{}
+	chanConf    map[string]map[string]string
+	driverConf  map[string]string
+	driverAttrs map[string]string
 	handlers    []plugins.SFHandler
 	pluginCache *PluginCache
 	config      string
 	pluginDir   string
 	driverDir   string
-	running     bool
+	ctx         context.Context
+	cancel      context.CancelFunc
+	abort       context.Context
+	abortCancel context.CancelFunc
+}
+
+// ContextAware is implemented by plugins that support context-based cancellation. The context
+// is canceled when the pipeline fails to drain within the shutdown timeout.
+type ContextAware interface {
+	SetContext(ctx context.Context)
+}
+
+// InFlightCounter is implemented by plugins that buffer records internally.
+type InFlightCounter interface {
+	InFlight() int
 }
 
 // New creates a new pipeline object
 func New(driverDir string, pluginDir string, config string) *Pipeline {
-	return &Pipeline{config: config,
+	pl := &Pipeline{config: config,
 		driverDir:   driverDir,
 		pluginDir:   pluginDir,
 		wg:          new(sync.WaitGroup),
+		merges:      make(map[string]*fanIn),
+		valves:      make(map[string]valveChan),
+		driverAttrs: make(map[string]string),
 		pluginCache: NewPluginCache(config),
 	}
+	pl.ctx, pl.cancel = context.WithCancel(context.Background())
+	pl.abort, pl.abortCancel = context.WithCancel(context.Background())
+	return pl
+}
+
+// SetDriverAttr sets a driver config attribute, overriding the config file and environment.
+func (pl *Pipeline) SetDriverAttr(key string, value string) {
+	pl.driverAttrs[key] = value
+}
+
+// GetDriverConfig returns the driver config, with defaults for unset attributes.
+func (pl *Pipeline) GetDriverConfig() map[string]string {
+	return pl.driverConf
+}
+
+// Context returns the pipeline context, which is canceled when the pipeline is shut down.
+func (pl *Pipeline) Context() context.Context {
+	return pl.ctx
 }
 
 // GetNumChannels returns the number of channels in the pipeline
@@ -77,29 +121,54 @@ func (pl *Pipeline) AddChannel(channelName string, channel interface{}) {
 	pl.pluginCache.AddChannel(channelName, channel)
 }
 
-// Load loads and enables the pipeline
-func (pl *Pipeline) Load(driverName string) error {
+// Resolve loads the pipeline plugins and config, including environment overrides, and validates
+// the config without initializing the plugins.
+func (pl *Pipeline) Resolve(driverName string) (*Config, error) {
 	if err := pl.pluginCache.LoadDrivers(pl.driverDir); err != nil {
 		logger.Error.Println("Unable to load dynamic driver: ", err)
-		return err
+		return nil, err
 	}
 	if err := pl.pluginCache.LoadPlugins(pl.pluginDir); err != nil {
 		logger.Error.Println("Unable to load dynamic plugins: ", err)
-		return err
+		return nil, err
 	}
 	conf, err := pl.pluginCache.GetConfig()
 	if err != nil {
 		logger.Error.Println("Unable to load pipeline config: ", err)
-		return err
+		return nil, err
 	}
-	setManifestInfo(conf)
 	if pl.driver, err = pl.pluginCache.GetDriver(driverName); err != nil {
 		logger.Error.Println("Unable to load driver: ", err)
+		return nil, err
+	}
+	for k, v := range pl.pluginCache.getEnv(pl.driver.GetName()) {
+		conf.Driver[k] = v
+	}
+	for k, v := range pl.driverAttrs {
+		conf.Driver[k] = v
+	}
+	if err = pl.validate(conf); err != nil {
+		logger.Error.Println("Invalid pipeline config: ", err)
+		return nil, err
+	}
+	return conf, nil
+}
+
+// Load loads and enables the pipeline
+func (pl *Pipeline) Load(driverName string) error {
+	conf, err := pl.Resolve(driverName)
+	if err != nil {
+		return err
+	}
+	setManifestInfo(conf)
+	producers, err := countProducers(conf)
+	if err != nil {
+		logger.Error.Println(err)
 		return err
 	}
 	var in interface{}
 	var out interface{}
-	for _, p := range conf.Pipeline {
+	for i, p := range conf.Pipeline {
 		hdler := false
 		var hdl plugins.SFHandler
 		if val, ok := p[HdlConfig]; ok {
@@ -127,13 +196,30 @@ func (pl *Pipeline) Load(driverName string) error {
 				logger.Error.Println(err)
 				return err
 			}
+			if ca, ok := prc.(ContextAware); ok {
+				ca.SetContext(pl.abort)
+			}
 		} else {
 			logger.Error.Println("Processor or handler tag must exist in plugin config")
-			return err
+			return errors.New("Processor or handler tag must exist in plugin config")
 		}
 		if v, o := p[InChanConfig]; o {
-			in, err = pl.pluginCache.GetChan(v, ChanSize)
+			specs, err := parseChanSpecs(v)
+			if err != nil {
+				logger.Error.Println(err)
+				return err
+			}
+			if in, err = pl.pluginCache.GetChan(v, pl.chanSize(specs[0].name)); err != nil {
+				logger.Error.Println(err)
+				return err
+			}
 			pl.channels = append(pl.channels, in)
+			if i == 0 {
+				if pl.root, err = pl.throttle(specs[0], in); err != nil {
+					logger.Error.Println(err)
+					return err
+				}
+			}
 			chp := fmt.Sprintf("%T", in)
 			logger.Trace.Println(chp)
 		} else {
@@ -141,9 +227,11 @@ func (pl *Pipeline) Load(driverName string) error {
 			return errors.New("in tag must exist in plugin config")
 		}
 		if v, o := p[OutChanConfig]; o {
-			out, err = pl.pluginCache.GetChan(v, ChanSize)
+			if out, err = pl.outChan(v, producers); err != nil {
+				logger.Error.Println(err)
+				return err
+			}
 			chp := fmt.Sprintf("%T", out)
-			pl.channels = append(pl.channels, out)
 			logger.Trace.Println(chp)
 			prc.SetOutChan(out)
 		}
@@ -151,9 +239,36 @@ func (pl *Pipeline) Load(driverName string) error {
 		pl.wg.Add(1)
 		go pl.process(prc, in)
 	}
+	pl.startMerges()
 	return nil
 }
 
+// countProducers returns the number of stages writing to each channel in the pipeline config.
+func countProducers(conf *Config) (map[string]int, error) {
+	producers := make(map[string]int)
+	var root string
+	for i, p := range conf.Pipeline {
+		if v, ok := p[InChanConfig]; ok && i == 0 {
+			if fields := strings.Fields(v); len(fields) > 0 {
+				root = fields[0]
+			}
+		}
+		if v, ok := p[OutChanConfig]; ok {
+			specs, err := parseChanSpecs(v)
+			if err != nil {
+				return nil, err
+			}
+			for _, s := range specs {
+				if s.name == root {
+					return nil, fmt.Errorf("Channel '%s' is fed by the driver and cannot be used as an out channel", root)
+				}
+				producers[s.name]++
+			}
+		}
+	}
+	return producers, nil
+}
+
 // Init initializes the pipeline
 func (pl *Pipeline) Init(path string) error {
 	logger.Info.Println("Starting the processing pipeline")
@@ -163,27 +278,89 @@ func (pl *Pipeline) Init(path string) error {
 		logger.Error.Println("Driver initialization error: " + err.Error())
 		return err
 	}
-	// start processing
-	pl.running = true
-	err = pl.driver.Run(path, &(pl.running))
-	if err != nil {
-		pl.running = false
+	defer pl.driver.Cleanup()
+	// start processing; drivers stop when the pipeline context is canceled, not through the running flag
+	running := true
+	if err = pl.driver.Run(path, &running); err != nil {
 		logger.Error.Println("Cannot start the driver: " + err.Error())
 		return err
 	}
+	pl.reportDrops()
 	return nil
 }
 
-// Shutdown stops the pipeline
+// Shutdown stops the pipeline driver. In-flight records are drained through the pipeline.
 func (pl *Pipeline) Shutdown() error {
 	logger.Info.Println("Stopping the processing pipeline")
-	pl.running = false
-	pl.driver.Cleanup()
+	pl.cancel()
 	return nil
 }
 
+// Drain waits for the pipeline to flush its in-flight records after a shutdown. If the pipeline
+// does not drain within timeout, plugins are aborted, a summary of the dropped records is logged,
+// and false is returned.
+func (pl *Pipeline) Drain(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		pl.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		logger.Info.Println("Processing pipeline drained")
+		return true
+	case <-time.After(timeout):
+	}
+	total := 0
+	for name, n := range pl.inFlight() {
+		logger.Warn.Printf("Dropping %d in-flight records in channel %s\n", n, name)
+		total += n
+	}
+	for _, prc := range pl.processors {
+		if c, ok := prc.(InFlightCounter); ok {
+			if n := c.InFlight(); n > 0 {
+				logger.Warn.Printf("Dropping %d in-flight records in plugin %s\n", n, prc.GetName())
+				total += n
+			}
+		}
+	}
+	logger.Warn.Printf("Processing pipeline did not drain within %v, dropping %d in-flight records\n", timeout, total)
+	pl.reportDrops()
+	pl.abortCancel()
+	return false
+}
+
+// inFlight returns the number of records buffered in each non-empty pipeline channel.
+func (pl *Pipeline) inFlight() map[string]int {
+	counts := make(map[string]int)
+	named := make(map[interface{}]string)
+	for name, ch := range pl.pluginCache.chanMap {
+		named[ch] = name
+	}
+	seen := make(map[interface{}]bool)
+	for _, ch := range pl.channels {
+		if seen[ch] {
+			continue
+		}
+		seen[ch] = true
+		v, err := chanValue(ch)
+		if err != nil || v.Len() == 0 {
+			continue
+		}
+		name, ok := named[ch]
+		if !ok {
+			name = "(internal)"
+		}
+		counts[name] += v.Len()
+	}
+	return counts
+}
+
 // GetRootChannel returns the first channel in the pipeline
 func (pl *Pipeline) GetRootChannel() interface{} {
+	if pl.root != nil {
+		return pl.root
+	}
 	if len(pl.channels) > 0 {
 		return pl.channels[0]
 	}
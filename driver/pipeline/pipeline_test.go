@@ -0,0 +1,109 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+// holdingProcessor holds the records it reads until it is released or aborted.
+type holdingProcessor struct {
+	release chan struct{}
+	ctx     context.Context
+	held    int64
+}
+
+func (p *holdingProcessor) GetName() string                   { return "holder" }
+func (p *holdingProcessor) Register(pc plugins.SFPluginCache) {}
+func (p *holdingProcessor) Init(conf map[string]string) error { return nil }
+func (p *holdingProcessor) SetOutChan(ch interface{})         {}
+func (p *holdingProcessor) Cleanup()                          {}
+func (p *holdingProcessor) SetContext(ctx context.Context)    { p.ctx = ctx }
+func (p *holdingProcessor) InFlight() int                     { return int(atomic.LoadInt64(&p.held)) }
+func (p *holdingProcessor) Process(ch interface{}, wg *sync.WaitGroup) {
+	defer wg.Done()
+	for range ch.(*engine.RecordChannel).In {
+		atomic.AddInt64(&p.held, 1)
+	}
+	select {
+	case <-p.release:
+		atomic.StoreInt64(&p.held, 0)
+	case <-p.ctx.Done():
+	}
+}
+
+// startHolder runs a holding processor stage that has read n records, and a channel without
+// consumers holding m records.
+func startHolder(t *testing.T, pl *Pipeline, n int, m int) *holdingProcessor {
+	p := &holdingProcessor{release: make(chan struct{})}
+	p.SetContext(pl.abort)
+	in, err := pl.pluginCache.GetChan("evt eventchan", n)
+	assert.NoError(t, err)
+	pending, err := pl.pluginCache.GetChan("pending eventchan", m)
+	assert.NoError(t, err)
+	pl.channels = append(pl.channels, in, pending)
+	pl.processors = append(pl.processors, p)
+	pl.wg.Add(1)
+	go pl.process(p, in)
+	for i := 0; i < n; i++ {
+		in.(*engine.RecordChannel).In <- engine.NewRecord(sfgo.FlatRecord{}, nil)
+	}
+	close(in.(*engine.RecordChannel).In)
+	for i := 0; i < m; i++ {
+		pending.(*engine.RecordChannel).In <- engine.NewRecord(sfgo.FlatRecord{}, nil)
+	}
+	eventually(t, func() bool { return p.InFlight() == n })
+	return p
+}
+
+func TestDrain(t *testing.T) {
+	pl := New("", "", "")
+	p := startHolder(t, pl, 3, 0)
+	close(p.release)
+	assert.True(t, pl.Drain(5*time.Second))
+	assert.NoError(t, pl.abort.Err())
+}
+
+func TestDrainTimeout(t *testing.T) {
+	var b bytes.Buffer
+	logger.Warn.SetOutput(&b)
+	defer logger.Warn.SetOutput(os.Stdout)
+	pl := New("", "", "")
+	startHolder(t, pl, 3, 2)
+	assert.False(t, pl.Drain(10*time.Millisecond))
+	// plugins holding records are aborted
+	assert.Error(t, pl.abort.Err())
+	pl.Wait()
+	assert.Contains(t, b.String(), "Dropping 2 in-flight records in channel pending")
+	assert.Contains(t, b.String(), "Dropping 3 in-flight records in plugin holder")
+	assert.Contains(t, b.String(), "did not drain within 10ms, dropping 5 in-flight records")
+}
@@ -23,14 +23,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"plugin"
 	"strings"
 
-	"github.com/spf13/viper"
 	"github.com/sysflow-telemetry/sf-apis/go/ioutils"
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
 	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-processor/core/aggregator"
+	"github.com/sysflow-telemetry/sf-processor/core/enricher"
 	"github.com/sysflow-telemetry/sf-processor/core/exporter"
 	"github.com/sysflow-telemetry/sf-processor/core/policyengine"
 	"github.com/sysflow-telemetry/sf-processor/core/processor"
@@ -44,13 +44,12 @@ type PluginCache struct {
 	procFuncMap map[string]interface{}
 	hdlFuncMap  map[string]interface{}
 	chanFuncMap map[string]interface{}
-	config      *viper.Viper
 	configFile  string
 }
 
 // NewPluginCache creates a new PluginCache instance.
 func NewPluginCache(conf string) *PluginCache {
-	plug := &PluginCache{config: viper.New(),
+	plug := &PluginCache{
 		chanMap:     make(map[string]interface{}),
 		driverMap:   make(map[string]interface{}),
 		procFuncMap: make(map[string]interface{}),
@@ -65,9 +64,13 @@ func NewPluginCache(conf string) *PluginCache {
 func (p *PluginCache) init() {
 	(&processor.SysFlowProcessor{}).Register(p)
 	(&policyengine.PolicyEngine{}).Register(p)
+	(&enricher.Enricher{}).Register(p)
+	(&aggregator.Aggregator{}).Register(p)
 	(&exporter.Exporter{}).Register(p)
 	(&sysflow.FileDriver{}).Register(p)
 	(&sysflow.StreamingDriver{}).Register(p)
+	(&sysflow.TCPDriver{}).Register(p)
+	(&sysflow.ReplayDriver{}).Register(p)
 }
 
 // LoadPlugins loads dynamic plugins to plugin cache from dir path.
@@ -140,23 +143,10 @@ func (p *PluginCache) GetConfig() (*Config, error) {
 	if s.IsDir() {
 		return nil, errors.New("Pipeline config file is not a file")
 	}
-	dir := filepath.Dir(p.configFile)
-	p.config.SetConfigName(strings.TrimSuffix(filepath.Base(p.configFile), filepath.Ext(p.configFile)))
-	p.config.SetConfigType("json")
-	p.config.AddConfigPath(dir)
-
-	conf := new(Config)
-	err = p.config.ReadInConfig()
-
-	if err != nil {
-		return nil, err
-	}
-
-	err = p.config.Unmarshal(conf)
+	conf, err := loadConfig(p.configFile)
 	if err != nil {
 		return nil, err
 	}
-
 	p.updateConfigFromEnv(conf)
 	return conf, nil
 }
@@ -207,28 +197,40 @@ func (p *PluginCache) GetChan(ch string, size int) (interface{}, error) {
 		logger.Trace.Println("Found existing channel ", fields[0])
 		return val, nil
 	}
-	if val, ok := p.chanFuncMap[fields[1]]; ok {
-		funct := val.(func(int) interface{})
-		c := funct(size)
+	if _, ok := p.chanFuncMap[fields[1]]; ok {
+		c, err := p.NewChan(fields[1], size)
+		if err != nil {
+			return nil, err
+		}
 		p.chanMap[fields[0]] = c
 		return c, nil
 	}
 	return nil, fmt.Errorf("Channel '%s' not found in plugin cache", fields[0])
 }
 
+// NewChan creates a new unnamed channel of a given type. The channel is not cached.
+func (p *PluginCache) NewChan(chType string, size int) (interface{}, error) {
+	if val, ok := p.chanFuncMap[chType]; ok {
+		funct := val.(func(int) interface{})
+		return funct(size), nil
+	}
+	return nil, fmt.Errorf("Channel type '%s' not found in plugin cache", chType)
+}
+
 // GetProcessor retrieves a cached plugin processor by name.
 func (p *PluginCache) GetProcessor(name string, hdl plugins.SFHandler, hdlr bool) (plugins.SFProcessor, error) {
 	if val, ok := p.procFuncMap[name]; ok {
 		logger.Trace.Println("Found processor in function map: ", name)
-		var prc plugins.SFProcessor
 		if hdlr {
-			funct := val.(func(plugins.SFHandler) plugins.SFProcessor)
-			prc = funct(hdl)
-		} else {
-			funct := val.(func() plugins.SFProcessor)
-			prc = funct()
+			if funct, ok := val.(func(plugins.SFHandler) plugins.SFProcessor); ok {
+				return funct(hdl), nil
+			}
+			return nil, fmt.Errorf("Plugin '%s' does not accept a handler", name)
+		}
+		if funct, ok := val.(func() plugins.SFProcessor); ok {
+			return funct(), nil
 		}
-		return prc, nil
+		return nil, fmt.Errorf("Plugin '%s' requires a handler", name)
 	}
 	return nil, fmt.Errorf("Plugin '%s' not found in plugin cache", name)
 }
@@ -0,0 +1,262 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
+	"github.com/sysflow-telemetry/sf-processor/driver/manifest"
+)
+
+// reservedKeys are pipeline and manifest attributes accepted in every plugin config.
+var reservedKeys = []string{ModConfig, ProcConfig, HdlConfig, InChanConfig, OutChanConfig,
+	manifest.VersionKey, manifest.JSONSchemaVersionKey, manifest.BuildNumberKey}
+
+// channelGraph tracks the types, producers, and consumers of the pipeline channels.
+type channelGraph struct {
+	types     map[string]string
+	producers map[string]int
+	consumers map[string]int
+}
+
+// DryRun resolves and validates the pipeline config, and writes it to w with the plugin defaults
+// for unset attributes, without running the pipeline.
+func (pl *Pipeline) DryRun(driverName string, w io.Writer) error {
+	conf, err := pl.Resolve(driverName)
+	if err != nil {
+		return err
+	}
+	var stages []map[string]string
+	for _, p := range conf.Pipeline {
+		prc, _, err := pl.stagePlugins(p)
+		if err != nil {
+			return err
+		}
+		if sp, ok := prc.(schema.Provider); ok {
+			p = sp.ConfigSchema().Resolve(p)
+		}
+		stages = append(stages, p)
+	}
+	out := map[string]interface{}{PipelineConfig: stages}
+	if len(pl.driverConf) > 0 {
+		out[DriverConfig] = pl.driverConf
+	}
+	if len(pl.chanConf) > 0 {
+		out[ChannelsConfig] = pl.chanConf
+	}
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
+
+// stagePlugins creates, without initializing, the processor and handler of a pipeline stage.
+func (pl *Pipeline) stagePlugins(p PluginConfig) (plugins.SFProcessor, plugins.SFHandler, error) {
+	name, ok := p[ProcConfig]
+	if !ok {
+		return nil, nil, errors.New("Processor tag must exist in plugin config")
+	}
+	var hdl plugins.SFHandler
+	if v, ok := p[HdlConfig]; ok {
+		var err error
+		if hdl, err = pl.pluginCache.GetHandler(v); err != nil {
+			return nil, nil, err
+		}
+	}
+	prc, err := pl.pluginCache.GetProcessor(name, hdl, hdl != nil)
+	return prc, hdl, err
+}
+
+// validate checks the plugin configs of the pipeline against their schemas, and the channel
+// connections between stages.
+func (pl *Pipeline) validate(conf *Config) error {
+	if len(conf.Pipeline) == 0 {
+		return errors.New("Pipeline config has no stages")
+	}
+	g := &channelGraph{types: make(map[string]string), producers: make(map[string]int), consumers: make(map[string]int)}
+	var errs []string
+	pl.driverConf = conf.Driver
+	if sp, ok := pl.driver.(schema.Provider); ok {
+		sch := sp.ConfigSchema()
+		if err := sch.Validate(conf.Driver); err != nil {
+			errs = append(errs, fmt.Sprintf("driver (%s): %v", pl.driver.GetName(), err))
+		}
+		pl.driverConf = sch.Resolve(conf.Driver)
+	}
+	for i, p := range conf.Pipeline {
+		if err := pl.validateStage(p, g); err != nil {
+			stage := fmt.Sprintf("stage %d", i+1)
+			if name, ok := p[ProcConfig]; ok {
+				stage += " (" + name + ")"
+			}
+			errs = append(errs, fmt.Sprintf("%s: %v", stage, err))
+		}
+	}
+	var root string
+	if fields := strings.Fields(conf.Pipeline[0][InChanConfig]); len(fields) > 0 {
+		root = fields[0]
+	}
+	var names []string
+	for name := range g.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if err := pl.validateChannels(conf, names); err != nil {
+		errs = append(errs, err.Error())
+	}
+	for _, name := range names {
+		if g.producers[name] == 0 && name != root {
+			errs = append(errs, fmt.Sprintf("channel '%s' is not written by any stage", name))
+		}
+		if g.consumers[name] == 0 {
+			errs = append(errs, fmt.Sprintf("channel '%s' is not read by any stage", name))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validateChannels checks the channel configs against the channel schema, and resolves their defaults.
+func (pl *Pipeline) validateChannels(conf *Config, names []string) error {
+	var errs []string
+	pl.chanConf = make(map[string]map[string]string)
+	used := make(map[string]bool)
+	for _, name := range names {
+		c, ok := conf.Channels[name]
+		if !ok {
+			continue
+		}
+		used[name] = true
+		if err := chanSchema.Validate(c); err != nil {
+			errs = append(errs, fmt.Sprintf("channel (%s): %v", name, err))
+		}
+		pl.chanConf[name] = chanSchema.Resolve(c)
+	}
+	var unknown []string
+	for name := range conf.Channels {
+		if !used[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = append(errs, fmt.Sprintf("channel '%s' is configured but not used by any stage", name))
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validateStage checks the config and channels of a pipeline stage, adding its channels to g.
+func (pl *Pipeline) validateStage(p PluginConfig, g *channelGraph) error {
+	prc, hdl, err := pl.stagePlugins(p)
+	if err != nil {
+		return err
+	}
+	var errs []string
+	var inTypes []string
+	outType, outKnown := "", false
+	if sp, ok := prc.(schema.Provider); ok {
+		sch := sp.ConfigSchema()
+		if err := sch.Validate(p, reservedKeys...); err != nil {
+			errs = append(errs, err.Error())
+		}
+		inTypes, outType, outKnown = sch.In, sch.Out, true
+		if outType == "" && hdl != nil {
+			if hp, ok := hdl.(schema.Provider); ok {
+				outType = hp.ConfigSchema().Out
+			} else {
+				outKnown = false
+			}
+		}
+	}
+	if v, ok := p[InChanConfig]; !ok {
+		errs = append(errs, "in tag must exist in plugin config")
+	} else if specs, err := parseChanSpecs(v); err != nil {
+		errs = append(errs, err.Error())
+	} else if len(specs) != 1 {
+		errs = append(errs, "in tag must reference a single channel")
+	} else {
+		if err := pl.addChannel(g, specs[0]); err != nil {
+			errs = append(errs, err.Error())
+		} else if len(inTypes) > 0 && !containsString(inTypes, specs[0].chType) {
+			errs = append(errs, fmt.Sprintf("in channel '%s' must be of type %s", specs[0].name, strings.Join(inTypes, " or ")))
+		}
+		if outType == schema.SameAsIn {
+			outType = specs[0].chType
+		}
+		g.consumers[specs[0].name]++
+	}
+	if v, ok := p[OutChanConfig]; !ok {
+		if outKnown && outType != "" {
+			errs = append(errs, fmt.Sprintf("out tag of type %s must exist in plugin config", outType))
+		}
+	} else if specs, err := parseChanSpecs(v); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		for _, s := range specs {
+			if err := pl.addChannel(g, s); err != nil {
+				errs = append(errs, err.Error())
+			} else if outKnown && outType == "" {
+				errs = append(errs, "plugin does not write to an out channel")
+			} else if outKnown && s.chType != outType {
+				errs = append(errs, fmt.Sprintf("out channel '%s' must be of type %s", s.name, outType))
+			}
+			g.producers[s.name]++
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// addChannel checks that a channel type exists and that the channel is referenced with a single type.
+func (pl *Pipeline) addChannel(g *channelGraph, s chanSpec) error {
+	if _, ok := pl.pluginCache.chanFuncMap[s.chType]; !ok {
+		return fmt.Errorf("channel type '%s' not found in plugin cache", s.chType)
+	}
+	if t, ok := g.types[s.name]; ok && t != s.chType {
+		return fmt.Errorf("channel '%s' is referenced with types %s and %s", s.name, t, s.chType)
+	}
+	g.types[s.name] = s.chType
+	return nil
+}
+
+func containsString(items []string, s string) bool {
+	for _, i := range items {
+		if i == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package pipeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testStages returns the stages of a reader, policy engine, and exporter pipeline.
+func testStages() []PluginConfig {
+	return []PluginConfig{
+		{ProcConfig: "sysflowreader", HdlConfig: "flattener", InChanConfig: "sysflow sysflowchan", OutChanConfig: "flat flattenerchan"},
+		{ProcConfig: "policyengine", InChanConfig: "flat flattenerchan", OutChanConfig: "evt eventchan", "policies": "/etc/policies"},
+		{ProcConfig: "exporter", InChanConfig: "evt eventchan"},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		update func(conf *Config)
+		errs   []string
+	}{
+		{"valid", func(conf *Config) {}, nil},
+		{"channel types", func(conf *Config) {
+			conf.Pipeline[1][InChanConfig] = "flat eventchan"
+		}, []string{"stage 2 (policyengine): channel 'flat' is referenced with types flattenerchan and eventchan"}},
+		{"in type", func(conf *Config) {
+			conf.Pipeline[2][InChanConfig] = "flat flattenerchan"
+		}, []string{"stage 3 (exporter): in channel 'flat' must be of type eventchan", "channel 'evt' is not read by any stage"}},
+		{"out type", func(conf *Config) {
+			conf.Pipeline[1][OutChanConfig] = "evt flattenerchan"
+			conf.Pipeline[2][InChanConfig] = "evt flattenerchan"
+		}, []string{"stage 2 (policyengine): out channel 'evt' must be of type eventchan"}},
+		{"no out", func(conf *Config) {
+			conf.Pipeline[2][OutChanConfig] = "more eventchan"
+			conf.Pipeline = append(conf.Pipeline, PluginConfig{ProcConfig: "exporter", InChanConfig: "more eventchan"})
+		}, []string{"stage 3 (exporter): plugin does not write to an out channel"}},
+		{"no producer", func(conf *Config) {
+			conf.Pipeline[2][InChanConfig] = "other eventchan"
+		}, []string{"channel 'other' is not written by any stage", "channel 'evt' is not read by any stage"}},
+		{"no consumer", func(conf *Config) {
+			conf.Pipeline = conf.Pipeline[:2]
+		}, []string{"channel 'evt' is not read by any stage"}},
+		{"no processor", func(conf *Config) {
+			delete(conf.Pipeline[2], ProcConfig)
+		}, []string{"stage 3: Processor tag must exist in plugin config"}},
+		{"no handler", func(conf *Config) {
+			delete(conf.Pipeline[0], HdlConfig)
+		}, []string{"stage 1 (sysflowreader): Plugin 'sysflowreader' requires a handler"}},
+		{"unexpected handler", func(conf *Config) {
+			conf.Pipeline[2][HdlConfig] = "flattener"
+		}, []string{"stage 3 (exporter): Plugin 'exporter' does not accept a handler"}},
+		{"unknown processor", func(conf *Config) {
+			conf.Pipeline[2][ProcConfig] = "kafka"
+		}, []string{"stage 3 (kafka): Plugin 'kafka' not found in plugin cache"}},
+		{"no in", func(conf *Config) {
+			delete(conf.Pipeline[2], InChanConfig)
+		}, []string{"stage 3 (exporter): in tag must exist in plugin config"}},
+		{"required", func(conf *Config) {
+			delete(conf.Pipeline[1], "policies")
+		}, []string{"stage 2 (policyengine): 'policies' is required"}},
+		{"sinks", func(conf *Config) {
+			conf.Pipeline[2]["sinks"] = "siem,all"
+			conf.Pipeline[2]["siem.export"] = "syslog"
+			conf.Pipeline[2]["all_path"] = "/var/log/all.out"
+		}, nil},
+		{"sink value", func(conf *Config) {
+			conf.Pipeline[2]["sinks"] = "siem"
+			conf.Pipeline[2]["siem.export"] = "kafka"
+		}, []string{"stage 3 (exporter): 'export' must be one of"}},
+		{"unlisted sink", func(conf *Config) {
+			conf.Pipeline[2]["sinks"] = "siem"
+			conf.Pipeline[2]["other.export"] = "syslog"
+		}, []string{"stage 3 (exporter): unknown attribute 'other.export'"}},
+		{"driver", func(conf *Config) {
+			conf.Driver["follow"] = "maybe"
+		}, []string{"driver (file): 'follow' must be of type boolean: maybe"}},
+		{"channels", func(conf *Config) {
+			conf.Channels["evt"] = PluginConfig{ChanSizeConfig: "0"}
+			conf.Channels["unused"] = PluginConfig{}
+		}, []string{"channel (evt): 'size' has invalid value '0'", "channel 'unused' is configured but not used by any stage"}},
+	} {
+		pl := New("", "", "")
+		var err error
+		pl.driver, err = pl.pluginCache.GetDriver("file")
+		assert.NoError(t, err)
+		conf := &Config{Pipeline: testStages(), Driver: PluginConfig{}, Channels: map[string]PluginConfig{}}
+		tc.update(conf)
+		err = pl.validate(conf)
+		if len(tc.errs) == 0 {
+			assert.NoError(t, err, tc.name)
+			continue
+		}
+		if assert.Error(t, err, tc.name) {
+			for _, e := range tc.errs {
+				assert.Contains(t, err.Error(), e, tc.name)
+			}
+		}
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	dir := tempConfigDir(t)
+	defer os.RemoveAll(dir)
+	path := writeConfigFile(t, dir, "pipeline.yaml", `
+pipeline:
+  - processor: sysflowreader
+    handler: flattener
+    in: sysflow sysflowchan
+    out: flat flattenerchan
+  - processor: policyengine
+    in: flat flattenerchan
+    out: evt eventchan
+    policies: /etc/policies
+  - processor: exporter
+    in: evt eventchan
+    export: syslog
+    sinks: [{name: siem, host: siem.local}]
+driver:
+  checkpoint: /var/lib/sf.ckpt
+channels:
+  evt:
+    policy: drop-oldest
+`)
+	os.Setenv("EXPORTER_EXPORT", "file")
+	defer os.Unsetenv("EXPORTER_EXPORT")
+	os.Setenv("FILE_FOLLOW", "true")
+	defer os.Unsetenv("FILE_FOLLOW")
+	pl := New("", "", path)
+	pl.SetDriverAttr("pollinterval", "5s")
+	var b bytes.Buffer
+	assert.NoError(t, pl.DryRun("file", &b))
+	var out struct {
+		Pipeline []map[string]string
+		Driver   map[string]string
+		Channels map[string]map[string]string
+	}
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &out))
+	assert.Len(t, out.Pipeline, 3)
+	assert.Equal(t, "legacy", out.Pipeline[1]["nullsemantics"])
+	exp := out.Pipeline[2]
+	assert.Equal(t, "file", exp["export"])
+	assert.Equal(t, "siem.local", exp["siem.host"])
+	assert.Equal(t, "514", exp["port"])
+	assert.Equal(t, map[string]string{"follow": "true", "checkpoint": "/var/lib/sf.ckpt", "pollinterval": "5s"}, out.Driver)
+	assert.Equal(t, map[string]map[string]string{"evt": {"size": "100000", "policy": "drop-oldest", "rate": "10"}}, out.Channels)
+
+	// invalid env overrides fail the dry run
+	os.Setenv("EXPORTER_EXPORT", "kafka")
+	assert.Error(t, New("", "", path).DryRun("file", &b))
+}
@@ -0,0 +1,298 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package pipeline
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
+)
+
+// dropLogInterval is the minimum interval between warnings about records dropped by a valve.
+const dropLogInterval = 10 * time.Second
+
+// stallTimeout is the time a valve waits for room in a full channel before reporting a stall.
+var stallTimeout = time.Second
+
+// chanSchema describes the attributes of a channel config.
+var chanSchema = schema.Schema{Attrs: []schema.Attr{
+	{Key: ChanSizeConfig, Type: schema.Int, Default: strconv.Itoa(ChanSize), Check: positive},
+	{Key: ChanPolicyConfig, Type: schema.Enum, Default: PolicyBlock,
+		Values: []string{PolicyBlock, PolicyDropNewest, PolicyDropOldest, PolicySample}},
+	{Key: ChanRateConfig, Type: schema.Int, Default: "10", Check: positive},
+}}
+
+// positive checks that an integer attribute is positive.
+func positive(v string) error {
+	if n, _ := strconv.Atoi(v); n <= 0 {
+		return errors.New("must be positive")
+	}
+	return nil
+}
+
+// valve forwards records to a pipeline channel, applying the overflow policy of the channel
+// when it is full. With drop-newest, incoming records are dropped; with drop-oldest, the valve
+// queues up to size records, dropping the oldest queued records to make room; and with sample,
+// one in every rate incoming records is kept, waiting up to stallTimeout for room in the channel,
+// and the others are dropped. SysFlow headers and entities are never dropped, since the records
+// that follow refer to them: a valve waits for room in the channel to forward them, so that a
+// wedged consumer stalls the producers, and such stalls are counted.
+type valve struct {
+	name    string
+	policy  string
+	size    int
+	rate    int
+	in      reflect.Value
+	out     reflect.Value
+	dropped uint64
+	stalled uint64
+	last    uint64
+	logged  time.Time
+	stalls  time.Time
+}
+
+// run forwards records from in to out until in is closed, and then closes out.
+func (v *valve) run(wg *sync.WaitGroup) {
+	defer wg.Done()
+	if v.policy == PolicyDropOldest {
+		v.queue()
+	} else {
+		v.forward()
+	}
+	v.out.Close()
+}
+
+// forward sends records to out, dropping or sampling incoming records when out is full.
+func (v *valve) forward() {
+	var overflow uint64
+	for {
+		r, ok := v.in.Recv()
+		if !ok {
+			return
+		}
+		if v.out.TrySend(r) {
+			continue
+		}
+		if isEntity(r) || v.policy == PolicySample && overflow%uint64(v.rate) == 0 {
+			v.send(r)
+		} else {
+			v.drop()
+		}
+		overflow++
+	}
+}
+
+// send sends a record to out, waiting up to stallTimeout for room in out. After a stall, entities
+// are sent once out has room, and other records are dropped.
+func (v *valve) send(r reflect.Value) {
+	timer := time.NewTimer(stallTimeout)
+	defer timer.Stop()
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: v.out, Send: r},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)},
+	}
+	if i, _, _ := reflect.Select(cases); i == 0 {
+		return
+	}
+	v.stall()
+	if isEntity(r) {
+		v.out.Send(r)
+	} else {
+		v.drop()
+	}
+}
+
+// queue sends records to out through a queue of up to size records, dropping the oldest queued
+// record that is not an entity when the queue is full.
+func (v *valve) queue() {
+	var q []reflect.Value
+	cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: v.in}, {Dir: reflect.SelectSend}}
+	for cases[0].Chan.IsValid() || len(q) > 0 {
+		cases[1].Chan = reflect.Value{}
+		if len(q) > 0 {
+			cases[1].Chan, cases[1].Send = v.out, q[0]
+		}
+		i, r, ok := reflect.Select(cases)
+		if i == 1 {
+			q[0] = reflect.Value{}
+			q = q[1:]
+			continue
+		}
+		if !ok {
+			cases[0].Chan = reflect.Value{}
+			continue
+		}
+		q = append(q, r)
+		if len(q) <= v.size {
+			continue
+		}
+		for j := range q {
+			if j == 0 && !isEntity(q[0]) {
+				q = q[1:]
+				v.drop()
+				break
+			}
+			if !isEntity(q[j]) {
+				q = append(q[:j], q[j+1:]...)
+				v.drop()
+				break
+			}
+		}
+	}
+}
+
+// drop counts a dropped record, and periodically logs the number of records dropped.
+func (v *valve) drop() {
+	d := atomic.AddUint64(&v.dropped, 1)
+	if time.Since(v.logged) >= dropLogInterval {
+		logger.Warn.Printf("Channel %s is full, dropped %d records (%s)\n", v.name, d-v.last, v.policy)
+		v.logged, v.last = time.Now(), d
+	}
+}
+
+// stall counts a send blocked for stallTimeout, and periodically logs the number of stalls.
+func (v *valve) stall() {
+	n := atomic.AddUint64(&v.stalled, 1)
+	if time.Since(v.stalls) >= dropLogInterval {
+		logger.Warn.Printf("Channel %s is stalled, blocked for over %v %d times (%s)\n", v.name, stallTimeout, n, v.policy)
+		v.stalls = time.Now()
+	}
+}
+
+// isEntity returns whether r is a SysFlow header or entity record.
+func isEntity(r reflect.Value) bool {
+	if sf, ok := r.Interface().(*sfgo.SysFlow); ok && sf != nil {
+		switch sf.Rec.UnionType {
+		case sfgo.SF_HEADER, sfgo.SF_CONT, sfgo.SF_PROCESS, sfgo.SF_FILE:
+			return true
+		}
+	}
+	return false
+}
+
+// Dropped returns the number of records dropped by the valve.
+func (v *valve) Dropped() uint64 {
+	return atomic.LoadUint64(&v.dropped)
+}
+
+// Stalled returns the number of sends of the valve blocked for stallTimeout.
+func (v *valve) Stalled() uint64 {
+	return atomic.LoadUint64(&v.stalled)
+}
+
+// chanConfig returns the config of the named channel, with defaults for unset attributes.
+func (pl *Pipeline) chanConfig(name string) map[string]string {
+	if c, ok := pl.chanConf[name]; ok {
+		return c
+	}
+	return chanSchema.Resolve(nil)
+}
+
+// chanSize returns the capacity of the named channel. Records of channels with the drop-oldest
+// policy are queued by their valve, and handed over to consumers through a smaller channel.
+func (pl *Pipeline) chanSize(name string) int {
+	conf := pl.chanConfig(name)
+	size, err := strconv.Atoi(conf[ChanSizeConfig])
+	if err != nil {
+		return ChanSize
+	}
+	if conf[ChanPolicyConfig] == PolicyDropOldest && size > ValveSize {
+		return ValveSize
+	}
+	return size
+}
+
+// throttle returns the channel through which producers write to the named channel ch. Channels
+// with a blocking policy are written directly, while other channels are fed by a valve applying
+// their overflow policy.
+func (pl *Pipeline) throttle(s chanSpec, ch interface{}) (interface{}, error) {
+	conf := pl.chanConfig(s.name)
+	policy := conf[ChanPolicyConfig]
+	if policy == PolicyBlock {
+		return ch, nil
+	}
+	if v, ok := pl.valves[s.name]; ok {
+		return v.src, nil
+	}
+	src, err := pl.pluginCache.NewChan(s.chType, ValveSize)
+	if err != nil {
+		return nil, err
+	}
+	pl.channels = append(pl.channels, src)
+	in, err := chanValue(src)
+	if err != nil {
+		return nil, err
+	}
+	out, err := chanValue(ch)
+	if err != nil {
+		return nil, err
+	}
+	size, _ := strconv.Atoi(conf[ChanSizeConfig])
+	rate, _ := strconv.Atoi(conf[ChanRateConfig])
+	v := &valve{name: s.name, policy: policy, size: size, rate: rate, in: in, out: out}
+	pl.valves[s.name] = valveChan{valve: v, src: src}
+	logger.Trace.Printf("Applying %s policy to channel %s\n", policy, s.name)
+	pl.wg.Add(1)
+	go v.run(pl.wg)
+	return src, nil
+}
+
+// valveChan associates a valve with the channel feeding it.
+type valveChan struct {
+	*valve
+	src interface{}
+}
+
+// Drops returns the number of records dropped by each channel with a dropping overflow policy.
+func (pl *Pipeline) Drops() map[string]uint64 {
+	drops := make(map[string]uint64)
+	for name, v := range pl.valves {
+		drops[name] = v.Dropped()
+	}
+	return drops
+}
+
+// Stalls returns the number of stalled sends of each channel with a dropping overflow policy.
+func (pl *Pipeline) Stalls() map[string]uint64 {
+	stalls := make(map[string]uint64)
+	for name, v := range pl.valves {
+		stalls[name] = v.Stalled()
+	}
+	return stalls
+}
+
+// reportDrops logs the records dropped and the stalls of each channel with a dropping overflow
+// policy, if any.
+func (pl *Pipeline) reportDrops() {
+	stalls := pl.Stalls()
+	for name, d := range pl.Drops() {
+		if d > 0 || stalls[name] > 0 {
+			logger.Warn.Printf("Channel %s dropped %d records in total, and stalled %d times (%s)\n",
+				name, d, stalls[name], pl.valves[name].policy)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package pipeline
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/sysflowtest"
+)
+
+
+
+
+// startValve runs a valve applying policy to an out channel of the given capacity, and sends
+// records to it, closing its input.
+func startValve(policy string, size int, capacity int, rate int, records ...*sfgo.SysFlow) (*valve, chan *sfgo.SysFlow, *sync.WaitGroup) {
+	in := make(chan *sfgo.SysFlow, len(records))
+	out := make(chan *sfgo.SysFlow, capacity)
+	v := &valve{name: "test", policy: policy, size: size, rate: rate, in: reflect.ValueOf(in), out: reflect.ValueOf(out)}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go v.run(&wg)
+	for _, r := range records {
+		in <- r
+	}
+	close(in)
+	return v, out, &wg
+}
+
+// setStallTimeout sets the stall timeout of valves, returning the previous timeout.
+func setStallTimeout(d time.Duration) time.Duration {
+	prev := stallTimeout
+	stallTimeout = d
+	return prev
+}
+
+// eventually waits until cond holds.
+func eventually(t *testing.T, cond func() bool) {
+	assert.Eventually(t, cond, 5*time.Second, time.Millisecond)
+}
+
+func TestValveDropNewest(t *testing.T) {
+	e := []*sfgo.SysFlow{sysflowtest.ProcEvt(1, 0), sysflowtest.ProcEvt(1, 0), sysflowtest.ProcEvt(1, 0), sysflowtest.ProcEvt(1, 0)}
+	v, out, wg := startValve(PolicyDropNewest, 2, 2, 0, e...)
+	wg.Wait()
+	assert.Equal(t, []interface{}{e[0], e[1]}, drain(reflect.ValueOf(out)))
+	assert.Equal(t, uint64(2), v.Dropped())
+	assert.Zero(t, v.Stalled())
+}
+
+func TestValveEntities(t *testing.T) {
+	defer setStallTimeout(setStallTimeout(10 * time.Millisecond))
+	e, p := sysflowtest.ProcEvt(1, 0), sysflowtest.Process(1, "/bin/sh")
+	// entities wait for room in the channel, counting a stall
+	v, out, wg := startValve(PolicyDropNewest, 1, 1, 0, e, p)
+	eventually(t, func() bool { return v.Stalled() == 1 })
+	assert.Equal(t, []interface{}{e, p}, drain(reflect.ValueOf(out)))
+	wg.Wait()
+	assert.Zero(t, v.Dropped())
+}
+
+func TestValveSample(t *testing.T) {
+	defer setStallTimeout(setStallTimeout(10 * time.Millisecond))
+	e := []*sfgo.SysFlow{sysflowtest.ProcEvt(1, 0), sysflowtest.ProcEvt(1, 0), sysflowtest.ProcEvt(1, 0), sysflowtest.ProcEvt(1, 0), sysflowtest.ProcEvt(1, 0)}
+	// one in two overflowing records is sampled, and dropped after a stall
+	v, out, wg := startValve(PolicySample, 1, 1, 2, e...)
+	wg.Wait()
+	assert.Equal(t, []interface{}{e[0]}, drain(reflect.ValueOf(out)))
+	assert.Equal(t, uint64(4), v.Dropped())
+	assert.Equal(t, uint64(2), v.Stalled())
+
+	// sampled records are forwarded once the channel has room
+	setStallTimeout(5 * time.Second)
+	v, out, wg = startValve(PolicySample, 1, 1, 2, e[:3]...)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, e[0], <-out)
+	wg.Wait()
+	assert.Equal(t, []interface{}{e[1]}, drain(reflect.ValueOf(out)))
+	assert.Equal(t, uint64(1), v.Dropped())
+	assert.Zero(t, v.Stalled())
+}
+
+func TestValveDropOldest(t *testing.T) {
+	p := sysflowtest.Process(1, "/bin/sh")
+	e := []*sfgo.SysFlow{sysflowtest.ProcEvt(1, 0), sysflowtest.ProcEvt(1, 0), sysflowtest.ProcEvt(1, 0), sysflowtest.ProcEvt(1, 0)}
+	// the oldest queued records are dropped, except entities, while the consumer is blocked
+	v, out, wg := startValve(PolicyDropOldest, 2, 0, 0, append([]*sfgo.SysFlow{p}, e...)...)
+	eventually(t, func() bool { return v.Dropped() == 3 })
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, uint64(3), v.Dropped())
+	assert.Equal(t, []interface{}{p, e[3]}, drain(reflect.ValueOf(out)))
+	wg.Wait()
+}
+
+func TestThrottle(t *testing.T) {
+	pl := New("", "", "")
+	pl.chanConf = map[string]map[string]string{
+		"b": chanSchema.Resolve(nil),
+		"s": chanSchema.Resolve(map[string]string{ChanPolicyConfig: PolicySample, ChanSizeConfig: "1", ChanRateConfig: "3"}),
+	}
+	ch := &plugins.SFChannel{In: make(chan *sfgo.SysFlow, 1)}
+	src, err := pl.throttle(chanSpec{"b", "sysflowchan"}, ch)
+	assert.NoError(t, err)
+	assert.Same(t, ch, src)
+
+	// producers of a dropping channel write to its valve
+	defer setStallTimeout(setStallTimeout(10 * time.Millisecond))
+	src, err = pl.throttle(chanSpec{"s", "sysflowchan"}, ch)
+	assert.NoError(t, err)
+	assert.NotSame(t, ch, src)
+	again, err := pl.throttle(chanSpec{"s", "sysflowchan"}, ch)
+	assert.NoError(t, err)
+	assert.Same(t, src, again)
+	in := src.(*plugins.SFChannel).In
+	for i := 0; i < 5; i++ {
+		in <- sysflowtest.ProcEvt(1, 0)
+	}
+	close(in)
+	pl.wg.Wait()
+	assert.Len(t, drain(reflect.ValueOf(ch.In)), 1)
+	assert.Equal(t, map[string]uint64{"s": 4}, pl.Drops())
+	assert.Equal(t, map[string]uint64{"s": 2}, pl.Stalls())
+}
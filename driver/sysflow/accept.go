@@ -0,0 +1,72 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+)
+
+const (
+	// MinAcceptDelay represents the initial delay before retrying a failed accept
+	MinAcceptDelay = 5 * time.Millisecond
+	// MaxAcceptDelay represents the maximum delay before retrying a failed accept
+	MaxAcceptDelay = time.Second
+)
+
+// acceptBackoff paces the retries of failed accepts with capped exponential backoff, like
+// net/http.Server.Serve, so that transient errors such as running out of file descriptors
+// do not stop a driver.
+type acceptBackoff struct {
+	delay time.Duration
+}
+
+// retry reports whether a failed accept should be retried, after waiting for the backoff delay.
+// Accept errors are fatal once the driver is canceled or its listener is closed.
+func (b *acceptBackoff) retry(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if isClosed(err) {
+		logger.Error.Println("accept error:", err)
+		return false
+	}
+	if b.delay == 0 {
+		b.delay = MinAcceptDelay
+	} else if b.delay *= 2; b.delay > MaxAcceptDelay {
+		b.delay = MaxAcceptDelay
+	}
+	logger.Warn.Printf("accept error: %v; retrying in %v\n", err, b.delay)
+	t := time.NewTimer(b.delay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// reset resets the backoff delay after a successful accept.
+func (b *acceptBackoff) reset() {
+	b.delay = 0
+}
@@ -0,0 +1,62 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceptBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var b acceptBackoff
+	// transient errors are retried with doubling delays, up to MaxAcceptDelay
+	err := errors.New("accept: too many open files")
+	assert.True(t, b.retry(ctx, err))
+	assert.Equal(t, MinAcceptDelay, b.delay)
+	assert.True(t, b.retry(ctx, err))
+	assert.Equal(t, 2*MinAcceptDelay, b.delay)
+	b.delay = MaxAcceptDelay*2/3 + 1
+	start := time.Now()
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	// cancellation interrupts the backoff delay
+	assert.False(t, b.retry(ctx, err))
+	assert.Equal(t, MaxAcceptDelay, b.delay)
+	assert.Less(t, int64(time.Since(start)), int64(MaxAcceptDelay))
+	b.reset()
+	assert.Zero(t, b.delay)
+}
+
+func TestAcceptBackoffClosed(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	l.Close()
+	_, err = l.Accept()
+	var b acceptBackoff
+	assert.False(t, b.retry(context.Background(), err))
+}
@@ -0,0 +1,119 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// fileState records the progress of the file driver in a file. Offset is the position of the
+// first unprocessed block, of which the first Skip records have been processed.
+type fileState struct {
+	Offset int64 `json:"offset"`
+	Skip   int64 `json:"skip,omitempty"`
+	Done   bool  `json:"done,omitempty"`
+}
+
+// checkpoint records the files and offsets processed by the file driver, so that a restarted
+// driver resumes without reprocessing records.
+type checkpoint struct {
+	path  string
+	Files map[string]*fileState `json:"files"`
+}
+
+// loadCheckpoint reads the checkpoint at path. A checkpoint is created if path does not exist,
+// and is kept in memory only if path is empty.
+func loadCheckpoint(path string) (*checkpoint, error) {
+	cp := &checkpoint{path: path, Files: make(map[string]*fileState)}
+	if path == "" {
+		return cp, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cp, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, cp); err != nil {
+		return nil, err
+	}
+	if cp.Files == nil {
+		cp.Files = make(map[string]*fileState)
+	}
+	return cp, nil
+}
+
+// state returns the progress of the driver in file fn.
+func (cp *checkpoint) state(fn string) *fileState {
+	st, ok := cp.Files[fn]
+	if !ok {
+		st = new(fileState)
+		cp.Files[fn] = st
+	}
+	return st
+}
+
+// done returns whether file fn has been fully processed.
+func (cp *checkpoint) done(fn string) bool {
+	st, ok := cp.Files[fn]
+	return ok && st.Done
+}
+
+// prune removes the files not in files from the checkpoint.
+func (cp *checkpoint) prune(files []string) {
+	keep := make(map[string]bool)
+	for _, fn := range files {
+		keep[fn] = true
+	}
+	for fn := range cp.Files {
+		if !keep[fn] {
+			delete(cp.Files, fn)
+		}
+	}
+}
+
+// save atomically writes the checkpoint to its path.
+func (cp *checkpoint) save() error {
+	if cp.path == "" {
+		return nil
+	}
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(cp.path), filepath.Base(cp.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(b); err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), cp.path)
+}
@@ -0,0 +1,187 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+)
+
+const tracesDir = "../../resources/traces"
+
+// copyTrace copies a trace of the resources into dir.
+func copyTrace(t *testing.T, name string, dir string) string {
+	b, err := ioutil.ReadFile(filepath.Join(tracesDir, name))
+	assert.NoError(t, err)
+	path := filepath.Join(dir, name)
+	assert.NoError(t, ioutil.WriteFile(path, b, 0600))
+	return path
+}
+
+// runFileDriver runs a file or replay driver on path, returning the records sent to the pipeline. The
+// driver is canceled once stop returns true for a received record.
+func runFileDriver(t *testing.T, d plugins.SFDriver, path string, config map[string]string, stop func(sf *sfgo.SysFlow) bool) []*sfgo.SysFlow {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pl := newTestPipeline(ctx, 0, config)
+	assert.NoError(t, d.Init(pl))
+	done := make(chan error)
+	go func() { done <- d.Run(path, nil) }()
+	var recs []*sfgo.SysFlow
+	for sf := range pl.channel.In {
+		recs = append(recs, sf)
+		if stop != nil && stop(sf) {
+			cancel()
+		}
+	}
+	assert.NoError(t, <-done)
+	return recs
+}
+
+// events returns keys identifying the event and flow records of recs.
+func events(recs []*sfgo.SysFlow) []string {
+	var evts []string
+	for _, sf := range recs {
+		if ts, end, ok := recordTime(sf); ok {
+			evts = append(evts, fmt.Sprintf("%d/%d/%d/%v", sf.Rec.UnionType, ts, end, *procOID(sf)))
+		}
+	}
+	return evts
+}
+
+// procOID returns the process OID of an event or flow record.
+func procOID(sf *sfgo.SysFlow) *sfgo.OID {
+	switch sf.Rec.UnionType {
+	case sfgo.SF_PROC_EVT:
+		return sf.Rec.ProcessEvent.ProcOID
+	case sfgo.SF_FILE_EVT:
+		return sf.Rec.FileEvent.ProcOID
+	case sfgo.SF_NET_EVT:
+		return sf.Rec.NetworkEvent.ProcOID
+	case sfgo.SF_PROC_FLOW:
+		return sf.Rec.ProcessFlow.ProcOID
+	case sfgo.SF_FILE_FLOW:
+		return sf.Rec.FileFlow.ProcOID
+	}
+	return sf.Rec.NetworkFlow.ProcOID
+}
+
+// setExporter sets the exporter of the headers of recs.
+func setExporter(recs []*sfgo.SysFlow, exporter string) []*sfgo.SysFlow {
+	for _, sf := range recs {
+		if sf.Rec.UnionType == sfgo.SF_HEADER {
+			sf.Rec.SFHeader.Exporter = exporter
+		}
+	}
+	return recs
+}
+
+func TestCheckpointResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoint")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	traces := filepath.Join(dir, "traces")
+	assert.NoError(t, os.Mkdir(traces, 0700))
+	copyTrace(t, "mon.1531776682.sf", traces)
+	second := copyTrace(t, "mon.1531776712.sf", traces)
+	all := runFileDriver(t, newFileDriver(), traces, nil, nil)
+	full := events(all)
+	assert.NotEmpty(t, full)
+
+	// interrupt the driver in the middle of a block of the second file
+	config := map[string]string{CheckpointConfig: filepath.Join(dir, "checkpoint.json")}
+	n, k := 0, len(full)-500
+	first := runFileDriver(t, newFileDriver(), traces, config, func(sf *sfgo.SysFlow) bool {
+		if !isEntity(sf) {
+			n++
+		}
+		return n == k
+	})
+	cp, err := loadCheckpoint(config[CheckpointConfig])
+	assert.NoError(t, err)
+	assert.Len(t, cp.Files, 2)
+	st := cp.state(second)
+	assert.False(t, st.Done)
+	assert.True(t, st.Offset > 0)
+	assert.True(t, st.Skip > 0)
+
+	// the restarted driver resumes after the last processed record
+	rest := runFileDriver(t, newFileDriver(), traces, config, nil)
+	assert.Equal(t, full, append(events(first), events(rest)...))
+	cp, err = loadCheckpoint(config[CheckpointConfig])
+	assert.NoError(t, err)
+	assert.True(t, cp.state(second).Done)
+
+	// the entities of processed records are replayed, so that resumed records resolve them
+	resumed := flatten(setExporter(rest, "checkpoint-resumed"))
+	complete := flatten(setExporter(all, "checkpoint-full"))
+	assert.NotEmpty(t, resumed)
+	tail := complete[len(complete)-len(resumed):]
+	for i := range resumed {
+		assert.Equal(t, tail[i].Strs[sfgo.SYSFLOW_IDX][sfgo.PROC_EXE_STR], resumed[i].Strs[sfgo.SYSFLOW_IDX][sfgo.PROC_EXE_STR], "record %d", i)
+		assert.Equal(t, tail[i].Strs[sfgo.SYSFLOW_IDX][sfgo.CONT_ID_STR], resumed[i].Strs[sfgo.SYSFLOW_IDX][sfgo.CONT_ID_STR], "record %d", i)
+	}
+
+	// completed files are not read again
+	assert.Empty(t, events(runFileDriver(t, newFileDriver(), traces, config, nil)))
+}
+
+func TestTailRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tail")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	b, err := ioutil.ReadFile(filepath.Join(tracesDir, "tcp.sf"))
+	assert.NoError(t, err)
+	expected := events(runFileDriver(t, newFileDriver(), filepath.Join(tracesDir, "tcp.sf"), nil, nil))
+	expected = append(expected, events(runFileDriver(t, newFileDriver(), filepath.Join(tracesDir, "httpd.sf"), nil, nil))...)
+
+	// the first trace is written in two parts while followed, then rotated to the second trace
+	fn := filepath.Join(dir, "trace.1.sf")
+	assert.NoError(t, ioutil.WriteFile(fn, b[:len(b)/2], 0600))
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		f, err := os.OpenFile(fn, os.O_APPEND|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Write(b[len(b)/2:])
+			f.Close()
+		}
+		time.Sleep(100 * time.Millisecond)
+		h, _ := ioutil.ReadFile(filepath.Join(tracesDir, "httpd.sf"))
+		ioutil.WriteFile(filepath.Join(dir, "trace.2.sf"), h, 0600)
+	}()
+	n := 0
+	config := map[string]string{FollowConfig: "true", PollIntervalConfig: "10ms"}
+	recs := runFileDriver(t, newFileDriver(), dir, config, func(sf *sfgo.SysFlow) bool {
+		if !isEntity(sf) {
+			n++
+		}
+		return n == len(expected)
+	})
+	assert.Equal(t, expected, events(recs))
+}
@@ -0,0 +1,42 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"context"
+
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+)
+
+// pipelineContext returns the cancellation context of the pipeline, if the pipeline supports it.
+func pipelineContext(pipeline plugins.SFPipeline) context.Context {
+	if p, ok := pipeline.(interface{ Context() context.Context }); ok {
+		return p.Context()
+	}
+	return context.Background()
+}
+
+// driverConfig returns the driver config of the pipeline, if the pipeline supports it.
+func driverConfig(pipeline plugins.SFPipeline) map[string]string {
+	if p, ok := pipeline.(interface{ GetDriverConfig() map[string]string }); ok && p.GetDriverConfig() != nil {
+		return p.GetDriverConfig()
+	}
+	return make(map[string]string)
+}
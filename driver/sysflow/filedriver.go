@@ -21,56 +21,52 @@ package sysflow
 
 import (
 	"bufio"
+	"context"
 	"errors"
-	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/linkedin/goavro"
 	"github.com/sysflow-telemetry/sf-apis/go/converter"
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
 	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
 )
 
 const (
 	fileDriverName = "file"
 )
 
-func getFiles(filename string) ([]string, error) {
-	var fls []string
-	if fi, err := os.Stat(filename); os.IsNotExist(err) {
-		return nil, err
-	} else if fi.IsDir() {
-		logger.Trace.Println("File is a directory")
-		var files []os.FileInfo
-		var err error
-		if files, err = ioutil.ReadDir(filename); err != nil {
-			return nil, err
-		}
-		for _, file := range files {
-			f := filename + "/" + file.Name()
-			logger.Trace.Println("File in Directory: " + f)
-			fls = append(fls, f)
-		}
-		if len(fls) == 0 {
-			return nil, errors.New("No files present in directory: " + filename)
-		}
-
-	} else {
-		fls = append(fls, filename)
-	}
-	logger.Trace.Printf("Number of files in list: %d\n", len(fls))
-	return fls, nil
-}
+// File driver config attributes.
+const (
+	FollowConfig       = "follow"
+	CheckpointConfig   = "checkpoint"
+	PollIntervalConfig = "pollinterval"
+	FromConfig         = "from"
+	ToConfig           = "to"
+)
 
 // FileDriver represents reading a sysflow file from source
 type FileDriver struct {
 	pipeline plugins.SFPipeline
 	file     *os.File
+	follow   bool
+	window   timeWindow
+	formats  map[string]bool
+	starts   map[string]int64
+	replay   *replayer
 }
 
 // NewFileDriver creates a new file driver object
 func NewFileDriver() plugins.SFDriver {
-	return &FileDriver{}
+	return newFileDriver()
+}
+
+func newFileDriver() *FileDriver {
+	return &FileDriver{formats: make(map[string]bool), starts: make(map[string]int64)}
 }
 
 // GetName returns the driver name.
@@ -83,55 +79,137 @@ func (s *FileDriver) Register(pc plugins.SFPluginCache) {
 	pc.AddDriver(fileDriverName, NewFileDriver)
 }
 
+// ConfigSchema returns the config schema of the driver.
+func (s *FileDriver) ConfigSchema() schema.Schema {
+	return schema.Schema{Attrs: []schema.Attr{
+		{Key: FollowConfig, Type: schema.Bool, Default: "false"},
+		{Key: CheckpointConfig, Type: schema.String},
+		{Key: PollIntervalConfig, Type: schema.Duration, Default: "1s", Check: func(v string) error {
+			if d, _ := time.ParseDuration(v); d <= 0 {
+				return errors.New("must be positive")
+			}
+			return nil
+		}},
+		{Key: FromConfig, Type: schema.String, Check: checkTime},
+		{Key: ToConfig, Type: schema.String, Check: checkTime},
+	}}
+}
+
+func checkTime(v string) error {
+	_, err := parseTime(v)
+	return err
+}
+
 // Init initializes the file driver with the pipeline
 func (s *FileDriver) Init(pipeline plugins.SFPipeline) error {
 	s.pipeline = pipeline
 	return nil
 }
 
-// Run runs the file driver
+// Run runs the file driver until all files are read or the pipeline is shut down. In follow mode,
+// the driver tails the newest file, and watches for new files until the pipeline is shut down.
 func (s *FileDriver) Run(path string, running *bool) error {
+	ctx := pipelineContext(s.pipeline)
 	channel := s.pipeline.GetRootChannel()
 	sfChannel := channel.(*plugins.SFChannel)
 	records := sfChannel.In
 
-	logger.Trace.Println("Loading file: ", path)
+	conf := driverConfig(s.pipeline)
+	follow, _ := strconv.ParseBool(conf[FollowConfig])
+	s.follow = follow
+	poll := time.Second
+	if d, err := time.ParseDuration(conf[PollIntervalConfig]); err == nil && d > 0 {
+		poll = d
+	}
+	var err error
+	if s.window, err = newTimeWindow(conf[FromConfig], conf[ToConfig]); err != nil {
+		logger.Error.Println("Time window error: ", err)
+		return err
+	}
+	cp, err := loadCheckpoint(conf[CheckpointConfig])
+	if err != nil {
+		logger.Error.Println("Checkpoint error: ", err)
+		return err
+	}
 
-	sfobjcvter := converter.NewSFObjectConverter()
+	logger.Trace.Println("Loading file: ", path)
 
-	files, err := getFiles(path)
+	files, err := s.getFiles(path)
 	if err != nil {
 		logger.Error.Println("Files error: ", err)
 		return err
 	}
-	for _, fn := range files {
-		logger.Trace.Println("Loading file: " + fn)
-		s.file, err = os.Open(fn)
-		if err != nil {
-			logger.Error.Println("File open error: ", err)
-			return err
-		}
-		reader := bufio.NewReader(s.file)
-		sreader, err := goavro.NewOCFReader(reader)
-		if err != nil {
-			logger.Error.Println("Reader error: ", err)
-			return err
-		}
-		for sreader.Scan() {
-			if !*running {
+	if len(files) == 0 && !follow {
+		err = errors.New("No SysFlow files present in: " + path)
+		logger.Error.Println("Files error: ", err)
+		return err
+	}
+	for ctx.Err() == nil {
+		i := nextFile(files, cp)
+		if i < 0 {
+			if s.replay != nil && s.replay.loop {
+				if s.replay.sent == 0 {
+					logger.Warn.Println("No events or flows replayed, stopping replay loop")
+					break
+				}
+				logger.Trace.Println("Restarting replay of: ", path)
+				cp.Files = make(map[string]*fileState)
+				s.replay.base = 0
+				continue
+			}
+			if !follow {
 				break
 			}
-			datum, err := sreader.Read()
-			if err != nil {
-				logger.Error.Println("Datum reading error: ", err)
+			wait(ctx, poll)
+			if files, err = s.getFiles(path); err != nil {
+				logger.Error.Println("Files error: ", err)
 				break
 			}
-			records <- sfobjcvter.ConvertToSysFlow(datum)
+			continue
 		}
-		s.file.Close()
-		if !*running {
+		fn, newer := files[i], i < len(files)-1
+		rotated := func() bool {
+			fls, err := s.getFiles(path)
+			if err != nil {
+				return true
+			}
+			fi, err := os.Stat(fn)
+			if err != nil || len(fls) > 0 && fls[len(fls)-1] != fn {
+				return true
+			}
+			ofi, err := s.file.Stat()
+			return err != nil || !os.SameFile(fi, ofi)
+		}
+		if s.replay != nil && s.replay.base == 0 {
+			if ts, ok := s.startTime(fn); ok {
+				s.replay.begin(ts)
+			}
+		}
+		if s.window.bounded() && s.outside(files, i) {
+			logger.Trace.Println("Skipping file outside of time window: " + fn)
+		} else if err := s.readFile(ctx, fn, cp, follow && !newer, poll, rotated, records); err != nil && ctx.Err() == nil {
+			logger.Error.Println("Error reading file "+fn+": ", err)
+		}
+		if ctx.Err() != nil {
 			break
 		}
+		cp.state(fn).Done = true
+		if follow {
+			if files, err = s.getFiles(path); err != nil {
+				logger.Error.Println("Files error: ", err)
+				break
+			}
+		}
+		cp.prune(files)
+		if err := cp.save(); err != nil {
+			logger.Error.Println("Checkpoint error: ", err)
+		}
+	}
+	if err := cp.save(); err != nil {
+		logger.Error.Println("Checkpoint error: ", err)
+	}
+	if ctx.Err() != nil {
+		logger.Trace.Println("Driver canceled")
 	}
 	logger.Warn.Println("Closing main channel")
 	close(records)
@@ -139,6 +217,165 @@ func (s *FileDriver) Run(path string, running *bool) error {
 	return nil
 }
 
+// nextFile returns the index of the first file not processed according to the checkpoint, or -1.
+func nextFile(files []string, cp *checkpoint) int {
+	for i, fn := range files {
+		if !cp.done(fn) {
+			return i
+		}
+	}
+	return -1
+}
+
+// outside returns whether the records of the i-th file are outside of the time window, given the
+// first record timestamps of the file and of the next file in its directory.
+func (s *FileDriver) outside(files []string, i int) bool {
+	if ts, ok := s.startTime(files[i]); ok && s.window.after(ts) {
+		return true
+	}
+	for _, fn := range files[i+1:] {
+		if filepath.Dir(fn) == filepath.Dir(files[i]) {
+			ts, ok := s.startTime(fn)
+			return ok && s.window.before(ts)
+		}
+	}
+	return false
+}
+
+// startTime returns the timestamp of the first event or flow record of a file.
+func (s *FileDriver) startTime(fn string) (int64, bool) {
+	if ts, ok := s.starts[fn]; ok {
+		return ts, true
+	}
+	f, err := os.Open(fn)
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+	sreader, _, closer, err := openTrace(bufio.NewReader(f))
+	if err != nil {
+		return 0, false
+	}
+	defer closer()
+	sfobjcvter := converter.NewSFObjectConverter()
+	for sreader.Scan() {
+		datum, err := sreader.Read()
+		if err != nil {
+			break
+		}
+		if ts, _, ok := recordTime(sfobjcvter.ConvertToSysFlow(datum)); ok {
+			s.starts[fn] = ts
+			return ts, true
+		}
+	}
+	return 0, false
+}
+
+// openTrace creates an Avro reader of a SysFlow trace, possibly compressed, and a counter of the
+// bytes read from the uncompressed trace.
+func openTrace(r *bufio.Reader) (*goavro.OCFReader, *countingReader, func() error, error) {
+	dr, closer, err := decompress(r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	cr := &countingReader{r: dr}
+	sreader, err := goavro.NewOCFReader(cr)
+	if err != nil {
+		closer()
+		return nil, nil, nil, err
+	}
+	return sreader, cr, closer, nil
+}
+
+// readFile reads the records of a file into the pipeline, starting from its checkpointed offset.
+// When following, the file is tailed until it is rotated.
+func (s *FileDriver) readFile(ctx context.Context, fn string, cp *checkpoint, follow bool, poll time.Duration, rotated func() bool, records chan *sfgo.SysFlow) error {
+	logger.Trace.Println("Loading file: " + fn)
+	f, err := os.Open(fn)
+	if err != nil {
+		return err
+	}
+	s.file = f
+	defer f.Close()
+	save := func() {
+		if err := cp.save(); err != nil {
+			logger.Error.Println("Checkpoint error: ", err)
+		}
+	}
+	tr := &tailReader{ctx: ctx, file: f, follow: follow, poll: poll, rotated: rotated, idle: save}
+	br := bufio.NewReader(tr)
+	// compressed traces are complete archives, and are not tailed
+	if compressed(br) {
+		tr.follow = false
+	}
+	sreader, cr, closer, err := openTrace(br)
+	if err != nil {
+		return err
+	}
+	defer closer()
+	st := cp.state(fn)
+	offset, skip := st.Offset, st.Skip
+	sfobjcvter := converter.NewSFObjectConverter()
+	var block, n int64
+	saved := time.Now()
+	for ctx.Err() == nil {
+		if sreader.RemainingBlockItems() <= 0 {
+			block, n = cr.n, 0
+		}
+		if !sreader.Scan() {
+			break
+		}
+		datum, err := sreader.Read()
+		if err != nil {
+			return err
+		}
+		n++
+		sf := sfobjcvter.ConvertToSysFlow(datum)
+		// replay the entities of processed records to rebuild the reader caches
+		if block < offset || block == offset && n <= skip {
+			if isEntity(sf) {
+				records <- sf
+			}
+			continue
+		}
+		if s.window.contains(sf) {
+			if s.replay != nil {
+				s.replay.pace(ctx, sf)
+			}
+			records <- sf
+		}
+		if sreader.RemainingBlockItems() <= 0 {
+			st.Offset, st.Skip = cr.n, 0
+			if time.Since(saved) >= poll {
+				save()
+				saved = time.Now()
+			}
+		} else {
+			st.Offset, st.Skip = block, n
+		}
+	}
+	return sreader.Err()
+}
+
+// isEntity returns whether a SysFlow record is a header or an entity.
+func isEntity(sf *sfgo.SysFlow) bool {
+	switch sf.Rec.UnionType {
+	case sfgo.SF_HEADER, sfgo.SF_CONT, sfgo.SF_PROCESS, sfgo.SF_FILE:
+		return true
+	}
+	return false
+}
+
+// wait waits for d, or until the context is canceled.
+func wait(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
 // Cleanup tears down the driver resources.
 func (s *FileDriver) Cleanup() {
 	logger.Trace.Println("Exiting ", fileDriverName)
@@ -0,0 +1,157 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DataDog/zstd"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+)
+
+// Magic bytes of the supported file formats.
+var (
+	avroMagic = []byte{'O', 'b', 'j', 1}
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// fileEntry is a SysFlow file and its timestamp.
+type fileEntry struct {
+	path string
+	ts   int64
+}
+
+// getFiles returns the SysFlow files given by a file, a directory, or a glob pattern, in timestamp
+// order. Directories are read recursively, and files that are not SysFlow traces are skipped.
+func (s *FileDriver) getFiles(path string) ([]string, error) {
+	matches := []string{path}
+	if strings.ContainsAny(path, "*?[") {
+		var err error
+		if matches, err = filepath.Glob(path); err != nil {
+			return nil, err
+		}
+	} else if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	var entries []fileEntry
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		err := filepath.Walk(m, func(p string, fi os.FileInfo, err error) error {
+			if err != nil {
+				// files may be removed while the directory is read
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if fi.IsDir() || seen[p] {
+				return nil
+			}
+			seen[p] = true
+			if p != path && !s.isSysFlow(p) {
+				logger.Trace.Println("Skipping non-SysFlow file: " + p)
+				return nil
+			}
+			logger.Trace.Println("File in Directory: " + p)
+			entries = append(entries, fileEntry{p, fileTime(fi)})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].ts < entries[j].ts })
+	var fls []string
+	for _, e := range entries {
+		fls = append(fls, e.path)
+	}
+	logger.Trace.Printf("Number of files in list: %d\n", len(fls))
+	return fls, nil
+}
+
+// isSysFlow returns whether a file is a SysFlow trace, possibly compressed. In follow mode, files
+// too short to be identified are assumed to be traces still being written.
+func (s *FileDriver) isSysFlow(path string) bool {
+	if v, ok := s.formats[path]; ok {
+		return v
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	r, closer, err := decompress(bufio.NewReader(f))
+	if err != nil {
+		return false
+	}
+	defer closer()
+	magic := make([]byte, len(avroMagic))
+	if n, _ := io.ReadFull(r, magic); n < len(magic) {
+		return s.follow
+	}
+	v := bytes.Equal(magic, avroMagic)
+	s.formats[path] = v
+	return v
+}
+
+// fileTime returns the timestamp of a SysFlow file, given by the last numeric component of its
+// name (e.g., mon.1531776712.sf), or else by its modification time.
+func fileTime(fi os.FileInfo) int64 {
+	parts := strings.Split(fi.Name(), ".")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if t, err := strconv.ParseInt(parts[i], 10, 64); err == nil {
+			return t
+		}
+	}
+	return fi.ModTime().Unix()
+}
+
+// compressed returns whether a file is compressed with gzip or zstd, given its magic bytes.
+func compressed(r *bufio.Reader) bool {
+	magic, _ := r.Peek(len(zstdMagic))
+	return bytes.HasPrefix(magic, gzipMagic) || bytes.Equal(magic, zstdMagic)
+}
+
+// decompress returns a reader of the decompressed contents of a gzip or zstd file, identified
+// by its magic bytes, or r if the file is not compressed.
+func decompress(r *bufio.Reader) (io.Reader, func() error, error) {
+	magic, _ := r.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return bufio.NewReader(gz), gz.Close, nil
+	case bytes.Equal(magic, zstdMagic):
+		zr := zstd.NewReader(r)
+		return bufio.NewReader(zr), zr.Close, nil
+	}
+	return r, func() error { return nil }, nil
+}
@@ -0,0 +1,158 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DataDog/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+)
+
+func TestGetFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "files")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	deeper := filepath.Join(dir, "sub", "deeper")
+	assert.NoError(t, os.MkdirAll(deeper, 0700))
+	trace, err := ioutil.ReadFile(filepath.Join(tracesDir, "tcp.sf"))
+	assert.NoError(t, err)
+	write := func(name string, b []byte) string {
+		fn := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(fn, b, 0600))
+		return fn
+	}
+	c := write("sub/deeper/c.30.sf", trace)
+	a := write("a.10.sf", trace)
+	b := write("sub/b.20.sf", trace)
+	notes := write("sub/notes.txt", []byte("not a trace"))
+	short := write("short.40.sf", avroMagic[:2])
+
+	d := newFileDriver()
+	files, err := d.getFiles(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{a, b, c}, files)
+	files, err = d.getFiles(filepath.Join(dir, "s*"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{b, c}, files)
+	files, err = d.getFiles(filepath.Join(dir, "*", "*.sf"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{b}, files)
+
+	// files given explicitly are read regardless of their format
+	files, err = d.getFiles(notes)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{notes}, files)
+	_, err = d.getFiles(filepath.Join(dir, "missing.sf"))
+	assert.Error(t, err)
+
+	// traces too short to be identified are still being written when following
+	d = newFileDriver()
+	d.follow = true
+	files, err = d.getFiles(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{a, b, c, short}, files)
+}
+
+func TestCompressedTraces(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compressed")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	trace, err := ioutil.ReadFile(filepath.Join(tracesDir, "tcp.sf"))
+	assert.NoError(t, err)
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write(trace)
+	assert.NoError(t, w.Close())
+	zs, err := zstd.Compress(nil, trace)
+	assert.NoError(t, err)
+
+	expected := events(runFileDriver(t, newFileDriver(), filepath.Join(tracesDir, "tcp.sf"), nil, nil))
+	assert.NotEmpty(t, expected)
+	for name, b := range map[string][]byte{"tcp.sf.gz": gz.Bytes(), "tcp.sf.zst": zs} {
+		fn := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(fn, b, 0600))
+		d := newFileDriver()
+		assert.True(t, d.isSysFlow(fn), name)
+		assert.Equal(t, expected, events(runFileDriver(t, d, fn, nil, nil)), name)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	for _, v := range []string{"1531776712", "2018-07-16T21:31:52Z", "2018-07-16T21:31:52", "2018-07-16 21:31:52"} {
+		ts, err := parseTime(v)
+		assert.NoError(t, err, v)
+		assert.Equal(t, int64(1531776712), ts.Unix(), v)
+	}
+	ts, err := parseTime("2018-07-16")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2018, 7, 16, 0, 0, 0, 0, time.UTC), ts)
+	_, err = parseTime("yesterday")
+	assert.Error(t, err)
+	_, err = newTimeWindow("", "tomorrow")
+	assert.Error(t, err)
+}
+
+func TestTimeWindow(t *testing.T) {
+	d := newFileDriver()
+	files, err := d.getFiles(filepath.Join(tracesDir, "mon.*.sf"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+	var starts []int64
+	for _, fn := range files {
+		ts, ok := d.startTime(fn)
+		assert.True(t, ok, fn)
+		starts = append(starts, ts)
+	}
+
+	// the window starts after the first record of the second file, and ends with the third file
+	from, to := time.Unix(0, starts[1]+1).UTC(), time.Unix(0, starts[2]).UTC()
+	d.window, err = newTimeWindow(from.Format(time.RFC3339Nano), to.Format(time.RFC3339Nano))
+	assert.NoError(t, err)
+	assert.True(t, d.window.bounded())
+	assert.True(t, d.outside(files, 0))
+	assert.False(t, d.outside(files, 1))
+	assert.True(t, d.outside(files, 2))
+
+	// only the second file is read, and its records outside of the window are filtered
+	all := runFileDriver(t, newFileDriver(), files[1], nil, nil)
+	var expected []*sfgo.SysFlow
+	for _, sf := range all {
+		if d.window.contains(sf) {
+			expected = append(expected, sf)
+		}
+	}
+	assert.True(t, len(expected) < len(all))
+	config := map[string]string{FromConfig: from.Format(time.RFC3339Nano), ToConfig: to.Format(time.RFC3339Nano)}
+	recs := runFileDriver(t, newFileDriver(), filepath.Join(tracesDir, "mon.*.sf"), config, nil)
+	assert.NotEmpty(t, events(recs))
+	assert.Equal(t, events(expected), events(recs))
+	for _, sf := range recs {
+		ts, end, ok := recordTime(sf)
+		assert.True(t, !ok || end >= from.UnixNano() && ts < to.UnixNano())
+	}
+}
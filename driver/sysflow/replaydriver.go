@@ -0,0 +1,193 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
+)
+
+const (
+	replayDriverName = "replay"
+)
+
+// Replay driver config attributes.
+const (
+	SpeedConfig     = "speed"
+	LoopConfig      = "loop"
+	RewriteTsConfig = "rewritets"
+)
+
+// SpeedMax denotes replaying records as fast as possible.
+const SpeedMax = "max"
+
+// ReplayDriver represents a sysflow file datasource that replays records at the pace at which
+// they were recorded.
+type ReplayDriver struct {
+	FileDriver
+}
+
+// NewReplayDriver creates a new replay driver object
+func NewReplayDriver() plugins.SFDriver {
+	return &ReplayDriver{FileDriver: *newFileDriver()}
+}
+
+// GetName returns the driver name.
+func (s *ReplayDriver) GetName() string {
+	return replayDriverName
+}
+
+// Register registers driver to plugin cache
+func (s *ReplayDriver) Register(pc plugins.SFPluginCache) {
+	pc.AddDriver(replayDriverName, NewReplayDriver)
+}
+
+// ConfigSchema returns the config schema of the driver.
+func (s *ReplayDriver) ConfigSchema() schema.Schema {
+	return schema.Schema{Attrs: []schema.Attr{
+		{Key: SpeedConfig, Type: schema.String, Default: "1x", Check: func(v string) error {
+			_, err := parseSpeed(v)
+			return err
+		}},
+		{Key: LoopConfig, Type: schema.Bool, Default: "false"},
+		{Key: RewriteTsConfig, Type: schema.Bool, Default: "false"},
+		{Key: FromConfig, Type: schema.String, Check: checkTime},
+		{Key: ToConfig, Type: schema.String, Check: checkTime},
+	}}
+}
+
+// Run replays the files at path until all files are replayed, or until the pipeline is shut down
+// when looping.
+func (s *ReplayDriver) Run(path string, running *bool) error {
+	conf := driverConfig(s.pipeline)
+	r := &replayer{speed: 1}
+	if v, ok := conf[SpeedConfig]; ok {
+		speed, err := parseSpeed(v)
+		if err != nil {
+			logger.Error.Println("Replay speed error: ", err)
+			return err
+		}
+		r.speed = speed
+	}
+	r.loop, _ = strconv.ParseBool(conf[LoopConfig])
+	r.rewrite, _ = strconv.ParseBool(conf[RewriteTsConfig])
+	s.replay = r
+	return s.FileDriver.Run(path, running)
+}
+
+// parseSpeed parses a replay speed multiplier of the form <n>x (e.g., 10x), or max, which is
+// returned as 0.
+func parseSpeed(v string) (float64, error) {
+	if v == SpeedMax {
+		return 0, nil
+	}
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(v, "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, errors.New("expected a positive multiplier (e.g., 10x) or max")
+	}
+	return speed, nil
+}
+
+// replayer paces the records read by the file driver by the deltas of their timestamps, and
+// optionally rewrites their timestamps to the time at which they are replayed.
+type replayer struct {
+	speed   float64
+	loop    bool
+	rewrite bool
+	base    int64
+	start   time.Time
+	last    time.Time // wall time of the latest record replayed
+	sent    int       // events and flows replayed in the current pass
+}
+
+// begin starts a replay pass at the trace time base. A pass starts no earlier than the records of
+// the previous pass, whose wall times run ahead of the clock when replaying at max speed.
+func (r *replayer) begin(base int64) {
+	r.base, r.start, r.sent = base, time.Now(), 0
+	if r.start.Before(r.last) {
+		r.start = r.last
+	}
+}
+
+// wall returns the wall time at which a record with timestamp ts is replayed.
+func (r *replayer) wall(ts int64) time.Time {
+	d := float64(ts - r.base)
+	if r.speed > 0 {
+		d /= r.speed
+	}
+	return r.start.Add(time.Duration(d))
+}
+
+// pace waits until a record is due, and rewrites its timestamps if configured.
+func (r *replayer) pace(ctx context.Context, sf *sfgo.SysFlow) {
+	if ts, end, ok := recordTime(sf); ok {
+		if r.base == 0 {
+			r.begin(ts)
+		}
+		if d := time.Until(r.wall(ts)); r.speed > 0 && d > 0 {
+			wait(ctx, d)
+		}
+		if w := r.wall(end); w.After(r.last) {
+			r.last = w
+		}
+		r.sent++
+	}
+	if r.rewrite && r.base != 0 {
+		r.rewriteTs(sf)
+	}
+}
+
+// rewriteTs sets the timestamps of a record to the wall time of its replay.
+func (r *replayer) rewriteTs(sf *sfgo.SysFlow) {
+	ts := func(t *int64) {
+		if *t != 0 {
+			*t = r.wall(*t).UnixNano()
+		}
+	}
+	switch sf.Rec.UnionType {
+	case sfgo.SF_PROCESS:
+		ts(&sf.Rec.Process.Ts)
+	case sfgo.SF_FILE:
+		ts(&sf.Rec.File.Ts)
+	case sfgo.SF_PROC_EVT:
+		ts(&sf.Rec.ProcessEvent.Ts)
+	case sfgo.SF_FILE_EVT:
+		ts(&sf.Rec.FileEvent.Ts)
+	case sfgo.SF_NET_EVT:
+		ts(&sf.Rec.NetworkEvent.Ts)
+	case sfgo.SF_PROC_FLOW:
+		ts(&sf.Rec.ProcessFlow.Ts)
+		ts(&sf.Rec.ProcessFlow.EndTs)
+	case sfgo.SF_FILE_FLOW:
+		ts(&sf.Rec.FileFlow.Ts)
+		ts(&sf.Rec.FileFlow.EndTs)
+	case sfgo.SF_NET_FLOW:
+		ts(&sf.Rec.NetworkFlow.Ts)
+		ts(&sf.Rec.NetworkFlow.EndTs)
+	}
+}
@@ -0,0 +1,147 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/sysflowtest"
+)
+
+const base = int64(1531776712000000000)
+
+func newFileFlow(ts int64, end int64) *sfgo.SysFlow {
+	sf := sysflowtest.Record(sfgo.UnionSFHeaderContainerProcessFileProcessEventNetworkFlowFileFlowFileEventNetworkEventProcessFlowTypeEnumFileFlow)
+	sf.Rec.FileFlow = &sfgo.FileFlow{ProcOID: &sfgo.OID{}, Ts: ts, EndTs: end}
+	return sf
+}
+
+func TestParseSpeed(t *testing.T) {
+	for v, speed := range map[string]float64{"1x": 1, "10x": 10, "0.5x": 0.5, "4": 4, SpeedMax: 0} {
+		s, err := parseSpeed(v)
+		assert.NoError(t, err, v)
+		assert.Equal(t, speed, s, v)
+	}
+	for _, v := range []string{"0x", "-2x", "fast", ""} {
+		_, err := parseSpeed(v)
+		assert.Error(t, err, v)
+	}
+}
+
+func TestReplayerWall(t *testing.T) {
+	r := &replayer{speed: 4}
+	r.begin(base)
+	assert.Equal(t, r.start, r.wall(base))
+	assert.Equal(t, r.start.Add(5*time.Millisecond), r.wall(base+int64(20*time.Millisecond)))
+	r.speed = 0.5
+	assert.Equal(t, r.start.Add(40*time.Millisecond), r.wall(base+int64(20*time.Millisecond)))
+	r.speed = 0
+	assert.Equal(t, r.start.Add(20*time.Millisecond), r.wall(base+int64(20*time.Millisecond)))
+}
+
+func TestReplayerPace(t *testing.T) {
+	ctx := context.Background()
+	r := &replayer{speed: 10}
+	// entities do not start the replay
+	r.pace(ctx, sysflowtest.Process(1, "/bin/sh"))
+	assert.Zero(t, r.base)
+	assert.Zero(t, r.sent)
+
+	start := time.Now()
+	for _, d := range []time.Duration{0, 200 * time.Millisecond, 400 * time.Millisecond} {
+		r.pace(ctx, sysflowtest.ProcEvt(1, base+int64(d)))
+	}
+	elapsed := time.Since(start)
+	assert.Equal(t, base, r.base)
+	assert.Equal(t, 3, r.sent)
+	assert.True(t, elapsed >= 40*time.Millisecond, elapsed)
+	assert.True(t, elapsed < 400*time.Millisecond, elapsed)
+
+	// records are replayed as fast as possible at max speed
+	r = &replayer{}
+	start = time.Now()
+	r.pace(ctx, sysflowtest.ProcEvt(1, base))
+	r.pace(ctx, sysflowtest.ProcEvt(1, base+int64(time.Hour)))
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Equal(t, 2, r.sent)
+
+	// pacing stops when the driver is canceled
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	r = &replayer{speed: 1}
+	start = time.Now()
+	r.pace(cctx, sysflowtest.ProcEvt(1, base))
+	r.pace(cctx, sysflowtest.ProcEvt(1, base+int64(time.Hour)))
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestReplayerRewriteTs(t *testing.T) {
+	r := &replayer{speed: 10, rewrite: true}
+	// records preceding the first event are not rewritten
+	proc := sysflowtest.Process(1, "/bin/sh")
+	proc.Rec.Process.Ts = base - 1000
+	r.pace(context.Background(), proc)
+	assert.Equal(t, base-1000, proc.Rec.Process.Ts)
+
+	r.pace(context.Background(), sysflowtest.ProcEvt(1, base))
+	start := r.start.UnixNano()
+	evt := sysflowtest.ProcEvt(1, base+1000)
+	r.rewriteTs(evt)
+	assert.Equal(t, start+100, evt.Rec.ProcessEvent.Ts)
+	ff := newFileFlow(base+2000, base+5000)
+	r.rewriteTs(ff)
+	assert.Equal(t, start+200, ff.Rec.FileFlow.Ts)
+	assert.Equal(t, start+500, ff.Rec.FileFlow.EndTs)
+	// unset timestamps are kept
+	proc.Rec.Process.Ts = 0
+	r.rewriteTs(proc)
+	assert.Zero(t, proc.Rec.Process.Ts)
+}
+
+func TestReplayLoop(t *testing.T) {
+	path := filepath.Join(tracesDir, "tcp.sf")
+	n := len(events(runFileDriver(t, newFileDriver(), path, nil, nil)))
+	assert.True(t, n > 0)
+	config := map[string]string{SpeedConfig: SpeedMax, LoopConfig: "true", RewriteTsConfig: "true"}
+	var evts []*sfgo.SysFlow
+	runFileDriver(t, NewReplayDriver(), path, config, func(sf *sfgo.SysFlow) bool {
+		if _, _, ok := recordTime(sf); ok {
+			evts = append(evts, sf)
+		}
+		return len(evts) == 2*n
+	})
+	assert.Len(t, evts, 2*n)
+
+	// each pass restarts at the trace time base, rewritten to the time of the pass
+	for i := 0; i < n; i++ {
+		ts1, _, _ := recordTime(evts[i])
+		ts2, _, _ := recordTime(evts[n+i])
+		assert.True(t, ts2 > ts1, "record %d", i)
+		assert.Equal(t, *procOID(evts[i]), *procOID(evts[n+i]), "record %d", i)
+	}
+	last, _, _ := recordTime(evts[n-1])
+	second, _, _ := recordTime(evts[n])
+	assert.True(t, second >= last, "pass 2 starts at %d before pass 1 ends at %d", second, last)
+}
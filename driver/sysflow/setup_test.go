@@ -0,0 +1,86 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/flattener"
+	"github.com/sysflow-telemetry/sf-processor/core/processor"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLoggers(logger.TRACE)
+	os.Exit(m.Run())
+}
+
+// testPipeline is a pipeline stub feeding a driver's records into a root channel.
+type testPipeline struct {
+	plugins.SFPipeline
+	ctx     context.Context
+	channel *plugins.SFChannel
+	config  map[string]string
+}
+
+func newTestPipeline(ctx context.Context, size int, config map[string]string) *testPipeline {
+	return &testPipeline{ctx: ctx, channel: &plugins.SFChannel{In: make(chan *sfgo.SysFlow, size)}, config: config}
+}
+
+func (p *testPipeline) GetRootChannel() interface{}        { return p.channel }
+func (p *testPipeline) Wait()                              {}
+func (p *testPipeline) Context() context.Context           { return p.ctx }
+func (p *testPipeline) GetDriverConfig() map[string]string { return p.config }
+
+
+
+
+
+// flatten runs records through the SysFlow processor, returning the flattened event records.
+func flatten(records []*sfgo.SysFlow) []*sfgo.FlatRecord {
+	ch := flattener.NewFlattenerChan(len(records)).(*flattener.FlatChannel)
+	proc := processor.NewSysFlowProcessor(flattener.NewFlattener())
+	proc.Init(map[string]string{})
+	proc.SetOutChan(ch)
+	in := processor.NewSysFlowChan(len(records)).(*plugins.SFChannel)
+	for _, r := range records {
+		in.In <- r
+	}
+	close(in.In)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	proc.Process(in, &wg)
+	close(ch.In)
+	var frs []*sfgo.FlatRecord
+	for fr := range ch.In {
+		frs = append(frs, fr)
+	}
+	return frs
+}
+
+// streamExe returns the exporter and process executable of a flattened event record.
+func streamExe(fr *sfgo.FlatRecord) (string, string) {
+	return fr.Strs[sfgo.SYSFLOW_IDX][sfgo.SFHE_EXPORTER_STR], fr.Strs[sfgo.SYSFLOW_IDX][sfgo.PROC_EXE_STR]
+}
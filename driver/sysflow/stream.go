@@ -0,0 +1,130 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+)
+
+// StreamStats reports the records received from a driver client.
+type StreamStats struct {
+	Origin    string
+	Connected time.Time
+	Records   uint64
+	Bytes     uint64
+	Truncated uint64
+	Errors    uint64
+}
+
+// stream holds the state of a client stream multiplexed into the pipeline.
+type stream struct {
+	origin    string
+	connected time.Time
+	hdr       *sfgo.SysFlow
+	records   uint64
+	bytes     uint64
+	truncated uint64
+	errors    uint64
+}
+
+// received counts a record of n bytes received from the client.
+func (st *stream) received(n int) {
+	atomic.AddUint64(&st.records, 1)
+	atomic.AddUint64(&st.bytes, uint64(n))
+}
+
+// stats returns a snapshot of the stream stats.
+func (st *stream) stats() StreamStats {
+	return StreamStats{
+		Origin:    st.origin,
+		Connected: st.connected,
+		Records:   atomic.LoadUint64(&st.records),
+		Bytes:     atomic.LoadUint64(&st.bytes),
+		Truncated: atomic.LoadUint64(&st.truncated),
+		Errors:    atomic.LoadUint64(&st.errors),
+	}
+}
+
+// streamMux multiplexes the record streams of concurrent clients into the pipeline root channel.
+type streamMux struct {
+	mu      sync.Mutex
+	last    *stream
+	smu     sync.Mutex
+	streams map[*stream]bool
+}
+
+// add registers a new client stream.
+func (m *streamMux) add(origin string) *stream {
+	st := &stream{origin: origin, connected: time.Now()}
+	m.smu.Lock()
+	defer m.smu.Unlock()
+	if m.streams == nil {
+		m.streams = make(map[*stream]bool)
+	}
+	m.streams[st] = true
+	logger.Info.Println("Accepted client connection from", origin)
+	return st
+}
+
+// remove unregisters a client stream, logging its stats.
+func (m *streamMux) remove(st *stream) {
+	m.smu.Lock()
+	delete(m.streams, st)
+	m.smu.Unlock()
+	s := st.stats()
+	logger.Info.Printf("Closed client connection from %s: %d records, %d bytes, %d truncated, %d errors\n",
+		s.Origin, s.Records, s.Bytes, s.Truncated, s.Errors)
+}
+
+// Stats returns the stats of the connected client streams, ordered by connection time.
+func (m *streamMux) Stats() []StreamStats {
+	m.smu.Lock()
+	defer m.smu.Unlock()
+	var stats []StreamStats
+	for st := range m.streams {
+		stats = append(stats, st.stats())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Connected.Before(stats[j].Connected) })
+	return stats
+}
+
+// forward sends a run of records from a stream to the pipeline. The stream header is re-sent when
+// the previous run came from another stream, so that records are attributed to their stream.
+// Sends block while the pipeline is busy, applying backpressure to the client.
+func (m *streamMux) forward(st *stream, run []*sfgo.SysFlow, records chan *sfgo.SysFlow) {
+	if len(run) == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.last != st && st.hdr != nil && run[0] != st.hdr {
+		records <- st.hdr
+	}
+	m.last = st
+	for _, r := range run {
+		records <- r
+	}
+}
@@ -0,0 +1,94 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/sysflowtest"
+)
+
+// drain returns the records buffered in a channel.
+func drain(records chan *sfgo.SysFlow) []*sfgo.SysFlow {
+	var recs []*sfgo.SysFlow
+	for len(records) > 0 {
+		recs = append(recs, <-records)
+	}
+	return recs
+}
+
+func TestStreamMuxInterleaved(t *testing.T) {
+	var mux streamMux
+	records := make(chan *sfgo.SysFlow, 64)
+	exporters := []string{"mux-a", "mux-b", "mux-c"}
+	var streams []*stream
+	// all collectors report the same process OID, which must resolve to their own process
+	for _, e := range exporters {
+		st := mux.add(e)
+		st.hdr = sysflowtest.Header(e)
+		mux.forward(st, []*sfgo.SysFlow{st.hdr, sysflowtest.Process(42, "/bin/"+e)}, records)
+		streams = append(streams, st)
+	}
+	for ts := int64(1); ts <= 2; ts++ {
+		for _, st := range streams {
+			mux.forward(st, []*sfgo.SysFlow{sysflowtest.ProcEvt(42, ts)}, records)
+		}
+	}
+	recs := drain(records)
+	headers := 0
+	for _, r := range recs {
+		if r.Rec.UnionType == sfgo.SF_HEADER {
+			headers++
+		}
+	}
+	// each switch between streams re-sends the stream header
+	assert.Equal(t, 3+6, headers)
+
+	frs := flatten(recs)
+	assert.Len(t, frs, 6)
+	for _, fr := range frs {
+		exporter, exe := streamExe(fr)
+		assert.Equal(t, "/bin/"+exporter, exe)
+	}
+
+	for _, st := range streams {
+		mux.remove(st)
+	}
+	assert.Empty(t, mux.Stats())
+}
+
+func TestStreamMuxStats(t *testing.T) {
+	var mux streamMux
+	a := mux.add("stats-a")
+	b := mux.add("stats-b")
+	b.connected = a.connected.Add(time.Second)
+	a.received(10)
+	a.received(20)
+	b.received(5)
+	stats := mux.Stats()
+	assert.Len(t, stats, 2)
+	assert.Equal(t, StreamStats{Origin: "stats-a", Connected: a.connected, Records: 2, Bytes: 30}, stats[0])
+	assert.Equal(t, StreamStats{Origin: "stats-b", Connected: b.connected, Records: 1, Bytes: 5}, stats[1])
+	mux.remove(a)
+	assert.Equal(t, []StreamStats{b.stats()}, mux.Stats())
+}
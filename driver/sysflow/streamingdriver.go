@@ -21,14 +21,23 @@ package sysflow
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net"
 	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
 
 	"github.com/actgardner/gogen-avro/v7/compiler"
 	"github.com/actgardner/gogen-avro/v7/vm"
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
 	"github.com/sysflow-telemetry/sf-apis/go/plugins"
 	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
 )
 
 const (
@@ -42,15 +51,25 @@ const (
 	OOBuffSize = 1024
 )
 
-// StreamingDriver represents a streaming sysflow datasource
+// Socket driver config attributes.
+const (
+	MaxMsgSizeConfig = "maxmsgsize"
+)
+
+// StreamingDriver represents a streaming sysflow datasource, which serves concurrent collectors
+// over a domain socket.
 type StreamingDriver struct {
 	pipeline plugins.SFPipeline
-	conn     *net.UnixConn
+	listener *net.UnixListener
+	conns    map[*net.UnixConn]bool
+	clients  int
+	mu       sync.Mutex
+	mux      streamMux
 }
 
 // NewStreamingDriver creates a new streaming driver object
 func NewStreamingDriver() plugins.SFDriver {
-	return &StreamingDriver{}
+	return &StreamingDriver{conns: make(map[*net.UnixConn]bool)}
 }
 
 // GetName returns the driver name.
@@ -63,17 +82,37 @@ func (s *StreamingDriver) Register(pc plugins.SFPluginCache) {
 	pc.AddDriver(streamDriverName, NewStreamingDriver)
 }
 
+// ConfigSchema returns the config schema of the driver.
+func (s *StreamingDriver) ConfigSchema() schema.Schema {
+	return schema.Schema{Attrs: []schema.Attr{
+		{Key: MaxMsgSizeConfig, Type: schema.Int, Default: strconv.Itoa(BuffSize), Check: func(v string) error {
+			if n, _ := strconv.Atoi(v); n <= 0 {
+				return errors.New("must be positive")
+			}
+			return nil
+		}},
+	}}
+}
+
 // Init initializes the driver
 func (s *StreamingDriver) Init(pipeline plugins.SFPipeline) error {
 	s.pipeline = pipeline
 	return nil
 }
 
-// Run runs the driver
+// Run runs the driver until the pipeline is shut down.
 func (s *StreamingDriver) Run(path string, running *bool) error {
+	ctx := pipelineContext(s.pipeline)
 	channel := s.pipeline.GetRootChannel()
 	sfChannel := channel.(*plugins.SFChannel)
 
+	maxSize := BuffSize
+	if v, ok := driverConfig(s.pipeline)[MaxMsgSizeConfig]; ok {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			maxSize = n
+		}
+	}
+
 	records := sfChannel.In
 	if err := os.RemoveAll(path); err != nil {
 		logger.Error.Println("remove error:", err)
@@ -85,54 +124,136 @@ func (s *StreamingDriver) Run(path string, running *bool) error {
 		logger.Error.Println("listen error:", err)
 		return err
 	}
-	defer l.Close()
+	s.mu.Lock()
+	s.listener = l
+	s.mu.Unlock()
+
+	// unblock pending accepts and reads on shutdown
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.closeAll()
+		case <-stop:
+		}
+	}()
 
 	sFlow := sfgo.NewSysFlow()
 	deser, err := compiler.CompileSchemaBytes([]byte(sFlow.Schema()), []byte(sFlow.Schema()))
 	if err != nil {
 		logger.Error.Println("compiler error:", err)
+		s.closeAll()
 		return err
 	}
 
-	for *running {
-		buf := make([]byte, BuffSize)
-		oobuf := make([]byte, OOBuffSize)
-		reader := bytes.NewReader(buf)
-		s.conn, err = l.AcceptUnix()
+	var wg sync.WaitGroup
+	var backoff acceptBackoff
+	for ctx.Err() == nil {
+		conn, err := l.AcceptUnix()
 		if err != nil {
-			logger.Error.Println("accept error:", err)
+			if backoff.retry(ctx, err) {
+				continue
+			}
 			break
 		}
-		for *running {
-			sFlow = sfgo.NewSysFlow()
-			_, _, flags, _, err := s.conn.ReadMsgUnix(buf[:], oobuf[:])
-			if err != nil {
-				logger.Error.Println("read error:", err)
-				break
-			}
-			if flags == 0 {
-				reader.Reset(buf)
-				err = vm.Eval(reader, deser, sFlow)
-				if err != nil {
-					logger.Error.Println("deserialize:", err)
-				}
-				records <- sFlow
-			} else {
-				logger.Error.Println("Flag error ReadMsgUnix:", flags)
-			}
+		backoff.reset()
+		origin, ok := s.addConn(ctx, conn)
+		if !ok {
+			break
 		}
-		s.conn.Close()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer s.removeConn(conn)
+			s.serve(ctx, conn, origin, maxSize, deser, records)
+		}()
 	}
+	s.closeAll()
+	wg.Wait()
 	logger.Trace.Println("Closing main channel")
 	close(records)
 	s.pipeline.Wait()
 	return nil
 }
 
+// serve reads SysFlow records from a collector connection, one record per message. Messages
+// larger than maxSize are truncated by the socket, and are reported and skipped.
+func (s *StreamingDriver) serve(ctx context.Context, conn *net.UnixConn, origin string, maxSize int, deser *vm.Program, records chan *sfgo.SysFlow) {
+	st := s.mux.add(origin)
+	defer s.mux.remove(st)
+
+	buf := make([]byte, maxSize)
+	oobuf := make([]byte, OOBuffSize)
+	reader := bytes.NewReader(buf)
+	for {
+		n, _, flags, _, err := conn.ReadMsgUnix(buf, oobuf)
+		if err != nil || n == 0 {
+			if err != nil && err != io.EOF && ctx.Err() == nil {
+				logger.Error.Println("read error:", err)
+			}
+			break
+		}
+		if flags&syscall.MSG_TRUNC != 0 {
+			logger.Error.Printf("Message from %s truncated to %d bytes; increase the driver %s\n", st.origin, n, MaxMsgSizeConfig)
+			atomic.AddUint64(&st.truncated, 1)
+			continue
+		}
+		sFlow := sfgo.NewSysFlow()
+		reader.Reset(buf[:n])
+		if err := vm.Eval(reader, deser, sFlow); err != nil {
+			logger.Error.Println("deserialize:", err)
+			atomic.AddUint64(&st.errors, 1)
+			continue
+		}
+		st.received(n)
+		if sFlow.Rec.UnionType == sfgo.SF_HEADER {
+			st.hdr = sFlow
+		}
+		s.mux.forward(st, []*sfgo.SysFlow{sFlow}, records)
+	}
+}
+
+// Stats returns the stats of the connected collectors.
+func (s *StreamingDriver) Stats() []StreamStats {
+	return s.mux.Stats()
+}
+
 // Cleanup tears down the driver resources.
 func (s *StreamingDriver) Cleanup() {
 	logger.Trace.Println("Exiting ", streamDriverName)
-	if s.conn != nil {
-		s.conn.Close()
+	s.closeAll()
+}
+
+// addConn tracks a collector connection, closing it if the driver has been canceled.
+func (s *StreamingDriver) addConn(ctx context.Context, conn *net.UnixConn) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ctx.Err() != nil {
+		conn.Close()
+		return "", false
+	}
+	s.conns[conn] = true
+	s.clients++
+	return fmt.Sprintf("client %d", s.clients), true
+}
+
+// removeConn closes and untracks a collector connection.
+func (s *StreamingDriver) removeConn(conn *net.UnixConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn.Close()
+	delete(s.conns, conn)
+}
+
+// closeAll closes the listener and all collector connections.
+func (s *StreamingDriver) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
 	}
 }
@@ -0,0 +1,132 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/sysflowtest"
+)
+
+// startStreamingDriver runs a streaming driver on a domain socket in a temp dir.
+func startStreamingDriver(t *testing.T, config map[string]string) (*StreamingDriver, *testPipeline, string, func()) {
+	dir, err := ioutil.TempDir("", "streamingdriver")
+	assert.NoError(t, err)
+	path := filepath.Join(dir, "sysflow.sock")
+	ctx, cancel := context.WithCancel(context.Background())
+	pl := newTestPipeline(ctx, 64, config)
+	d := NewStreamingDriver().(*StreamingDriver)
+	assert.NoError(t, d.Init(pl))
+	done := make(chan error)
+	go func() { done <- d.Run(path, nil) }()
+	for i := 0; i < 100; i++ {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return d, pl, path, func() {
+		cancel()
+		assert.NoError(t, <-done)
+		os.RemoveAll(dir)
+	}
+}
+
+// send writes records to a collector connection, one record per message.
+func send(t *testing.T, conn net.Conn, records ...*sfgo.SysFlow) {
+	for _, r := range records {
+		_, err := conn.Write(frame(t, r)[4:])
+		assert.NoError(t, err)
+	}
+}
+
+func dialStream(t *testing.T, path string) net.Conn {
+	conn, err := net.Dial("unixpacket", path)
+	assert.NoError(t, err)
+	return conn
+}
+
+func TestStreamingDriverMaxMsgSize(t *testing.T) {
+	d, pl, path, stop := startStreamingDriver(t, map[string]string{MaxMsgSizeConfig: "128"})
+	defer stop()
+	conn := dialStream(t, path)
+	defer conn.Close()
+
+	// the oversized process message is truncated by the socket and skipped
+	send(t, conn, sysflowtest.Header("trunc"), sysflowtest.Process(1, "/bin/"+strings.Repeat("x", 256)), sysflowtest.Process(2, "/bin/sh"))
+	recs := receive(t, pl, 2)
+	assert.Equal(t, "trunc", recs[0].Rec.SFHeader.Exporter)
+	assert.Equal(t, "/bin/sh", recs[1].Rec.Process.Exe)
+	stats := d.Stats()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, "client 1", stats[0].Origin)
+	assert.Equal(t, uint64(2), stats[0].Records)
+	assert.Equal(t, uint64(1), stats[0].Truncated)
+	assert.Zero(t, stats[0].Errors)
+}
+
+func TestStreamingDriverClients(t *testing.T) {
+	d, pl, path, stop := startStreamingDriver(t, nil)
+	defer stop()
+	var recs []*sfgo.SysFlow
+
+	// collectors on the same host share an exporter, and must not evict each other's entities
+	a := dialStream(t, path)
+	defer a.Close()
+	send(t, a, sysflowtest.Header("unix-host"), sysflowtest.Process(1, "/bin/a"))
+	recs = append(recs, receive(t, pl, 2)...)
+	b := dialStream(t, path)
+	defer b.Close()
+	send(t, b, sysflowtest.Header("unix-host"), sysflowtest.Process(2, "/bin/b"))
+	recs = append(recs, receive(t, pl, 2)...)
+	send(t, a, sysflowtest.ProcEvt(1, 1))
+	recs = append(recs, receive(t, pl, 2)...)
+	c := dialStream(t, path)
+	defer c.Close()
+	send(t, c, sysflowtest.Header("unix-host"), sysflowtest.Process(3, "/bin/c"))
+	recs = append(recs, receive(t, pl, 2)...)
+	for i, conn := range []net.Conn{a, b, c} {
+		send(t, conn, sysflowtest.ProcEvt(int64(i+1), 2))
+		recs = append(recs, receive(t, pl, 2)...)
+	}
+
+	stats := d.Stats()
+	assert.Len(t, stats, 3)
+	for i, n := range []uint64{4, 3, 3} {
+		assert.Equal(t, "client "+string(rune('1'+i)), stats[i].Origin)
+		assert.Equal(t, n, stats[i].Records)
+	}
+
+	frs := flatten(recs)
+	assert.Len(t, frs, 4)
+	for i, exe := range []string{"/bin/a", "/bin/a", "/bin/b", "/bin/c"} {
+		_, e := streamExe(frs[i])
+		assert.Equal(t, exe, e, "event %d", i)
+	}
+}
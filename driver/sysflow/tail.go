@@ -0,0 +1,73 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+// tailReader reads a file that may still be written. When following, it waits for more data at
+// the end of the file, until the file is rotated or the driver is canceled.
+type tailReader struct {
+	ctx     context.Context
+	file    *os.File
+	follow  bool
+	poll    time.Duration
+	rotated func() bool
+	idle    func()
+}
+
+// Read reads from the file, returning io.EOF at the end of a file that is not followed, or
+// that has been rotated.
+func (t *tailReader) Read(p []byte) (int, error) {
+	for {
+		n, err := t.file.Read(p)
+		if n > 0 || err != io.EOF || !t.follow {
+			return n, err
+		}
+		if t.ctx.Err() != nil {
+			return 0, io.EOF
+		}
+		if t.rotated() {
+			// read data written before the file was rotated
+			if n, err = t.file.Read(p); n > 0 {
+				return n, nil
+			}
+			return 0, io.EOF
+		}
+		t.idle()
+		wait(t.ctx, t.poll)
+	}
+}
+
+// countingReader counts the bytes read from a reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
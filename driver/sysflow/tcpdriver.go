@@ -0,0 +1,370 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/actgardner/gogen-avro/v7/compiler"
+	"github.com/actgardner/gogen-avro/v7/vm"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/schema"
+)
+
+const (
+	tcpDriverName = "tcp"
+)
+
+const (
+	// MaxFrameSize represents the maximum size of a length-prefixed SysFlow record
+	MaxFrameSize = 16 * 1024 * 1024
+	// RunSize represents the maximum number of records forwarded from a connection at a time
+	RunSize = 256
+	// HandshakeTimeout represents the maximum duration of a TLS handshake with a collector
+	HandshakeTimeout = 10 * time.Second
+	// ReadTimeout represents the maximum idle time of a collector connection before it is closed
+	ReadTimeout = 5 * time.Minute
+	// MaxConns represents the default maximum number of concurrent collector connections
+	MaxConns = 1024
+)
+
+// Network driver config attributes.
+const (
+	MaxConnsConfig = "maxconns"
+)
+
+// TCPDriver represents a network sysflow datasource, which receives length-prefixed SysFlow
+// records from many collectors over TCP, optionally secured with (mutual) TLS.
+type TCPDriver struct {
+	pipeline plugins.SFPipeline
+	listener net.Listener
+	conns    map[net.Conn]bool
+	maxConns int
+	mu       sync.Mutex
+	mux      streamMux
+}
+
+// NewTCPDriver creates a new network driver object
+func NewTCPDriver() plugins.SFDriver {
+	return &TCPDriver{conns: make(map[net.Conn]bool), maxConns: MaxConns}
+}
+
+// GetName returns the driver name.
+func (s *TCPDriver) GetName() string {
+	return tcpDriverName
+}
+
+// Register registers driver to plugin cache
+func (s *TCPDriver) Register(pc plugins.SFPluginCache) {
+	pc.AddDriver(tcpDriverName, NewTCPDriver)
+}
+
+// ConfigSchema returns the config schema of the driver.
+func (s *TCPDriver) ConfigSchema() schema.Schema {
+	return schema.Schema{Attrs: []schema.Attr{
+		{Key: MaxConnsConfig, Type: schema.Int, Default: strconv.Itoa(MaxConns), Check: func(v string) error {
+			if n, _ := strconv.Atoi(v); n <= 0 {
+				return errors.New("must be positive")
+			}
+			return nil
+		}},
+	}}
+}
+
+// Init initializes the driver
+func (s *TCPDriver) Init(pipeline plugins.SFPipeline) error {
+	s.pipeline = pipeline
+	return nil
+}
+
+// Run listens on the address given by path until the pipeline is shut down. The address has the
+// form [tcp://]host:port, or tls://host:port?cert=<file>&key=<file>[&ca=<file>], where ca enables
+// client certificate verification.
+func (s *TCPDriver) Run(path string, running *bool) error {
+	ctx := pipelineContext(s.pipeline)
+	channel := s.pipeline.GetRootChannel()
+	records := channel.(*plugins.SFChannel).In
+
+	if v, ok := driverConfig(s.pipeline)[MaxConnsConfig]; ok {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			s.maxConns = n
+		}
+	}
+
+	l, err := listen(path)
+	if err != nil {
+		logger.Error.Println("listen error:", err)
+		return err
+	}
+	s.mu.Lock()
+	s.listener = l
+	s.mu.Unlock()
+
+	// unblock pending accepts and reads on shutdown
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.closeAll()
+		case <-stop:
+		}
+	}()
+
+	sFlow := sfgo.NewSysFlow()
+	deser, err := compiler.CompileSchemaBytes([]byte(sFlow.Schema()), []byte(sFlow.Schema()))
+	if err != nil {
+		logger.Error.Println("compiler error:", err)
+		return err
+	}
+
+	var wg sync.WaitGroup
+	var backoff acceptBackoff
+	for ctx.Err() == nil {
+		conn, err := l.Accept()
+		if err != nil {
+			if backoff.retry(ctx, err) {
+				continue
+			}
+			break
+		}
+		backoff.reset()
+		if !s.addConn(ctx, conn) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer s.removeConn(conn)
+			s.serve(conn, deser, records)
+		}()
+	}
+	s.closeAll()
+	wg.Wait()
+	logger.Trace.Println("Closing main channel")
+	close(records)
+	s.pipeline.Wait()
+	return nil
+}
+
+// serve reads length-prefixed SysFlow records from a collector connection and forwards them to the
+// pipeline in runs of up to RunSize records. Frames are buffered as their bytes arrive, so that
+// a length prefix alone does not allocate a frame of that size.
+func (s *TCPDriver) serve(conn net.Conn, deser *vm.Program, records chan *sfgo.SysFlow) {
+	org, err := origin(conn)
+	if err != nil {
+		logger.Error.Printf("TLS handshake with %s failed: %v\n", conn.RemoteAddr(), err)
+		return
+	}
+	st := s.mux.add(org)
+	defer s.mux.remove(st)
+
+	reader := bufio.NewReader(conn)
+	var buf bytes.Buffer
+	var run []*sfgo.SysFlow
+	for {
+		if reader.Buffered() == 0 {
+			conn.SetReadDeadline(time.Now().Add(ReadTimeout))
+		}
+		var size uint32
+		if err := binary.Read(reader, binary.BigEndian, &size); err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				logger.Warn.Printf("Closing connection from %s idle for %v\n", st.origin, ReadTimeout)
+			} else if err != io.EOF {
+				logger.Trace.Println("read error:", err)
+			}
+			break
+		}
+		if size > MaxFrameSize {
+			logger.Error.Printf("Frame of %d bytes from %s exceeds the maximum frame size\n", size, st.origin)
+			atomic.AddUint64(&st.truncated, 1)
+			break
+		}
+		buf.Reset()
+		if _, err := io.CopyN(&buf, reader, int64(size)); err != nil {
+			logger.Error.Println("read error:", err)
+			break
+		}
+		sFlow := sfgo.NewSysFlow()
+		if err := vm.Eval(bytes.NewReader(buf.Bytes()), deser, sFlow); err != nil {
+			logger.Error.Println("deserialize:", err)
+			atomic.AddUint64(&st.errors, 1)
+			continue
+		}
+		st.received(int(size))
+		if sFlow.Rec.UnionType == sfgo.SF_HEADER {
+			tagHeader(conn, st, sFlow.Rec.SFHeader)
+			s.mux.forward(st, run, records)
+			run = run[:0]
+			st.hdr = sFlow
+		}
+		run = append(run, sFlow)
+		if len(run) >= RunSize || reader.Buffered() == 0 {
+			s.mux.forward(st, run, records)
+			run = run[:0]
+		}
+	}
+	s.mux.forward(st, run, records)
+}
+
+// Stats returns the stats of the connected collectors.
+func (s *TCPDriver) Stats() []StreamStats {
+	return s.mux.Stats()
+}
+
+// tagHeader sets the origin of a stream in its header, unless set by the collector. The exporter
+// omits the client port, so that entity caches keyed on it survive collector reconnects.
+func tagHeader(conn net.Conn, st *stream, hdr *sfgo.SFHeader) {
+	if hdr == nil {
+		return
+	}
+	if hdr.Exporter == "" {
+		hdr.Exporter = st.origin
+		if host, _, err := net.SplitHostPort(st.origin); err == nil {
+			hdr.Exporter = host
+		}
+	}
+	if hdr.Ip == "" {
+		if host, _, err := net.SplitHostPort(conn.RemoteAddr().String()); err == nil {
+			hdr.Ip = host
+		}
+	}
+}
+
+// origin returns the identity of a collector, given by its client certificate or remote address.
+// TLS handshakes are bounded by HandshakeTimeout.
+func origin(conn net.Conn) (string, error) {
+	if tc, ok := conn.(*tls.Conn); ok {
+		tc.SetDeadline(time.Now().Add(HandshakeTimeout))
+		if err := tc.Handshake(); err != nil {
+			return "", err
+		}
+		tc.SetDeadline(time.Time{})
+		if certs := tc.ConnectionState().PeerCertificates; len(certs) > 0 && certs[0].Subject.CommonName != "" {
+			return certs[0].Subject.CommonName, nil
+		}
+	}
+	return conn.RemoteAddr().String(), nil
+}
+
+// listen creates a TCP or TLS listener for a driver address.
+func listen(path string) (net.Listener, error) {
+	u, err := url.Parse(path)
+	if err != nil || u.Host == "" {
+		return net.Listen("tcp", path)
+	}
+	switch u.Scheme {
+	case "tcp":
+		return net.Listen("tcp", u.Host)
+	case "tls":
+		config, err := tlsConfig(u.Query())
+		if err != nil {
+			return nil, err
+		}
+		return tls.Listen("tcp", u.Host, config)
+	}
+	return nil, fmt.Errorf("Unsupported network driver scheme '%s'", u.Scheme)
+}
+
+// tlsConfig creates a server TLS configuration from the cert, key, and ca query parameters.
+func tlsConfig(q url.Values) (*tls.Config, error) {
+	if q.Get("cert") == "" || q.Get("key") == "" {
+		return nil, errors.New("TLS network driver requires cert and key parameters")
+	}
+	cert, err := tls.LoadX509KeyPair(q.Get("cert"), q.Get("key"))
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if ca := q.Get("ca"); ca != "" {
+		pem, err := ioutil.ReadFile(ca)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("No certificates found in " + ca)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// addConn tracks a collector connection, closing it if the driver has been canceled or if it
+// exceeds the maximum number of concurrent connections.
+func (s *TCPDriver) addConn(ctx context.Context, conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ctx.Err() != nil {
+		conn.Close()
+		return false
+	}
+	if len(s.conns) >= s.maxConns {
+		logger.Warn.Printf("Rejecting connection from %s: %d connections already open\n", conn.RemoteAddr(), len(s.conns))
+		conn.Close()
+		return false
+	}
+	s.conns[conn] = true
+	return true
+}
+
+// removeConn closes and untracks a collector connection.
+func (s *TCPDriver) removeConn(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn.Close()
+	delete(s.conns, conn)
+}
+
+// closeAll closes the listener and all collector connections.
+func (s *TCPDriver) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+}
+
+// Cleanup tears down the driver resources.
+func (s *TCPDriver) Cleanup() {
+	logger.Trace.Println("Exiting ", tcpDriverName)
+	s.closeAll()
+}
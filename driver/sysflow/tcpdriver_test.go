@@ -0,0 +1,267 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/actgardner/gogen-avro/v7/compiler"
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/sysflowtest"
+)
+
+// frame serializes records as length-prefixed frames.
+func frame(t *testing.T, records ...*sfgo.SysFlow) []byte {
+	var buf bytes.Buffer
+	for _, r := range records {
+		var rec bytes.Buffer
+		assert.NoError(t, r.Serialize(&rec))
+		binary.Write(&buf, binary.BigEndian, uint32(rec.Len()))
+		buf.Write(rec.Bytes())
+	}
+	return buf.Bytes()
+}
+
+// startTCPDriver runs a network driver on path, returning its listener address.
+func startTCPDriver(t *testing.T, path string, config map[string]string) (*TCPDriver, *testPipeline, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pl := newTestPipeline(ctx, 64, config)
+	d := NewTCPDriver().(*TCPDriver)
+	assert.NoError(t, d.Init(pl))
+	done := make(chan error)
+	go func() { done <- d.Run(path, nil) }()
+	for i := 0; i < 100 && d.addr() == ""; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.NotEmpty(t, d.addr())
+	return d, pl, func() {
+		cancel()
+		assert.NoError(t, <-done)
+	}
+}
+
+// addr returns the listener address of the driver, once listening.
+func (s *TCPDriver) addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// numConns returns the number of open collector connections.
+func (s *TCPDriver) numConns() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+// receive reads n records from the pipeline root channel.
+func receive(t *testing.T, pl *testPipeline, n int) []*sfgo.SysFlow {
+	var recs []*sfgo.SysFlow
+	for len(recs) < n {
+		select {
+		case r := <-pl.channel.In:
+			recs = append(recs, r)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d of %d records", len(recs), n)
+		}
+	}
+	return recs
+}
+
+func TestTCPDriverFraming(t *testing.T) {
+	d, pl, stop := startTCPDriver(t, "127.0.0.1:0", nil)
+	conn, err := net.Dial("tcp", d.addr())
+	assert.NoError(t, err)
+
+	// frames split across writes are reassembled
+	data := frame(t, sysflowtest.Header(""), sysflowtest.Process(7, "/bin/tcp"), sysflowtest.ProcEvt(7, 1), sysflowtest.ProcEvt(7, 2))
+	for _, n := range []int{3, 17, len(data) - 20} {
+		_, err = conn.Write(data[:n])
+		assert.NoError(t, err)
+		data = data[n:]
+		time.Sleep(10 * time.Millisecond)
+	}
+	recs := receive(t, pl, 4)
+	assert.Equal(t, sfgo.SF_HEADER, recs[0].Rec.UnionType)
+	assert.Equal(t, "127.0.0.1", recs[0].Rec.SFHeader.Exporter)
+	assert.Equal(t, "127.0.0.1", recs[0].Rec.SFHeader.Ip)
+	assert.Equal(t, "/bin/tcp", recs[1].Rec.Process.Exe)
+	assert.Equal(t, int64(2), recs[3].Rec.ProcessEvent.Ts)
+
+	stats := d.Stats()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, conn.LocalAddr().String(), stats[0].Origin)
+	assert.Equal(t, uint64(4), stats[0].Records)
+
+	conn.Close()
+	stop()
+	_, open := <-pl.channel.In
+	assert.False(t, open)
+}
+
+func TestTCPDriverMaxFrameSize(t *testing.T) {
+	sFlow := sfgo.NewSysFlow()
+	deser, err := compiler.CompileSchemaBytes([]byte(sFlow.Schema()), []byte(sFlow.Schema()))
+	assert.NoError(t, err)
+	client, server := net.Pipe()
+	records := make(chan *sfgo.SysFlow, 8)
+	d := NewTCPDriver().(*TCPDriver)
+	done := make(chan struct{})
+	go func() {
+		d.serve(server, deser, records)
+		close(done)
+	}()
+
+	client.Write(frame(t, sysflowtest.Header("max")))
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], MaxFrameSize+1)
+	client.Write(size[:])
+	// the connection is dropped without reading the oversized frame
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("oversized frame did not end the connection")
+	}
+	recs := drain(records)
+	assert.Len(t, recs, 1)
+	assert.Equal(t, "max", recs[0].Rec.SFHeader.Exporter)
+	assert.Empty(t, d.Stats())
+	client.Close()
+}
+
+func TestTCPDriverMaxConns(t *testing.T) {
+	d, pl, stop := startTCPDriver(t, "127.0.0.1:0", map[string]string{MaxConnsConfig: "1"})
+	defer stop()
+	first, err := net.Dial("tcp", d.addr())
+	assert.NoError(t, err)
+	defer first.Close()
+	_, err = first.Write(frame(t, sysflowtest.Header("first")))
+	assert.NoError(t, err)
+	receive(t, pl, 1)
+
+	// connections past the limit are closed
+	second, err := net.Dial("tcp", d.addr())
+	assert.NoError(t, err)
+	second.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = second.Read(make([]byte, 1))
+	assert.Equal(t, io.EOF, err)
+	second.Close()
+
+	// connections are accepted again once others close
+	first.Close()
+	for i := 0; i < 100 && d.numConns() > 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	third, err := net.Dial("tcp", d.addr())
+	assert.NoError(t, err)
+	defer third.Close()
+	_, err = third.Write(frame(t, sysflowtest.Header("third")))
+	assert.NoError(t, err)
+	assert.Equal(t, "third", receive(t, pl, 1)[0].Rec.SFHeader.Exporter)
+}
+
+// writeCert creates a certificate signed by parent, or self-signed if parent is nil, and writes
+// its PEM encoded certificate and key to dir.
+func writeCert(t *testing.T, dir string, name string, tmpl *x509.Certificate, parent *tls.Certificate) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	issuer, signer := tmpl, interface{}(key)
+	if parent != nil {
+		issuer, signer = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, issuer, &key.PublicKey, signer)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name+".crt"), certPem, 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name+".key"), keyPem, 0600))
+	cert, err := tls.X509KeyPair(certPem, keyPem)
+	assert.NoError(t, err)
+	cert.Leaf, err = x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return cert
+}
+
+func TestTCPDriverTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tcpdriver")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ca := writeCert(t, dir, "ca", &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "ca"},
+		IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign}, nil)
+	writeCert(t, dir, "server", &x509.Certificate{SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "server"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")}, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}}, &ca)
+	client := writeCert(t, dir, "client", &x509.Certificate{SerialNumber: big.NewInt(3), Subject: pkix.Name{CommonName: "collector-1"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}, &ca)
+
+	path := "tls://127.0.0.1:0?cert=" + filepath.Join(dir, "server.crt") + "&key=" + filepath.Join(dir, "server.key") +
+		"&ca=" + filepath.Join(dir, "ca.crt")
+	d, pl, stop := startTCPDriver(t, path, nil)
+	defer stop()
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Leaf)
+
+	// collectors without a client certificate are rejected
+	conn, err := tls.Dial("tcp", d.addr(), &tls.Config{RootCAs: roots})
+	if err == nil {
+		conn.Write(frame(t, sysflowtest.Header("")))
+		_, err = conn.Read(make([]byte, 1))
+		conn.Close()
+	}
+	assert.Error(t, err)
+
+	// the client certificate common name identifies the collector
+	conn, err = tls.Dial("tcp", d.addr(), &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{client}})
+	assert.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write(frame(t, sysflowtest.Header(""), sysflowtest.Process(9, "/bin/tls")))
+	assert.NoError(t, err)
+	recs := receive(t, pl, 2)
+	assert.Equal(t, "collector-1", recs[0].Rec.SFHeader.Exporter)
+	assert.Equal(t, "127.0.0.1", recs[0].Rec.SFHeader.Ip)
+	assert.Equal(t, "/bin/tls", recs[1].Rec.Process.Exe)
+	stats := d.Stats()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, "collector-1", stats[0].Origin)
+}
@@ -0,0 +1,111 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package sysflow
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+)
+
+// timeLayouts are the accepted layouts of time window bounds, besides Unix timestamps in seconds.
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// parseTime parses a time window bound given as an RFC 3339 time, a UTC date and time, a UTC
+// date, or a Unix timestamp in seconds.
+func parseTime(v string) (time.Time, error) {
+	if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	for _, l := range timeLayouts {
+		if t, err := time.Parse(l, v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("expected an RFC 3339 time, a date, or a Unix timestamp")
+}
+
+// timeWindow selects records with timestamps in [from, to), in nanoseconds. A zero bound is open.
+type timeWindow struct {
+	from int64
+	to   int64
+}
+
+// newTimeWindow creates a time window from bounds in the formats accepted by parseTime.
+func newTimeWindow(from string, to string) (timeWindow, error) {
+	var w timeWindow
+	if from != "" {
+		t, err := parseTime(from)
+		if err != nil {
+			return w, err
+		}
+		w.from = t.UnixNano()
+	}
+	if to != "" {
+		t, err := parseTime(to)
+		if err != nil {
+			return w, err
+		}
+		w.to = t.UnixNano()
+	}
+	return w, nil
+}
+
+// bounded returns whether the window has a bound.
+func (w timeWindow) bounded() bool {
+	return w.from != 0 || w.to != 0
+}
+
+// before returns whether ts precedes the window.
+func (w timeWindow) before(ts int64) bool {
+	return w.from != 0 && ts < w.from
+}
+
+// after returns whether ts follows the window.
+func (w timeWindow) after(ts int64) bool {
+	return w.to != 0 && ts >= w.to
+}
+
+// contains returns whether a record overlaps the window. Headers and entities are always contained.
+func (w timeWindow) contains(sf *sfgo.SysFlow) bool {
+	ts, end, ok := recordTime(sf)
+	return !ok || !w.before(end) && !w.after(ts)
+}
+
+// recordTime returns the start and end timestamps of an event or flow record.
+func recordTime(sf *sfgo.SysFlow) (int64, int64, bool) {
+	switch sf.Rec.UnionType {
+	case sfgo.SF_PROC_EVT:
+		return sf.Rec.ProcessEvent.Ts, sf.Rec.ProcessEvent.Ts, true
+	case sfgo.SF_FILE_EVT:
+		return sf.Rec.FileEvent.Ts, sf.Rec.FileEvent.Ts, true
+	case sfgo.SF_NET_EVT:
+		return sf.Rec.NetworkEvent.Ts, sf.Rec.NetworkEvent.Ts, true
+	case sfgo.SF_PROC_FLOW:
+		return sf.Rec.ProcessFlow.Ts, sf.Rec.ProcessFlow.EndTs, true
+	case sfgo.SF_FILE_FLOW:
+		return sf.Rec.FileFlow.Ts, sf.Rec.FileFlow.EndTs, true
+	case sfgo.SF_NET_FLOW:
+		return sf.Rec.NetworkFlow.Ts, sf.Rec.NetworkFlow.EndTs, true
+	}
+	return 0, 0, false
+}